@@ -0,0 +1,18 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+type (
+	// Node is a node of SkipList, whose fields are unexported. An Allocator handles it as an opaque block of memory,
+	// such as an element of a slab. It has the memory layout of the node SkipList converts it to.
+	Node[O constraints.Ordered, T any] node[O, T]
+
+	// Allocator allocates the nodes of SkipList instead of the heap, set by WithAllocator.
+	// New must return a zeroed Node not in use. Free is passed a zeroed Node once it is unlinked and no Iterator or
+	// ReadView can reach it, and may hand it out by New again. Towers higher than the levels stored inline in a Node
+	// are still allocated on the heap. A concurrent SkipList calls them while holding its lock.
+	Allocator[O constraints.Ordered, T any] interface {
+		New() *Node[O, T]
+		Free(n *Node[O, T])
+	}
+)
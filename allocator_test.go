@@ -0,0 +1,148 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// slabAllocator carves nodes out of slabs and recycles freed ones, counting the calls.
+type slabAllocator struct {
+	slab      []Node[int, int]
+	free      []*Node[int, int]
+	news      int
+	frees     int
+	freeDirty int
+}
+
+func (a *slabAllocator) New() *Node[int, int] {
+	a.news++
+	if len(a.free) > 0 {
+		n := a.free[len(a.free)-1]
+		a.free = a.free[:len(a.free)-1]
+		return n
+	}
+	if len(a.slab) == cap(a.slab) {
+		a.slab = make([]Node[int, int], 0, 64)
+	}
+	a.slab = a.slab[:len(a.slab)+1]
+	return &a.slab[len(a.slab)-1]
+}
+
+func (a *slabAllocator) Free(n *Node[int, int]) {
+	a.frees++
+	if !reflect.DeepEqual(*n, Node[int, int]{}) {
+		a.freeDirty++
+	}
+	a.free = append(a.free, n)
+}
+
+func TestWithAllocator(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestWithAllocator 1", isConcurrent: false},
+		{name: "TestWithAllocator 2", isConcurrent: true, opts: []Option{WithArena(), WithNodePool()}},
+		{name: "TestWithAllocator 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+		{name: "TestWithAllocator 4", isConcurrent: false, opts: []Option{WithFinger()}},
+		{name: "TestWithAllocator 5", isConcurrent: false, opts: []Option{WithSafeIterators()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				a   = &slabAllocator{}
+				sl  = NewSkipList[int, int](0, tt.isConcurrent, append(tt.opts, WithAllocator[int, int](a))...)
+				ref = make(map[int]int)
+			)
+			for i := 0; i < 20000; i++ {
+				key := rnd.Intn(1000)
+				switch op := rnd.Intn(100); {
+				case op < 55:
+					sl.Put(key, i)
+					ref[key] = i
+				case op < 90:
+					sl.Delete(key)
+					delete(ref, key)
+				case op < 95:
+					sl.DeleteRange(key, key+5)
+					for k := key; k <= key+5; k++ {
+						delete(ref, k)
+					}
+				case op < 98:
+					sl.DeleteIf(func(_, val int) bool { return val%7 == 0 })
+					for k, v := range ref {
+						if v%7 == 0 {
+							delete(ref, k)
+						}
+					}
+				default:
+					sl.Compact()
+				}
+			}
+			sl.Compact()
+
+			for key, val := range ref {
+				if got, _ := sl.Get(key); got != val {
+					t.Fatalf("Get(%v) = %v, want %v", key, got, val)
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			// every node is allocated by a, and every unlinked one is freed zeroed
+			if live := a.news - a.frees; live != len(ref) || int(sl.Cap()) != len(ref) {
+				t.Errorf("New() - Free() = %v, Cap() = %v, want %v", live, sl.Cap(), len(ref))
+			}
+			if a.freeDirty != 0 {
+				t.Errorf("%d nodes are freed without being zeroed", a.freeDirty)
+			}
+			if sl.arena != nil {
+				t.Errorf("WithArena is not ignored")
+			}
+		})
+	}
+}
+
+func TestWithAllocator_Iterators(t *testing.T) {
+	var (
+		a  = &slabAllocator{}
+		sl = NewSkipList[int, int](10, false, WithSafeIterators(), WithAllocator[int, int](a))
+	)
+	for i := 0; i < 10; i++ {
+		sl.Put(i, i)
+	}
+
+	// nodes are freed once no open Iterator can reach them
+	it := sl.Iterator()
+	for i := 0; i < 5; i++ {
+		sl.Delete(i)
+	}
+	if a.frees != 0 {
+		t.Errorf("Free() called %d times while an Iterator is open", a.frees)
+	}
+	var keys []int
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if len(keys) != 6 || keys[0] != 0 || keys[1] != 5 {
+		t.Errorf("Iterator walked %v, want 0 and 5 to 9", keys)
+	}
+	it.Close()
+	if a.frees != 5 || a.news-a.frees != 5 {
+		t.Errorf("Free() called %d times after the Iterator is closed, want 5", a.frees)
+	}
+
+	// a freed node is allocated again
+	sl.Put(100, 100)
+	if a.news != 11 || len(a.slab) != 10 {
+		t.Errorf("New() called %d times with %d nodes carved, want 11 with 10", a.news, len(a.slab))
+	}
+
+	if sl := NewSkipList[int, string](10, false, WithAllocator[int, int](a)); sl != nil {
+		t.Errorf("NewSkipList() with a mismatched WithAllocator = %v, want nil", sl)
+	}
+}
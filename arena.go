@@ -0,0 +1,43 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// arenaChunk is the number of nodes or tower levels in a chunk of arena.
+const arenaChunk = 1024
+
+type (
+	// arena carves nodes and towers out of chunked backing slices.
+	// A chunk is released only when none of its nodes is referenced, so deleted nodes are not recycled.
+	arena[O constraints.Ordered, T any] struct {
+		nodes  []node[O, T]
+		towers []*node[O, T]
+	}
+)
+
+// reserve makes room for n nodes in one chunk.
+func (a *arena[O, T]) reserve(n int) {
+	if cap(a.nodes)-len(a.nodes) < n {
+		a.nodes = make([]node[O, T], 0, n)
+	}
+}
+
+func (a *arena[O, T]) newNode() *node[O, T] {
+	if len(a.nodes) == cap(a.nodes) {
+		a.nodes = make([]node[O, T], 0, arenaChunk)
+	}
+	a.nodes = a.nodes[:len(a.nodes)+1]
+	return &a.nodes[len(a.nodes)-1]
+}
+
+func (a *arena[O, T]) newTower(h int32) []*node[O, T] {
+	if cap(a.towers)-len(a.towers) < int(h) {
+		var size = arenaChunk
+		if int(h) > size {
+			size = int(h)
+		}
+		a.towers = make([]*node[O, T], 0, size)
+	}
+	var l = len(a.towers)
+	a.towers = a.towers[:l+int(h)]
+	return a.towers[l : l+int(h) : l+int(h)]
+}
@@ -0,0 +1,89 @@
+package skip_list
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// Batch is a group of Puts and Deletes applied together by ConcurrentSkipList.Apply.
+	// Only the last operation on a key is kept, and a Batch can be reused after Reset.
+	Batch[O constraints.Ordered, T any] struct {
+		ops []batchOp[O, T]
+
+		// index of the operation on a key in ops
+		index map[O]int
+	}
+
+	batchOp[O constraints.Ordered, T any] struct {
+		key    O
+		val    T
+		delete bool
+	}
+)
+
+var ErrBatchNotAtomic = errors.New("skip_list: batch cannot be applied atomically with optimistic locking")
+
+// NewBatch returns an empty Batch.
+func NewBatch[O constraints.Ordered, T any]() *Batch[O, T] {
+	return &Batch[O, T]{index: make(map[O]int)}
+}
+
+// Put puts val on key, replacing the previous operation on key.
+func (b *Batch[O, T]) Put(key O, val T) {
+	b.set(batchOp[O, T]{key: key, val: val})
+}
+
+// Delete deletes key, replacing the previous operation on key.
+func (b *Batch[O, T]) Delete(key O) {
+	b.set(batchOp[O, T]{key: key, delete: true})
+}
+
+// Cap returns the number of operations, one per key.
+func (b *Batch[O, T]) Cap() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.ops)
+}
+
+// Reset empties the Batch for reuse, keeping its allocations.
+func (b *Batch[O, T]) Reset() {
+	b.ops = b.ops[:0]
+	for key := range b.index {
+		delete(b.index, key)
+	}
+}
+
+func (b *Batch[O, T]) set(op batchOp[O, T]) {
+	if i, ok := b.index[op.key]; ok {
+		b.ops[i] = op
+		return
+	}
+	b.index[op.key] = len(b.ops)
+	b.ops = append(b.ops, op)
+}
+
+// Apply applies the operations of b under one write lock, so that no reader observes a part of them.
+// It returns ErrBatchNotAtomic WithOptimisticLocking, whose readers take no lock, without applying any.
+func (c *ConcurrentSkipList[O, T]) Apply(b *Batch[O, T]) error {
+	if c == nil || b.Cap() == 0 {
+		return nil
+	}
+	if c.opt != nil {
+		return ErrBatchNotAtomic
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, op := range b.ops {
+		if op.delete {
+			c.sl.Delete(op.key)
+		} else {
+			c.sl.Put(op.key, op.val)
+		}
+	}
+	return nil
+}
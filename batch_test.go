@@ -0,0 +1,120 @@
+package skip_list
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	b := NewBatch[int, int]()
+	b.Put(1, 10)
+	b.Put(2, 20)
+	b.Delete(1)
+	b.Put(3, 30)
+	b.Put(2, 200)
+	b.Delete(4)
+
+	t.Run("TestBatch 1", func(t *testing.T) {
+		if got := b.Cap(); got != 4 {
+			t.Errorf("Cap() = %v, want 4", got)
+		}
+
+		c := NewConcurrent[int, int](10)
+		c.Put(1, 1)
+		c.Put(4, 4)
+		c.Put(5, 5)
+		if err := c.Apply(b); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		// the last operation on each key is kept
+		if got, want := c.Items(), []*KvPair[int, int]{{2, 200}, {3, 30}, {5, 5}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestBatch 2", func(t *testing.T) {
+		b.Reset()
+		if got := b.Cap(); got != 0 {
+			t.Errorf("Cap() = %v, want 0", got)
+		}
+		b.Put(1, 1)
+		if got := b.Cap(); got != 1 {
+			t.Errorf("Cap() = %v, want 1", got)
+		}
+
+		c := NewConcurrent[int, int](10)
+		if err := c.Apply(b); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if got, want := c.Items(), []*KvPair[int, int]{{1, 1}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestBatch 3", func(t *testing.T) {
+		c := NewConcurrent[int, int](10, WithOptimisticLocking())
+		if err := c.Apply(b); err != ErrBatchNotAtomic {
+			t.Errorf("Apply() error = %v, want %v", err, ErrBatchNotAtomic)
+		}
+		if got := c.Cap(); got != 0 {
+			t.Errorf("Cap() = %v, want 0", got)
+		}
+
+		var nilC *ConcurrentSkipList[int, int]
+		if err := nilC.Apply(b); err != nil {
+			t.Errorf("Apply() error = %v on nil ConcurrentSkipList", err)
+		}
+	})
+}
+
+func TestConcurrentSkipList_Apply(t *testing.T) {
+	const (
+		markers = 8
+		batches = 2000
+	)
+
+	var (
+		c    = NewConcurrent[int, int](0, WithRangeChunk(markers*4))
+		wg   sync.WaitGroup
+		done int32
+	)
+	// every batch moves all markers from one generation to the next
+	for rd := 0; rd < 4; rd++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&done) == 0 {
+				kvs := c.Range(0, markers-1)
+				if len(kvs) != 0 && len(kvs) != markers {
+					t.Errorf("Range() = %v, want none or all markers", kvs)
+					return
+				}
+				for _, kv := range kvs {
+					if kv.val != kvs[0].val {
+						t.Errorf("Range() = %v, want markers of one batch", kvs)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	b := NewBatch[int, int]()
+	for i := 0; i < batches; i++ {
+		b.Reset()
+		for key := 0; key < markers; key++ {
+			if i%3 == 2 {
+				b.Delete(key)
+			} else {
+				b.Put(key, i)
+			}
+		}
+		if err := c.Apply(b); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+	atomic.StoreInt32(&done, 1)
+	wg.Wait()
+}
@@ -0,0 +1,430 @@
+package skip_list
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"reflect"
+)
+
+// The binary form of SkipList is a header followed by count entries in key order, an end marker
+// and the little-endian CRC-32 (IEEE) of all the bytes before it.
+//
+//	header:  magic "SKBN" | version 2 | kind of the key type | count uvarint
+//	entry:   key length uvarint | key | value length uvarint | value
+//	trailer: "SKBE" | crc u32
+//
+// Signed integers are varint encoded, unsigned integers uvarint encoded, floats are the little-endian bits of float64,
+// bools are 1 byte, and strings and []byte are their bytes. Values are encoded by the Codec of SkipList, which is
+// the built-in one of these types, JSONCodec of the others, or the one set by WithCodec.
+// Version 1 is the same without the trailer, which is still read.
+const (
+	binaryMagic    = "SKBN"
+	binaryEnd      = "SKBE"
+	binaryVersion  = 2
+	binaryVersion1 = 1
+)
+
+var (
+	ErrCorruptBinary      = errors.New("skip_list: corrupt binary data")
+	ErrBadMagic           = errors.New("skip_list: bad magic of binary data")
+	ErrUnsupportedVersion = errors.New("skip_list: unsupported binary version")
+	ErrTruncated          = errors.New("skip_list: truncated binary data")
+	ErrChecksum           = errors.New("skip_list: checksum mismatch of binary data")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding kv-pairs in key order.
+func (sl *SkipList[O, T]) MarshalBinary() ([]byte, error) {
+	if sl == nil {
+		return nil, nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var buf bytes.Buffer
+	if err := sl.encodeBinary(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing all nodes by the kv-pairs MarshalBinary encodes
+// in one pass as BulkLoad does. Malformed data leaves SkipList untouched, and ErrBadMagic, ErrUnsupportedVersion,
+// ErrTruncated, ErrChecksum or ErrCorruptBinary is returned. SkipList must be returned by NewSkipList,
+// whose maxLevel and options are kept.
+func (sl *SkipList[O, T]) UnmarshalBinary(data []byte) error {
+	if sl == nil {
+		return nil
+	}
+	if sl.head == nil {
+		return ErrNotInitialized
+	}
+
+	var (
+		r     = &countingReader{r: bytes.NewReader(data)}
+		pairs = make([]*KvPair[O, T], 0)
+	)
+	if err := sl.decodeBinary(r, func(key O, val T) error {
+		pairs = append(pairs, newKvPair(key, val))
+		return nil
+	}); err != nil {
+		return err
+	}
+	if r.n < int64(len(data)) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrCorruptBinary, int64(len(data))-r.n)
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.replaceAll(pairs)
+	return nil
+}
+
+// WriteTo implements io.WriterTo, writing kv-pairs in the form MarshalBinary encodes entry by entry through a buffer,
+// and returns the number of bytes written to w. A concurrent SkipList is read locked until all are written.
+func (sl *SkipList[O, T]) WriteTo(w io.Writer) (int64, error) {
+	if sl == nil {
+		return 0, nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		cw = &countingWriter{w: w}
+		bw = bufio.NewWriter(cw)
+	)
+	if err := sl.encodeBinary(bw); err != nil {
+		return cw.n, err
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ReadFrom implements io.ReaderFrom, replacing all nodes by kv-pairs in the form MarshalBinary encodes read from r
+// entry by entry through a buffer, and returns the number of bytes of the form read. The nodes are linked as they
+// are decoded, which requires strictly ascending keys, and replace SkipList only once all are read; a malformed or
+// failing read leaves SkipList untouched. The buffer may read from r past the end of the form.
+func (sl *SkipList[O, T]) ReadFrom(r io.Reader) (int64, error) {
+	if sl == nil {
+		return 0, nil
+	}
+	if sl.head == nil {
+		return 0, ErrNotInitialized
+	}
+
+	var (
+		cr    = &countingReader{r: bufio.NewReader(r)}
+		first = &node[O, T]{}
+		tail  = first
+		cap   int32
+	)
+	first.nextNodes = first.inline[:1]
+	if err := sl.decodeBinary(cr, func(key O, val T) error {
+		if tail != first && tail.key >= key {
+			return fmt.Errorf("%w: key %v is not ascending", ErrCorruptBinary, key)
+		}
+		n := &node[O, T]{key: key, val: val}
+		n.nextNodes = n.inline[:1]
+		tail.nextNodes[0] = n
+		tail = n
+		cap++
+		return nil
+	}); err != nil {
+		return cr.n, err
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.replaceChain(first.nextNodes[0], cap)
+	return cr.n, nil
+}
+
+// encodeBinary writes the header, the entries of all kv-pairs and the trailer to w.
+func (sl *SkipList[O, T]) encodeBinary(dst io.Writer) error {
+	var (
+		crc = crc32.NewIEEE()
+		w   = io.MultiWriter(dst, crc)
+	)
+
+	var buf = append([]byte(binaryMagic), binaryVersion, byte(binaryKind(typeOf[O]())))
+	buf = binary.AppendUvarint(buf, uint64(sl.cap))
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+
+	var key []byte
+	for n := sl.head.next(); n != nil; n = n.next() {
+		val, err := sl.codec.Encode(n.val)
+		if err != nil {
+			return fmt.Errorf("key %v: %w", n.key, err)
+		}
+
+		key = appendBuiltin(key[:0], reflect.ValueOf(n.key))
+		buf = binary.AppendUvarint(buf[:0], uint64(len(key)))
+		buf = append(buf, key...)
+		buf = binary.AppendUvarint(buf, uint64(len(val)))
+		buf = append(buf, val...)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte(binaryEnd)); err != nil {
+		return err
+	}
+	_, err := dst.Write(binary.LittleEndian.AppendUint32(nil, crc.Sum32()))
+	return err
+}
+
+// decodeBinary reads the header, the entries and the trailer from r, and calls fn for each kv-pair
+// until it returns an error. The checksum is verified after the last call of fn.
+func (sl *SkipList[O, T]) decodeBinary(r *countingReader, fn func(key O, val T) error) error {
+	r.crc = crc32.NewIEEE()
+
+	var header = make([]byte, len(binaryMagic)+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return r.fail("header")
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return ErrBadMagic
+	}
+	var version = header[len(binaryMagic)]
+	if version != binaryVersion && version != binaryVersion1 {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	if kind := reflect.Kind(header[len(binaryMagic)+1]); kind != binaryKind(typeOf[O]()) {
+		return fmt.Errorf("%w: key kind %v", ErrCorruptBinary, kind)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return r.fail("count")
+	}
+
+	var buf bytes.Buffer
+	for i := uint64(0); i < count; i++ {
+		var key O
+		if err := readBinaryField(r, &buf); err != nil {
+			return r.fail(fmt.Sprintf("key of entry %d", i))
+		}
+		if err := decodeBuiltin(buf.Bytes(), reflect.ValueOf(&key).Elem()); err != nil {
+			return fmt.Errorf("%w: key of entry %d", ErrCorruptBinary, i)
+		}
+
+		if err := readBinaryField(r, &buf); err != nil {
+			return r.fail(fmt.Sprintf("value of entry %d", i))
+		}
+		val, err := sl.codec.Decode(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("%w: value of entry %d: %v", ErrCorruptBinary, i, err)
+		}
+
+		if err := fn(key, val); err != nil {
+			return err
+		}
+	}
+	if version == binaryVersion1 {
+		return nil
+	}
+
+	var end = make([]byte, len(binaryEnd))
+	if _, err := io.ReadFull(r, end); err != nil {
+		return r.fail("end marker")
+	}
+	if string(end) != binaryEnd {
+		return fmt.Errorf("%w: end marker", ErrCorruptBinary)
+	}
+	var (
+		sum = r.crc.Sum32()
+		crc = make([]byte, 4)
+	)
+	if _, err := io.ReadFull(r, crc); err != nil {
+		return r.fail("checksum")
+	}
+	if binary.LittleEndian.Uint32(crc) != sum {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// readBinaryField reads a length-prefixed field from r into buf, which grows with the bytes read
+// rather than by the length, so that a corrupt length cannot exhaust memory.
+func readBinaryField(r *countingReader, buf *bytes.Buffer) error {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if n > math.MaxInt64 {
+		return ErrCorruptBinary
+	}
+
+	buf.Reset()
+	_, err = io.CopyN(buf, r, int64(n))
+	return err
+}
+
+// fail returns the error of r for a failing read of what, ErrTruncated if r reached io.EOF, or ErrCorruptBinary.
+func (cr *countingReader) fail(what string) error {
+	switch {
+	case cr.err != nil:
+		return fmt.Errorf("%s: %w", what, cr.err)
+	case cr.eof:
+		return fmt.Errorf("%w: %s", ErrTruncated, what)
+	}
+	return fmt.Errorf("%w: %s", ErrCorruptBinary, what)
+}
+
+type (
+	// countingReader counts the bytes read from r and hashes them by crc if not nil,
+	// and keeps whether r reached io.EOF and the first error of r other than it.
+	countingReader struct {
+		r interface {
+			io.Reader
+			io.ByteReader
+		}
+		n   int64
+		crc hash.Hash32
+		eof bool
+		err error
+	}
+
+	// countingWriter counts the bytes written to w.
+	countingWriter struct {
+		w io.Writer
+		n int64
+	}
+)
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	if cr.crc != nil {
+		cr.crc.Write(p[:n])
+	}
+	cr.keep(err)
+	return n, err
+}
+
+func (cr *countingReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.n++
+		if cr.crc != nil {
+			cr.crc.Write([]byte{b})
+		}
+	}
+	cr.keep(err)
+	return b, err
+}
+
+func (cr *countingReader) keep(err error) {
+	switch {
+	case err == io.EOF:
+		cr.eof = true
+	case err != nil && cr.err == nil:
+		cr.err = err
+	}
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// typeOf returns the type T, which may be an interface type.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// binaryKind returns the kind of t if it has a built-in codec, or reflect.Invalid.
+func binaryKind(t reflect.Type) reflect.Kind {
+	switch k := t.Kind(); k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return k
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return k
+		}
+	}
+	return reflect.Invalid
+}
+
+// appendBuiltin appends the built-in encoding of v, whose type must have a binaryKind.
+func appendBuiltin(dst []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(dst, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(dst, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(dst, math.Float64bits(v.Float()))
+	case reflect.String:
+		return append(dst, v.String()...)
+	case reflect.Bool:
+		if v.Bool() {
+			return append(dst, 1)
+		}
+		return append(dst, 0)
+	default:
+		return append(dst, v.Bytes()...)
+	}
+}
+
+// decodeBuiltin sets v to the value appendBuiltin encodes as b, and returns ErrCorruptBinary if b is malformed
+// or overflows the type of v.
+func decodeBuiltin(b []byte, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, k := binary.Varint(b)
+		if k != len(b) || v.OverflowInt(x) {
+			return ErrCorruptBinary
+		}
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, k := binary.Uvarint(b)
+		if k != len(b) || v.OverflowUint(x) {
+			return ErrCorruptBinary
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		if len(b) != 8 {
+			return ErrCorruptBinary
+		}
+		x := math.Float64frombits(binary.LittleEndian.Uint64(b))
+		if v.OverflowFloat(x) {
+			return ErrCorruptBinary
+		}
+		v.SetFloat(x)
+	case reflect.String:
+		v.SetString(string(b))
+	case reflect.Bool:
+		if len(b) != 1 || b[0] > 1 {
+			return ErrCorruptBinary
+		}
+		v.SetBool(b[0] == 1)
+	default:
+		v.SetBytes(append([]byte(nil), b...))
+	}
+	return nil
+}
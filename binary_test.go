@@ -0,0 +1,356 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"golang.org/x/exp/constraints"
+)
+
+// testBinaryRoundTrip marshals a SkipList of keys and values and unmarshals it into another.
+func testBinaryRoundTrip[O constraints.Ordered, T any](t *testing.T, keys []O, vals []T, opts ...Option) {
+	t.Helper()
+
+	src := NewSkipList[O, T](10, false, opts...)
+	for i := range keys {
+		src.Put(keys[i], vals[i])
+	}
+	data, err := src.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	dst := NewSkipList[O, T](0, true, opts...)
+	if err := dst.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if got, want := dst.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if err := dst.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+type binaryValue struct {
+	Name string
+	N    int
+}
+
+func TestSkipList_MarshalBinary(t *testing.T) {
+	t.Run("TestSkipList_MarshalBinary 1", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int{math.MinInt, -1, 0, 1, math.MaxInt}, []string{"a", "", "b", "c", "d"})
+	})
+	t.Run("TestSkipList_MarshalBinary 2", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int8{math.MinInt8, 0, math.MaxInt8}, []bool{true, false, true})
+	})
+	t.Run("TestSkipList_MarshalBinary 3", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int16{math.MinInt16, 0, math.MaxInt16}, []int16{1, 2, 3})
+	})
+	t.Run("TestSkipList_MarshalBinary 4", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int32{math.MinInt32, 0, math.MaxInt32}, [][]byte{{1, 2}, nil, {0}})
+	})
+	t.Run("TestSkipList_MarshalBinary 5", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int64{math.MinInt64, 0, math.MaxInt64}, []float64{-1.5, math.Inf(1), 0})
+	})
+	t.Run("TestSkipList_MarshalBinary 6", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uint{0, 1, math.MaxUint}, []uint{3, 2, 1})
+	})
+	t.Run("TestSkipList_MarshalBinary 7", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uint8{0, math.MaxUint8}, []uint8{1, 2})
+	})
+	t.Run("TestSkipList_MarshalBinary 8", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uint16{0, math.MaxUint16}, []int{1, 2})
+	})
+	t.Run("TestSkipList_MarshalBinary 9", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uint32{0, math.MaxUint32}, []int{1, 2})
+	})
+	t.Run("TestSkipList_MarshalBinary 10", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uint64{0, math.MaxUint64}, []int{1, 2})
+	})
+	t.Run("TestSkipList_MarshalBinary 11", func(t *testing.T) {
+		testBinaryRoundTrip(t, []uintptr{0, 1 << 20}, []int{1, 2})
+	})
+	t.Run("TestSkipList_MarshalBinary 12", func(t *testing.T) {
+		testBinaryRoundTrip(t, []float32{-math.MaxFloat32, -0.5, math.SmallestNonzeroFloat32, math.MaxFloat32}, []float32{1, 2, 3, 4})
+	})
+	t.Run("TestSkipList_MarshalBinary 13", func(t *testing.T) {
+		testBinaryRoundTrip(t, []float64{math.Inf(-1), -0.5, 1e300}, []int{1, 2, 3})
+	})
+	t.Run("TestSkipList_MarshalBinary 14", func(t *testing.T) {
+		testBinaryRoundTrip(t, []string{"", "a", "ab", "日本"}, []int{1, 2, 3, 4})
+	})
+	t.Run("TestSkipList_MarshalBinary 15", func(t *testing.T) {
+		testBinaryRoundTrip(t, []int{}, []int{})
+	})
+	t.Run("TestSkipList_MarshalBinary 16", func(t *testing.T) {
+		// a custom value codec
+		codec := WithValueCodec(
+			func(val binaryValue) ([]byte, error) { return json.Marshal(val) },
+			func(b []byte) (val binaryValue, err error) { err = json.Unmarshal(b, &val); return },
+		)
+		testBinaryRoundTrip(t, []int{3, 1, 2}, []binaryValue{{"c", 3}, {"a", 1}, {"b", 2}}, codec)
+	})
+
+	t.Run("TestSkipList_MarshalBinary 17", func(t *testing.T) {
+		// JSONCodec without a codec
+		testBinaryRoundTrip(t, []int{3, 1, 2}, []binaryValue{{"c", 3}, {"a", 1}, {"b", 2}})
+		if sl := NewSkipList[int, int](10, false, WithValueCodec[string](nil, nil)); sl != nil {
+			t.Errorf("NewSkipList() with a mismatched codec = %v, want nil", sl)
+		}
+	})
+}
+
+func TestSkipList_UnmarshalBinary(t *testing.T) {
+	src := NewSkipList[int, string](10, false)
+	for i := 0; i < 10; i++ {
+		src.Put(i*100, "v")
+	}
+	data, _ := src.MarshalBinary()
+
+	type testCase struct {
+		name    string
+		data    []byte
+		wantErr error
+	}
+	testCases := []testCase{
+		{name: "TestSkipList_UnmarshalBinary 1", data: nil, wantErr: ErrTruncated},
+		{name: "TestSkipList_UnmarshalBinary 2", data: append([]byte("SKBX"), data[4:]...), wantErr: ErrBadMagic},
+		{name: "TestSkipList_UnmarshalBinary 3", data: append([]byte("SKBN\x03"), data[5:]...), wantErr: ErrUnsupportedVersion},
+		{name: "TestSkipList_UnmarshalBinary 4", data: append([]byte("SKBN\x02\x18"), data[6:]...), wantErr: ErrCorruptBinary},
+		{name: "TestSkipList_UnmarshalBinary 5", data: data[:len(data)-1], wantErr: ErrTruncated},
+		{name: "TestSkipList_UnmarshalBinary 6", data: append(append([]byte(nil), data...), 0), wantErr: ErrCorruptBinary},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sl := NewSkipList[int, string](10, false)
+			sl.Put(7, "7")
+			if err := sl.UnmarshalBinary(tc.data); !errors.Is(err, tc.wantErr) {
+				t.Errorf("UnmarshalBinary() error = %v, want %v", err, tc.wantErr)
+			}
+			// untouched
+			if got, want := sl.Items(), []*KvPair[int, string]{{7, "7"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("TestSkipList_UnmarshalBinary 7", func(t *testing.T) {
+		var sl SkipList[int, string]
+		if err := sl.UnmarshalBinary(data); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("UnmarshalBinary() error = %v, want %v", err, ErrNotInitialized)
+		}
+	})
+
+	t.Run("TestSkipList_UnmarshalBinary 8", func(t *testing.T) {
+		// version 1 has no trailer
+		v1 := append([]byte("SKBN\x01"), data[5:len(data)-len(binaryEnd)-4]...)
+		sl := NewSkipList[int, string](10, false)
+		if err := sl.UnmarshalBinary(v1); err != nil {
+			t.Fatalf("UnmarshalBinary() error = %v", err)
+		}
+		if got, want := sl.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestSkipList_UnmarshalBinary 9", func(t *testing.T) {
+		// every single bit flipped is detected
+		for i := range data {
+			for bit := 0; bit < 8; bit++ {
+				flipped := append([]byte(nil), data...)
+				flipped[i] ^= 1 << bit
+				sl := NewSkipList[int, string](10, false)
+				sl.Put(7, "7")
+				err := sl.UnmarshalBinary(flipped)
+				if !errors.Is(err, ErrBadMagic) && !errors.Is(err, ErrUnsupportedVersion) && !errors.Is(err, ErrTruncated) &&
+					!errors.Is(err, ErrChecksum) && !errors.Is(err, ErrCorruptBinary) {
+					t.Fatalf("UnmarshalBinary() with bit %d of byte %d flipped error = %v", bit, i, err)
+				}
+				if sl.Cap() != 1 {
+					t.Fatalf("Cap() = %v with bit %d of byte %d flipped, want 1", sl.Cap(), bit, i)
+				}
+			}
+		}
+	})
+
+	t.Run("TestSkipList_UnmarshalBinary 10", func(t *testing.T) {
+		// every truncation is detected
+		for i := 0; i < len(data); i++ {
+			sl := NewSkipList[int, string](10, false)
+			sl.Put(7, "7")
+			if err := sl.UnmarshalBinary(data[:i]); !errors.Is(err, ErrTruncated) {
+				t.Fatalf("UnmarshalBinary() of %d bytes error = %v, want %v", i, err, ErrTruncated)
+			}
+			if sl.Cap() != 1 {
+				t.Fatalf("Cap() = %v after UnmarshalBinary() of %d bytes, want 1", sl.Cap(), i)
+			}
+		}
+	})
+}
+
+func FuzzSkipList_UnmarshalBinary(f *testing.F) {
+	src := NewSkipList[int16, string](10, false)
+	for i := int16(0); i < 20; i++ {
+		src.Put(i*7, string(rune('a'+i)))
+	}
+	data, _ := src.MarshalBinary()
+	f.Add(data)
+	f.Add(data[:len(data)/2])
+	f.Add([]byte("SKBN\x02\x04\xff\xff\xff\xff\x0f"))
+	f.Add([]byte("SKBN\x01\x04\x01\x01\x02\x01a"))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sl := NewSkipList[int16, string](10, false)
+		if err := sl.UnmarshalBinary(data); err != nil {
+			if sl.Cap() != 0 {
+				t.Fatalf("Cap() = %v after UnmarshalBinary() error = %v", sl.Cap(), err)
+			}
+			return
+		}
+		if err := sl.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		// decoded data encodes again
+		if _, err := sl.MarshalBinary(); err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+	})
+}
+
+// failingWriter fails once n bytes are written.
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errFailingWriter
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+var errFailingWriter = errors.New("failing writer")
+
+func TestSkipList_WriteTo_ReadFrom(t *testing.T) {
+	src := NewSkipList[string, []byte](0, false)
+	for i := 0; i < 5000; i++ {
+		src.Put(fmt.Sprintf("key%06d", i), bytes.Repeat([]byte{byte(i)}, i%17))
+	}
+	data, err := src.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	t.Run("TestSkipList_WriteTo_ReadFrom 1", func(t *testing.T) {
+		// through a pipe
+		pr, pw := io.Pipe()
+		go func() {
+			n, err := src.WriteTo(pw)
+			if n != int64(len(data)) {
+				t.Errorf("WriteTo() = %v, want %v", n, len(data))
+			}
+			pw.CloseWithError(err)
+		}()
+
+		dst := NewSkipList[string, []byte](10, true)
+		dst.Put("old", nil)
+		n, err := dst.ReadFrom(pr)
+		if err != nil || n != int64(len(data)) {
+			t.Fatalf("ReadFrom() = %v, %v, want %v, nil", n, err, len(data))
+		}
+		got, want := dst.Items(), src.Items()
+		if len(got) != len(want) {
+			t.Fatalf("ReadFrom() read %v kv-pairs, want %v", len(got), len(want))
+		}
+		for i := range got {
+			// an empty []byte decodes as nil
+			if got[i].Key() != want[i].Key() || !bytes.Equal(got[i].Val(), want[i].Val()) {
+				t.Fatalf("Items()[%v] = %v, want %v", i, got[i], want[i])
+			}
+		}
+		if err := dst.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("TestSkipList_WriteTo_ReadFrom 2", func(t *testing.T) {
+		// through chunked readers
+		for _, r := range []io.Reader{
+			iotest.OneByteReader(bytes.NewReader(data)),
+			iotest.HalfReader(bytes.NewReader(data)),
+			iotest.DataErrReader(bytes.NewReader(data)),
+		} {
+			dst := NewSkipList[string, []byte](0, false)
+			if n, err := dst.ReadFrom(r); err != nil || n != int64(len(data)) {
+				t.Fatalf("ReadFrom() = %v, %v, want %v, nil", n, err, len(data))
+			}
+			if got := dst.Cap(); got != src.Cap() {
+				t.Errorf("Cap() = %v, want %v", got, src.Cap())
+			}
+		}
+	})
+
+	t.Run("TestSkipList_WriteTo_ReadFrom 3", func(t *testing.T) {
+		// a writer failing mid-stream
+		w := &failingWriter{n: len(data) / 2}
+		n, err := src.WriteTo(w)
+		if !errors.Is(err, errFailingWriter) || n != int64(len(data)/2) {
+			t.Errorf("WriteTo() = %v, %v, want %v, %v", n, err, len(data)/2, errFailingWriter)
+		}
+	})
+
+	t.Run("TestSkipList_WriteTo_ReadFrom 4", func(t *testing.T) {
+		// a reader failing mid-stream
+		dst := NewSkipList[string, []byte](0, false)
+		dst.Put("old", nil)
+		r := io.MultiReader(bytes.NewReader(data[:1000]), iotest.ErrReader(iotest.ErrTimeout))
+		n, err := dst.ReadFrom(r)
+		if !errors.Is(err, iotest.ErrTimeout) || n != 1000 {
+			t.Errorf("ReadFrom() = %v, %v, want 1000, %v", n, err, iotest.ErrTimeout)
+		}
+		if got := dst.Cap(); got != 1 {
+			t.Errorf("Cap() = %v after a failing ReadFrom(), want 1", got)
+		}
+
+		// a truncated stream
+		n, err = dst.ReadFrom(bytes.NewReader(data[:1000]))
+		if !errors.Is(err, ErrTruncated) || n != 1000 {
+			t.Errorf("ReadFrom() = %v, %v, want 1000, %v", n, err, ErrTruncated)
+		}
+
+		// a checksum mismatch
+		corrupt := append([]byte(nil), data...)
+		corrupt[len(corrupt)-1] ^= 1
+		if _, err = dst.ReadFrom(bytes.NewReader(corrupt)); !errors.Is(err, ErrChecksum) {
+			t.Errorf("ReadFrom() error = %v, want %v", err, ErrChecksum)
+		}
+		if got := dst.Cap(); got != 1 {
+			t.Errorf("Cap() = %v after a failing ReadFrom(), want 1", got)
+		}
+	})
+
+	t.Run("TestSkipList_WriteTo_ReadFrom 5", func(t *testing.T) {
+		// keys not ascending are rejected by ReadFrom, and sorted by UnmarshalBinary
+		data := []byte("SKBN\x01\x18\x02")
+		for _, key := range []string{"b", "a"} {
+			data = append(data, 1, key[0], 1, key[0])
+		}
+		if _, err := NewSkipList[string, []byte](10, false).ReadFrom(bytes.NewReader(data)); !errors.Is(err, ErrCorruptBinary) {
+			t.Errorf("ReadFrom() error = %v, want %v", err, ErrCorruptBinary)
+		}
+		sl := NewSkipList[string, []byte](10, false)
+		if err := sl.UnmarshalBinary(data); err != nil || sl.Cap() != 2 {
+			t.Errorf("UnmarshalBinary() = %v, %v, want 2 keys", sl.Items(), err)
+		}
+	})
+}
@@ -0,0 +1,302 @@
+package skip_list
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// The sorted-block form of SkipList is a sequence of blocks of records in key order, an index block and a footer,
+// as a sorted run of an LSM tree.
+//
+//	block:  records | crc u32
+//	record: key length uvarint | key | value length uvarint | value
+//	index:  count uvarint | count * (first key length uvarint | first key | offset uvarint | length uvarint) | crc u32
+//	footer: index offset u64 | index length u64 | version 1 | magic "SKBL"
+//
+// Keys and values are the bytes encode returns, and keys must be strictly ascending by bytes.Compare.
+// crc is the little-endian CRC-32 (IEEE) of the bytes of the block before it, and the integers of the footer are
+// little-endian.
+const (
+	blocksMagic   = "SKBL"
+	blocksVersion = 1
+	blocksFooter  = 8 + 8 + 1 + len(blocksMagic)
+)
+
+var (
+	ErrBlockKeyOrder = errors.New("skip_list: encoded keys are not strictly ascending")
+	ErrCorruptBlock  = errors.New("skip_list: corrupt block")
+)
+
+type (
+	// BlockIndex is the first key, offset and length of each block WriteSortedBlocks writes, in key order.
+	BlockIndex []BlockHandle
+
+	// BlockHandle locates a block.
+	BlockHandle struct {
+		FirstKey       []byte
+		Offset, Length int64
+	}
+
+	// BlockReader iterates the records of one block in key order, which is read and verified as a whole.
+	//
+	//	br, err := NewBlockReader(r, index[i])
+	//	for br.Next() {
+	//		key, val := br.Key(), br.Value()
+	//	}
+	BlockReader struct {
+		data, key, val []byte
+		err            error
+	}
+)
+
+// WriteSortedBlocks writes kv-pairs in key order in the sorted-block form, encoded by encode, starting a block once the
+// records of the current one reach blockSize bytes, and returns the index of the blocks, which is written as well.
+// The encoded keys must be ordered as the keys are, such as the big-endian bytes of integers, otherwise
+// ErrBlockKeyOrder is returned.
+func (sl *SkipList[O, T]) WriteSortedBlocks(w io.Writer, blockSize int, encode func(key O, val T) ([]byte, []byte, error)) (BlockIndex, error) {
+	if sl == nil {
+		return nil, nil
+	}
+	if blockSize <= 0 {
+		return nil, fmt.Errorf("skip_list: invalid block size %d", blockSize)
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		bw      = bufio.NewWriter(w)
+		index   BlockIndex
+		offset  int64
+		block   []byte
+		prevKey []byte
+	)
+	// flush writes block with its checksum as the last block of index
+	flush := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		block = binary.LittleEndian.AppendUint32(block, crc32.ChecksumIEEE(block))
+		index[len(index)-1].Length = int64(len(block))
+		offset += int64(len(block))
+		_, err := bw.Write(block)
+		block = block[:0]
+		return err
+	}
+	for n := sl.head.next(); n != nil; n = n.next() {
+		key, val, err := encode(n.key, n.val)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", n.key, err)
+		}
+		if index != nil && bytes.Compare(prevKey, key) >= 0 {
+			return nil, fmt.Errorf("%w: key %v", ErrBlockKeyOrder, n.key)
+		}
+		prevKey = append(prevKey[:0], key...)
+
+		if len(block) == 0 {
+			index = append(index, BlockHandle{FirstKey: append([]byte(nil), key...), Offset: offset})
+		}
+		block = binary.AppendUvarint(block, uint64(len(key)))
+		block = append(block, key...)
+		block = binary.AppendUvarint(block, uint64(len(val)))
+		block = append(block, val...)
+		if len(block) >= blockSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	// index
+	var buf = binary.AppendUvarint(nil, uint64(len(index)))
+	for _, h := range index {
+		buf = binary.AppendUvarint(buf, uint64(len(h.FirstKey)))
+		buf = append(buf, h.FirstKey...)
+		buf = binary.AppendUvarint(buf, uint64(h.Offset))
+		buf = binary.AppendUvarint(buf, uint64(h.Length))
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+
+	// footer
+	footer := binary.LittleEndian.AppendUint64(nil, uint64(offset))
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(len(buf)))
+	footer = append(footer, blocksVersion)
+	footer = append(footer, blocksMagic...)
+
+	if _, err := bw.Write(append(buf, footer...)); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// ReadBlockIndex reads the index of the sorted-block form of size bytes from r, without reading the blocks.
+func ReadBlockIndex(r io.ReaderAt, size int64) (BlockIndex, error) {
+	if size < int64(blocksFooter) {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTruncated, size)
+	}
+	var footer = make([]byte, blocksFooter)
+	if n, err := r.ReadAt(footer, size-int64(blocksFooter)); n < len(footer) {
+		return nil, err
+	}
+	if string(footer[17:]) != blocksMagic {
+		return nil, ErrBadMagic
+	}
+	if version := footer[16]; version != blocksVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	var (
+		offset = binary.LittleEndian.Uint64(footer)
+		length = binary.LittleEndian.Uint64(footer[8:])
+	)
+	if offset > uint64(size) || length > uint64(size)-offset || offset+length != uint64(size-int64(blocksFooter)) {
+		return nil, fmt.Errorf("%w: index of %d bytes at %d", ErrCorruptBlock, length, offset)
+	}
+	data, err := readBlock(r, int64(offset), int64(length))
+	if err != nil {
+		return nil, err
+	}
+
+	count, k := binary.Uvarint(data)
+	if k <= 0 || count > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: index count", ErrCorruptBlock)
+	}
+	data = data[k:]
+	var (
+		index = make(BlockIndex, 0, count)
+		end   int64
+	)
+	for i := uint64(0); i < count; i++ {
+		var (
+			h     BlockHandle
+			field []byte
+			ok    bool
+		)
+		if field, data, ok = cutField(data); !ok {
+			return nil, fmt.Errorf("%w: first key of block %d", ErrCorruptBlock, i)
+		}
+		h.FirstKey = append([]byte(nil), field...)
+		off, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, fmt.Errorf("%w: offset of block %d", ErrCorruptBlock, i)
+		}
+		data = data[k:]
+		n, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, fmt.Errorf("%w: length of block %d", ErrCorruptBlock, i)
+		}
+		data = data[k:]
+		if off != uint64(end) || n < 4 || n > offset-off {
+			return nil, fmt.Errorf("%w: block %d of %d bytes at %d", ErrCorruptBlock, i, n, off)
+		}
+		h.Offset, h.Length = int64(off), int64(n)
+		end += h.Length
+		index = append(index, h)
+	}
+	if len(data) != 0 || uint64(end) != offset {
+		return nil, fmt.Errorf("%w: index does not cover the blocks", ErrCorruptBlock)
+	}
+	return index, nil
+}
+
+// Search returns the index of the block whose records may hold key, which is the last block whose first key is less
+// than or equal to key, or -1 if key is less than every first key.
+func (ix BlockIndex) Search(key []byte) int {
+	return sort.Search(len(ix), func(i int) bool { return bytes.Compare(ix[i].FirstKey, key) > 0 }) - 1
+}
+
+// NewBlockReader returns a BlockReader of the block h of r, which is read and verified by its checksum.
+func NewBlockReader(r io.ReaderAt, h BlockHandle) (*BlockReader, error) {
+	data, err := readBlock(r, h.Offset, h.Length)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockReader{data: data}, nil
+}
+
+// Next advances to the next record and returns whether it exists. A malformed record stops the iteration with Err.
+func (br *BlockReader) Next() bool {
+	if br.err != nil || len(br.data) == 0 {
+		return false
+	}
+
+	var ok bool
+	if br.key, br.data, ok = cutField(br.data); ok {
+		br.val, br.data, ok = cutField(br.data)
+	}
+	if !ok {
+		br.err = ErrCorruptBlock
+		br.data, br.key, br.val = nil, nil, nil
+	}
+	return ok
+}
+
+// Seek advances to the first record whose key is greater than or equal to key and returns whether it exists.
+func (br *BlockReader) Seek(key []byte) bool {
+	if br.key != nil && bytes.Compare(br.key, key) >= 0 {
+		return true
+	}
+	for br.Next() {
+		if bytes.Compare(br.key, key) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the key of the current record, which is valid until the block is garbage collected.
+func (br *BlockReader) Key() []byte {
+	return br.key
+}
+
+// Value returns the value of the current record, which is valid until the block is garbage collected.
+func (br *BlockReader) Value() []byte {
+	return br.val
+}
+
+// Err returns the error of a malformed record.
+func (br *BlockReader) Err() error {
+	return br.err
+}
+
+// readBlock reads length bytes at offset of r, and returns them without the checksum after verifying it.
+func readBlock(r io.ReaderAt, offset, length int64) ([]byte, error) {
+	if length < 4 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrCorruptBlock, length)
+	}
+	var data = make([]byte, length)
+	if n, err := r.ReadAt(data, offset); n < len(data) {
+		if err == io.EOF {
+			err = ErrTruncated
+		}
+		return nil, err
+	}
+	data, crc := data[:length-4], data[length-4:]
+	if binary.LittleEndian.Uint32(crc) != crc32.ChecksumIEEE(data) {
+		return nil, ErrChecksum
+	}
+	return data, nil
+}
+
+// cutField returns the length-prefixed field at the start of b and the rest of b, or false if it is malformed.
+func cutField(b []byte) (field, rest []byte, ok bool) {
+	n, k := binary.Uvarint(b)
+	if k <= 0 || n > uint64(len(b)-k) {
+		return nil, nil, false
+	}
+	return b[k : k+int(n)], b[k+int(n):], true
+}
@@ -0,0 +1,194 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// encodeBlockRecord encodes the key as big-endian bytes, which are ordered as uint64 keys are.
+func encodeBlockRecord(key uint64, val string) ([]byte, []byte, error) {
+	return binary.BigEndian.AppendUint64(nil, key), []byte(val), nil
+}
+
+// getBlockRecord searches key in the block the index points to.
+func getBlockRecord(t *testing.T, r *bytes.Reader, index BlockIndex, key []byte) ([]byte, bool) {
+	t.Helper()
+
+	i := index.Search(key)
+	if i < 0 {
+		return nil, false
+	}
+	br, err := NewBlockReader(r, index[i])
+	if err != nil {
+		t.Fatalf("NewBlockReader() error = %v", err)
+	}
+	if !br.Seek(key) || !bytes.Equal(br.Key(), key) {
+		if br.Err() != nil {
+			t.Fatalf("Seek() error = %v", br.Err())
+		}
+		return nil, false
+	}
+	return br.Value(), true
+}
+
+func TestSkipList_WriteSortedBlocks(t *testing.T) {
+	var (
+		rnd = rand.New(rand.NewSource(1))
+		src = NewSkipList[uint64, string](0, false)
+	)
+	for i := 0; i < 20000; i++ {
+		key := rnd.Uint64()
+		src.Put(key, string(bytes.Repeat([]byte{byte('a' + key%26)}, int(key%40))))
+	}
+
+	type testCase struct {
+		name      string
+		blockSize int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_WriteSortedBlocks 1", blockSize: 4096},
+		{name: "TestSkipList_WriteSortedBlocks 2", blockSize: 1},
+		{name: "TestSkipList_WriteSortedBlocks 3", blockSize: 1 << 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			index, err := src.WriteSortedBlocks(&buf, tt.blockSize, encodeBlockRecord)
+			if err != nil {
+				t.Fatalf("WriteSortedBlocks() error = %v", err)
+			}
+
+			r := bytes.NewReader(buf.Bytes())
+			read, err := ReadBlockIndex(r, r.Size())
+			if err != nil {
+				t.Fatalf("ReadBlockIndex() error = %v", err)
+			}
+			if len(read) != len(index) {
+				t.Fatalf("ReadBlockIndex() = %v blocks, want %v", len(read), len(index))
+			}
+			for i := range index {
+				if !bytes.Equal(read[i].FirstKey, index[i].FirstKey) || read[i].Offset != index[i].Offset || read[i].Length != index[i].Length {
+					t.Fatalf("ReadBlockIndex()[%d] = %v, want %v", i, read[i], index[i])
+				}
+				// blocks reach the target size except the last one
+				if i < len(index)-1 && index[i].Length-4 < int64(tt.blockSize) {
+					t.Errorf("block %d has %d bytes, want at least %d", i, index[i].Length-4, tt.blockSize)
+				}
+			}
+
+			// random keys, present or not
+			items := src.Items()
+			for q := 0; q < 2000; q++ {
+				var (
+					key       uint64
+					want      string
+					wantExist bool
+				)
+				if q%2 == 0 {
+					kv := items[rnd.Intn(len(items))]
+					key, want, wantExist = kv.Key(), kv.Val(), true
+				} else {
+					key = rnd.Uint64()
+					want, wantExist = src.Get(key)
+				}
+				got, exist := getBlockRecord(t, r, read, binary.BigEndian.AppendUint64(nil, key))
+				if exist != wantExist || string(got) != want {
+					t.Fatalf("record of %v = %q, %v, want %q, %v", key, got, exist, want, wantExist)
+				}
+			}
+
+			// every record in order
+			var count int
+			for i := range read {
+				br, err := NewBlockReader(r, read[i])
+				if err != nil {
+					t.Fatalf("NewBlockReader() error = %v", err)
+				}
+				for br.Next() {
+					kv := items[count]
+					if binary.BigEndian.Uint64(br.Key()) != kv.Key() || string(br.Value()) != kv.Val() {
+						t.Fatalf("record %d = %x, %q, want %v, %q", count, br.Key(), br.Value(), kv.Key(), kv.Val())
+					}
+					count++
+				}
+				if br.Err() != nil {
+					t.Fatalf("Next() error = %v", br.Err())
+				}
+			}
+			if count != len(items) {
+				t.Errorf("%d records, want %d", count, len(items))
+			}
+		})
+	}
+}
+
+func TestSkipList_WriteSortedBlocks_Errors(t *testing.T) {
+	sl := NewSkipList[uint64, string](10, false)
+	for i := uint64(0); i < 100; i++ {
+		sl.Put(i, "v")
+	}
+	var buf bytes.Buffer
+	if _, err := sl.WriteSortedBlocks(&buf, 64, encodeBlockRecord); err != nil {
+		t.Fatalf("WriteSortedBlocks() error = %v", err)
+	}
+	data := buf.Bytes()
+
+	t.Run("TestSkipList_WriteSortedBlocks_Errors 1", func(t *testing.T) {
+		// duplicate encoded keys
+		_, err := sl.WriteSortedBlocks(&bytes.Buffer{}, 64, func(key uint64, val string) ([]byte, []byte, error) {
+			return []byte{byte(key / 10)}, nil, nil
+		})
+		if !errors.Is(err, ErrBlockKeyOrder) {
+			t.Errorf("WriteSortedBlocks() error = %v, want %v", err, ErrBlockKeyOrder)
+		}
+		if _, err := sl.WriteSortedBlocks(&bytes.Buffer{}, 0, encodeBlockRecord); err == nil {
+			t.Errorf("WriteSortedBlocks() with block size 0 error = nil")
+		}
+	})
+
+	t.Run("TestSkipList_WriteSortedBlocks_Errors 2", func(t *testing.T) {
+		// an empty list
+		var buf bytes.Buffer
+		if _, err := NewSkipList[uint64, string](10, false).WriteSortedBlocks(&buf, 64, encodeBlockRecord); err != nil {
+			t.Fatalf("WriteSortedBlocks() error = %v", err)
+		}
+		index, err := ReadBlockIndex(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil || len(index) != 0 || index.Search([]byte{1}) != -1 {
+			t.Errorf("ReadBlockIndex() = %v, %v, want no blocks", index, err)
+		}
+	})
+
+	t.Run("TestSkipList_WriteSortedBlocks_Errors 3", func(t *testing.T) {
+		corrupt := func(i int) []byte {
+			b := append([]byte(nil), data...)
+			b[i] ^= 1
+			return b
+		}
+		type testCase struct {
+			data    []byte
+			wantErr error
+		}
+		for i, tc := range []testCase{
+			{data: data[:10], wantErr: ErrTruncated},
+			{data: corrupt(len(data) - 1), wantErr: ErrBadMagic},
+			{data: corrupt(len(data) - 5), wantErr: ErrUnsupportedVersion},
+			{data: corrupt(len(data) - blocksFooter - 5), wantErr: ErrChecksum},
+			{data: corrupt(len(data) - blocksFooter), wantErr: ErrCorruptBlock},
+			{data: data[1:], wantErr: ErrCorruptBlock},
+		} {
+			if _, err := ReadBlockIndex(bytes.NewReader(tc.data), int64(len(tc.data))); !errors.Is(err, tc.wantErr) {
+				t.Errorf("ReadBlockIndex() of case %d error = %v, want %v", i, err, tc.wantErr)
+			}
+		}
+
+		// a flipped bit in a block
+		r := bytes.NewReader(data)
+		index, _ := ReadBlockIndex(r, r.Size())
+		if _, err := NewBlockReader(bytes.NewReader(corrupt(int(index[1].Offset))), index[1]); !errors.Is(err, ErrChecksum) {
+			t.Errorf("NewBlockReader() error = %v, want %v", err, ErrChecksum)
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package skip_list
+
+import (
+	"errors"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrReplaceNotAtomic = errors.New("skip_list: nodes cannot be replaced atomically with optimistic locking")
+	ErrUnsortedPairs    = errors.New("skip_list: pairs are not in strictly ascending order of keys")
+)
+
+// BulkLoad returns a SkipList of pairs built in one pass without searching, whose towers are as Compact builds.
+// Unsorted pairs are sorted first, and the last one of duplicate keys wins.
+func BulkLoad[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, pairs []*KvPair[O, T], opts ...Option) *SkipList[O, T] {
+	sl := NewSkipList[O, T](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+
+	sl.bulkLoad(pairs)
+	return sl
+}
+
+// bulkLoad links pairs into an empty SkipList.
+func (sl *SkipList[O, T]) bulkLoad(pairs []*KvPair[O, T]) {
+	less := func(i, j int) bool { return pairs[i].key < pairs[j].key }
+	if !sort.SliceIsSorted(pairs, less) {
+		pairs = append([]*KvPair[O, T](nil), pairs...)
+		sort.SliceStable(pairs, less)
+	}
+
+	if sl.arena != nil {
+		sl.arena.reserve(len(pairs))
+	}
+
+	var tail = sl.head
+	for i, kv := range pairs {
+		if i+1 < len(pairs) && pairs[i+1].key == kv.key {
+			// the last one wins
+			continue
+		}
+		tail = sl.pushBack(tail, kv.key, kv.val)
+	}
+	sl.build()
+}
+
+// ReplaceAll replaces all nodes by pairs in one pass as BulkLoad builds them, keeping maxLevel and the options.
+// Unsorted pairs are sorted first, and the last one of duplicate keys wins.
+func (sl *SkipList[O, T]) ReplaceAll(pairs []*KvPair[O, T]) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.replaceAll(pairs)
+}
+
+func (sl *SkipList[O, T]) replaceAll(pairs []*KvPair[O, T]) {
+	if sl.mvcc {
+		// keep the history
+		sl.deleteIf(func(O, T) bool { return true })
+		for _, kv := range pairs {
+			sl.put(nil, kv.key, kv.val)
+		}
+		return
+	}
+
+	sl.replacing()
+	sl.clear()
+	sl.bulkLoad(pairs)
+	sl.replaced()
+}
+
+// replaceChain replaces all nodes by the cap nodes from first linked on level 0 in strictly ascending key order.
+func (sl *SkipList[O, T]) replaceChain(first *node[O, T], cap int32) {
+	if sl.mvcc {
+		// keep the history
+		sl.deleteIf(func(O, T) bool { return true })
+		for n := first; n != nil; n = n.nextNodes[0] {
+			sl.put(nil, n.key, n.val)
+		}
+		return
+	}
+
+	sl.replacing()
+	sl.clear()
+	sl.head.nextNodes[0] = first
+	sl.cap = cap
+	sl.build()
+	sl.replaced()
+}
+
+// replacing invokes OnDelete for the nodes to be replaced, before clear marks them for open Iterators, which next skips.
+func (sl *SkipList[O, T]) replacing() {
+	if sl.hooks.OnDelete != nil {
+		for n := sl.head.next(); n != nil; n = n.next() {
+			sl.hooks.delete(n.key, n.val)
+		}
+	}
+}
+
+// replaced invokes OnInsert and appends the log records of replacing the previous nodes by the nodes of SkipList.
+func (sl *SkipList[O, T]) replaced() {
+	sl.log.clear()
+	if sl.hooks.OnInsert != nil || sl.log != nil {
+		for n := sl.head.next(); n != nil; n = n.next() {
+			sl.inserted(n.key, n.val)
+		}
+	}
+}
+
+// PutSorted puts pairs in strictly ascending order of keys. If every key is greater than the keys of all nodes,
+// including tombstones, the new nodes are linked after the last node on every level without searching.
+// Otherwise each pair is put searching from the node of the previous one. It returns ErrUnsortedPairs without
+// putting any if pairs are not in strictly ascending order of keys.
+func (sl *SkipList[O, T]) PutSorted(pairs []*KvPair[O, T]) error {
+	if sl == nil {
+		return nil
+	}
+	for i := 1; i < len(pairs); i++ {
+		if pairs[i-1].key >= pairs[i].key {
+			return ErrUnsortedPairs
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	sl.reclaimStaleViews()
+
+	// tune before the tails are found, as it may truncate the towers
+	sl.tune(sl.cap + int32(len(pairs)))
+
+	var (
+		tails = make([]*node[O, T], sl.maxLevel+1)
+		move  = sl.head
+	)
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+		tails[l] = move
+
+		// search down
+	}
+
+	if (move != sl.head && move.key >= pairs[0].key) || sl.keepsHistory() {
+		var hint *node[O, T]
+		for _, kv := range pairs {
+			hint = sl.put(hint, kv.key, kv.val)
+		}
+		return nil
+	}
+
+	// nodes of finger may precede the appended keys
+	sl.resetFinger()
+	for _, kv := range pairs {
+		randL := sl.randLevel()
+		for l := sl.level; l <= randL; l++ {
+			tails[l] = sl.head
+		}
+		sl.grow(randL + 1)
+
+		n := sl.newNode(kv.key, kv.val, randL+1)
+		for l := int32(0); l <= randL; l++ {
+			// append
+			tails[l].nextNodes[l] = n
+			tails[l] = n
+		}
+		sl.cap++
+		sl.inserted(kv.key, kv.val)
+	}
+	return nil
+}
+
+// ReplaceAll replaces all nodes by pairs under one write lock, so that no reader observes a part of them.
+// It returns ErrReplaceNotAtomic WithOptimisticLocking, whose readers take no lock, without replacing any.
+func (c *ConcurrentSkipList[O, T]) ReplaceAll(pairs []*KvPair[O, T]) error {
+	if c == nil {
+		return nil
+	}
+	if c.opt != nil {
+		return ErrReplaceNotAtomic
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sl.ReplaceAll(pairs)
+	return nil
+}
@@ -0,0 +1,390 @@
+package skip_list
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestBulkLoad(t *testing.T) {
+	type args[O constraints.Ordered, T any] struct {
+		maxLevel int32
+		pairs    []*KvPair[O, T]
+		opts     []Option
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name    string
+		args    args[O, T]
+		want    []*KvPair[O, T]
+		wantNil bool
+	}
+
+	var sorted = make([]*KvPair[int, int], 0)
+	for i := 0; i < 1000; i++ {
+		sorted = append(sorted, NewKvPair(i, i))
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name:    "TestBulkLoad 1",
+			args:    args[int, int]{maxLevel: -1},
+			wantNil: true,
+		},
+		{
+			name: "TestBulkLoad 2",
+			args: args[int, int]{maxLevel: 10},
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestBulkLoad 3",
+			args: args[int, int]{maxLevel: 10, pairs: sorted},
+			want: sorted,
+		},
+		{
+			name: "TestBulkLoad 4",
+			args: args[int, int]{maxLevel: 10, pairs: []*KvPair[int, int]{{3, 3}, {1, 1}, {2, 2}, {1, 10}}},
+			want: []*KvPair[int, int]{{1, 10}, {2, 2}, {3, 3}},
+		},
+		{
+			name: "TestBulkLoad 5",
+			args: args[int, int]{maxLevel: 0, pairs: sorted, opts: []Option{WithArena()}},
+			want: sorted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := BulkLoad(tt.args.maxLevel, false, tt.args.pairs, tt.args.opts...)
+			if tt.wantNil {
+				if sl != nil {
+					t.Errorf("BulkLoad() = %v, want nil", sl)
+				}
+				return
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BulkLoad().Items() = %v, want %v", got, tt.want)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("BulkLoad().Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWithArena(t *testing.T) {
+	var pairs = make([]*KvPair[int, int], 0)
+	for i := 0; i < 5000; i += 2 {
+		pairs = append(pairs, NewKvPair(i, i))
+	}
+
+	var sl = BulkLoad(16, false, pairs, WithArena())
+	for i := 1; i < 5000; i += 2 {
+		sl.Put(i, i)
+	}
+	for i := 0; i < 5000; i += 3 {
+		sl.Delete(i)
+	}
+
+	t.Run("TestWithArena 1", func(t *testing.T) {
+		if err := sl.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		for i := 0; i < 5000; i++ {
+			v, ok := sl.Get(i)
+			if want := i%3 != 0; ok != want || (ok && v != i) {
+				t.Fatalf("Get(%v) = %v, %v, want %v", i, v, ok, want)
+			}
+		}
+	})
+
+	t.Run("TestWithArena 2", func(t *testing.T) {
+		sl.Clear()
+		if got := sl.Cap(); got != 0 {
+			t.Errorf("Cap() = %v, want 0", got)
+		}
+		if got := len(sl.arena.nodes); got != 0 {
+			t.Errorf("arena has %v nodes, want 0", got)
+		}
+		sl.Put(1, 1)
+		if v, ok := sl.Get(1); !ok || v != 1 {
+			t.Errorf("Get() = %v, %v, want 1, true", v, ok)
+		}
+	})
+}
+
+func TestSkipList_ReplaceAll(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+	}
+	testCases := []testCase{
+		{name: "TestSkipList_ReplaceAll 1", maxLevel: 10},
+		{name: "TestSkipList_ReplaceAll 2", maxLevel: 0},
+		{name: "TestSkipList_ReplaceAll 3", maxLevel: 10, opts: []Option{WithArena(), WithTombstones()}},
+		{name: "TestSkipList_ReplaceAll 4", maxLevel: 10, opts: []Option{WithMVCC()}},
+	}
+
+	var (
+		pairs = []*KvPair[int, int]{{5, 5}, {3, 3}, {4, 4}, {3, 30}}
+		want  = []*KvPair[int, int]{{3, 30}, {4, 4}, {5, 5}}
+	)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted, inserted []int
+			hooks := Hooks[int, int]{
+				OnInsert: func(key, _ int) { inserted = append(inserted, key) },
+				OnDelete: func(key, _ int) { deleted = append(deleted, key) },
+			}
+			sl := NewSkipList[int, int](tc.maxLevel, true, append(tc.opts, WithHooks(hooks))...)
+			for i := 0; i < 3; i++ {
+				sl.Put(i, i)
+			}
+			sl.Delete(2)
+			inserted = nil
+
+			sl.ReplaceAll(pairs)
+			if got := sl.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+			if got := sl.Cap(); got != 3 {
+				t.Errorf("Cap() = %v, want 3", got)
+			}
+			if got := sl.maxLevel; tc.maxLevel != 0 && got != tc.maxLevel {
+				t.Errorf("maxLevel = %v, want %v", got, tc.maxLevel)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if want := []int{0, 1}; !reflect.DeepEqual(deleted[1:], want) {
+				t.Errorf("OnDelete called with %v, want %v", deleted[1:], want)
+			}
+			if len(inserted) != 3 {
+				t.Errorf("OnInsert called with %v, want 3 keys", inserted)
+			}
+
+			sl.ReplaceAll(nil)
+			if got := sl.Cap(); got != 0 {
+				t.Errorf("Cap() = %v after ReplaceAll(nil), want 0", got)
+			}
+		})
+	}
+}
+
+func TestSkipList_PutSorted(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+		existing []int
+		deleted  []int
+		keys     []int
+		want     []int
+	}
+	testCases := []testCase{
+		{name: "TestSkipList_PutSorted 1", maxLevel: 10, keys: []int{1, 2, 3}, want: []int{1, 2, 3}},
+		{name: "TestSkipList_PutSorted 2", maxLevel: 10, existing: []int{1, 2}, keys: []int{3, 4, 5}, want: []int{1, 2, 3, 4, 5}},
+		{name: "TestSkipList_PutSorted 3", maxLevel: 0, existing: []int{1, 2}, keys: []int{3, 4, 5}, want: []int{1, 2, 3, 4, 5}},
+		{name: "TestSkipList_PutSorted 4", maxLevel: 10, opts: []Option{WithFinger()}, existing: []int{1, 9}, keys: []int{10, 11}, want: []int{1, 9, 10, 11}},
+		{name: "TestSkipList_PutSorted 5", maxLevel: 10, opts: []Option{WithArena()}, existing: []int{1, 2}, keys: []int{3}, want: []int{1, 2, 3}},
+		// fallback
+		{name: "TestSkipList_PutSorted 6", maxLevel: 10, existing: []int{2, 4, 6}, keys: []int{1, 4, 5, 7}, want: []int{1, 2, 4, 5, 6, 7}},
+		{name: "TestSkipList_PutSorted 7", maxLevel: 0, opts: []Option{WithFinger()}, existing: []int{2, 4, 6}, keys: []int{3, 8}, want: []int{2, 3, 4, 6, 8}},
+		{name: "TestSkipList_PutSorted 8", maxLevel: 10, opts: []Option{WithTombstones()}, existing: []int{2, 4, 6}, deleted: []int{6}, keys: []int{5, 7}, want: []int{2, 4, 5, 7}},
+		{name: "TestSkipList_PutSorted 9", maxLevel: 10, opts: []Option{WithMVCC()}, existing: []int{2}, keys: []int{3, 4}, want: []int{2, 3, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inserted []int
+			hooks := Hooks[int, int]{
+				OnInsert: func(key, _ int) { inserted = append(inserted, key) },
+			}
+			sl := NewSkipList[int, int](tc.maxLevel, true, append(tc.opts, WithHooks(hooks))...)
+			for _, key := range tc.existing {
+				sl.Put(key, 0)
+			}
+			for _, key := range tc.deleted {
+				sl.Delete(key)
+			}
+			// the finger is after the appended keys
+			sl.Get(100)
+			inserted = nil
+
+			var pairs []*KvPair[int, int]
+			for _, key := range tc.keys {
+				pairs = append(pairs, NewKvPair(key, key*10))
+			}
+			if err := sl.PutSorted(pairs); err != nil {
+				t.Fatalf("PutSorted() error = %v", err)
+			}
+
+			var keys []int
+			for _, kv := range sl.Items() {
+				keys = append(keys, kv.Key())
+			}
+			if !reflect.DeepEqual(keys, tc.want) {
+				t.Errorf("keys = %v, want %v", keys, tc.want)
+			}
+			for _, key := range tc.keys {
+				if val, _ := sl.Get(key); val != key*10 {
+					t.Errorf("Get(%v) = %v, want %v", key, val, key*10)
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if len(inserted) != len(tc.want)-len(tc.existing)+len(tc.deleted) {
+				t.Errorf("OnInsert called with %v", inserted)
+			}
+		})
+	}
+
+	sl := NewSkipList[int, int](10, false)
+	sl.Put(1, 1)
+	for _, pairs := range [][]*KvPair[int, int]{{{3, 3}, {2, 2}}, {{2, 2}, {2, 3}}} {
+		if err := sl.PutSorted(pairs); err != ErrUnsortedPairs {
+			t.Errorf("PutSorted(%v) error = %v, want %v", pairs, err, ErrUnsortedPairs)
+		}
+	}
+	if sl.Cap() != 1 {
+		t.Errorf("Cap() = %v after unsorted PutSorted, want 1", sl.Cap())
+	}
+	if err := sl.PutSorted(nil); err != nil || sl.Cap() != 1 {
+		t.Errorf("PutSorted(nil) error = %v, Cap() = %v", err, sl.Cap())
+	}
+
+	// many appended batches keep the towers random
+	sl = NewSkipList[int, int](0, false)
+	for batch := 0; batch < 100; batch++ {
+		var pairs []*KvPair[int, int]
+		for i := 0; i < 1000; i++ {
+			pairs = append(pairs, NewKvPair(batch*1000+i, i))
+		}
+		sl.PutSorted(pairs)
+	}
+	if err := sl.Validate(); err != nil || sl.Cap() != 100000 || sl.Level() < 10 {
+		t.Errorf("Validate() error = %v, Cap() = %v, Level() = %v", err, sl.Cap(), sl.Level())
+	}
+}
+
+func BenchmarkSkipList_PutSorted(b *testing.B) {
+	const n = 100000
+
+	var pairs = make([]*KvPair[int, int], n)
+	for i := range pairs {
+		pairs[i] = NewKvPair(i, i)
+	}
+
+	b.Run("Put", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl := NewSkipList[int, int](0, false)
+			for _, kv := range pairs {
+				sl.Put(kv.key, kv.val)
+			}
+		}
+	})
+	b.Run("PutSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl := NewSkipList[int, int](0, false)
+			sl.PutSorted(pairs)
+		}
+	})
+}
+
+func TestConcurrentSkipList_ReplaceAll(t *testing.T) {
+	const n, gens = 256, 20
+
+	t.Run("TestConcurrentSkipList_ReplaceAll 1", func(t *testing.T) {
+		c := NewConcurrent[int, int](16)
+		var (
+			wg   sync.WaitGroup
+			done = make(chan struct{})
+		)
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					// one chunk, read under one read lock
+					items := c.Items()
+					if len(items) != 0 && len(items) != n {
+						t.Errorf("Items() has %v kv-pairs, want 0 or %v", len(items), n)
+						return
+					}
+					for _, kv := range items {
+						if kv.Val() != items[0].Val() {
+							t.Errorf("Items() mixes generations %v and %v", items[0].Val(), kv.Val())
+							return
+						}
+					}
+				}
+			}()
+		}
+
+		for g := 0; g < gens; g++ {
+			pairs := make([]*KvPair[int, int], n)
+			for i := range pairs {
+				pairs[i] = NewKvPair(i, g)
+			}
+			if err := c.ReplaceAll(pairs); err != nil {
+				t.Fatalf("ReplaceAll() error = %v", err)
+			}
+			runtime.Gosched()
+		}
+		close(done)
+		wg.Wait()
+
+		if err := c.validate(); err != nil {
+			t.Errorf("validate() error = %v", err)
+		}
+	})
+
+	t.Run("TestConcurrentSkipList_ReplaceAll 2", func(t *testing.T) {
+		c := NewConcurrent[int, int](10, WithOptimisticLocking())
+		c.Put(1, 1)
+		if err := c.ReplaceAll([]*KvPair[int, int]{{2, 2}}); err != ErrReplaceNotAtomic {
+			t.Errorf("ReplaceAll() error = %v, want %v", err, ErrReplaceNotAtomic)
+		}
+		if _, ok := c.Get(2); ok {
+			t.Errorf("Get(2) = _, true after a failed ReplaceAll()")
+		}
+	})
+}
+
+func BenchmarkBulkLoad(b *testing.B) {
+	const n = 5_000_000
+
+	var pairs = make([]*KvPair[int, int], n)
+	for i := range pairs {
+		pairs[i] = NewKvPair(i, i)
+	}
+
+	for _, opts := range [][]Option{nil, {WithArena()}} {
+		var sl = BulkLoad(0, false, pairs, opts...)
+		b.Run(fmt.Sprintf("arena=%v/GC", len(opts) > 0), func(b *testing.B) {
+			var pause time.Duration
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				runtime.GC()
+				pause += time.Since(start)
+			}
+			b.ReportMetric(float64(pause.Microseconds())/float64(b.N), "µs/GC")
+		})
+		b.Run(fmt.Sprintf("arena=%v/Get", len(opts) > 0), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sl.Get(i * 7919 % n)
+			}
+		})
+		runtime.KeepAlive(sl)
+	}
+}
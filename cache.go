@@ -0,0 +1,263 @@
+package skip_list
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Eviction is the order in which Cache evicts live entries once it is full.
+type Eviction int
+
+const (
+	// EvictByExpiry evicts the entry expiring first.
+	EvictByExpiry Eviction = iota
+
+	// EvictByKey evicts the entry of the least key, such as the oldest of keys increasing over time.
+	EvictByKey
+)
+
+type (
+	// Cache is a cache of at most maxEntries entries each expiring after its TTL, safe for concurrent use.
+	// It is a SkipList of the entries keyed by key, and a SkipList of the keys keyed by the Encode of CompositeKey
+	// of deadline and key, by which expired entries are found without a scan. Both are guarded by the mutex of Cache.
+	// Expired entries are removed by the calls which find them, and before any live entry is evicted.
+	Cache[K constraints.Ordered, V any] struct {
+		entries   *SkipList[K, cacheEntry[V]]
+		deadlines *SkipList[string, K]
+
+		maxEntries int
+		ttl        time.Duration
+		opts       cacheOptions
+		stats      CacheStats
+		mu         sync.Mutex
+	}
+
+	cacheEntry[V any] struct {
+		val V
+
+		// unix nanoseconds, math.MaxInt64 if never
+		deadline int64
+
+		// extends deadline by Get if refresh
+		ttl time.Duration
+	}
+
+	// CacheStats are the counts of Cache since it is created.
+	CacheStats struct {
+		Hits, Misses uint64
+
+		// live entries evicted for capacity
+		Evictions uint64
+
+		// entries removed once expired
+		Expirations uint64
+	}
+
+	// CacheOption configures a Cache.
+	CacheOption func(o *cacheOptions)
+
+	cacheOptions struct {
+		refresh  bool
+		eviction Eviction
+		now      func() time.Time
+	}
+)
+
+// WithRefresh makes Get extend the deadline of a hit entry by its TTL.
+func WithRefresh() CacheOption {
+	return func(o *cacheOptions) {
+		o.refresh = true
+	}
+}
+
+// WithEviction sets the order in which live entries are evicted once Cache is full, EvictByExpiry by default.
+func WithEviction(e Eviction) CacheOption {
+	return func(o *cacheOptions) {
+		o.eviction = e
+	}
+}
+
+// WithClock sets the clock of Cache, time.Now by default.
+func WithClock(now func() time.Time) CacheOption {
+	return func(o *cacheOptions) {
+		if now != nil {
+			o.now = now
+		}
+	}
+}
+
+// NewCache returns a Cache of at most maxEntries entries expiring after ttl by default, which never expire if ttl is
+// not positive. It returns nil if maxEntries is not positive.
+func NewCache[K constraints.Ordered, V any](maxEntries int, ttl time.Duration, opts ...CacheOption) *Cache[K, V] {
+	if maxEntries <= 0 {
+		return nil
+	}
+
+	var o = cacheOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Cache[K, V]{
+		entries:    NewSkipList[K, cacheEntry[V]](0, false),
+		deadlines:  NewSkipList[string, K](0, false),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		opts:       o,
+	}
+}
+
+// Get returns the value of key if it is not expired.
+func (c *Cache[K, V]) Get(key K) (val V, exist bool) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.opts.now().UnixNano()
+	n := c.entries.get(key)
+	if n == nil {
+		c.stats.Misses++
+		return
+	}
+	if n.val.deadline <= now {
+		c.remove(key, n.val.deadline)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return
+	}
+
+	c.stats.Hits++
+	if c.opts.refresh && n.val.ttl > 0 {
+		if deadline := deadlineOf(now, n.val.ttl); deadline != n.val.deadline {
+			c.deadlines.delete(deadlineKey(n.val.deadline, key), nil)
+			c.deadlines.put(nil, deadlineKey(deadline, key), key)
+			n.val.deadline = deadline
+		}
+	}
+	return n.val.val, true
+}
+
+// Set sets val of key expiring after the default TTL of Cache.
+func (c *Cache[K, V]) Set(key K, val V) {
+	if c == nil {
+		return
+	}
+
+	c.SetWithTTL(key, val, c.ttl)
+}
+
+// SetWithTTL sets val of key expiring after ttl, which never expires if ttl is not positive.
+// Setting a new key of a full Cache removes the expired entries, and then evicts a live entry if none is expired.
+func (c *Cache[K, V]) SetWithTTL(key K, val V, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.opts.now().UnixNano()
+	if n := c.entries.get(key); n != nil {
+		c.deadlines.delete(deadlineKey(n.val.deadline, key), nil)
+	} else if int(c.entries.cap) >= c.maxEntries {
+		c.expire(now)
+		if int(c.entries.cap) >= c.maxEntries {
+			c.evict()
+		}
+	}
+
+	deadline := deadlineOf(now, ttl)
+	c.entries.put(nil, key, cacheEntry[V]{val: val, deadline: deadline, ttl: ttl})
+	c.deadlines.put(nil, deadlineKey(deadline, key), key)
+}
+
+// Delete deletes key, and returns whether it exists and is not expired.
+func (c *Cache[K, V]) Delete(key K) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.entries.get(key)
+	if n == nil {
+		return false
+	}
+	live := n.val.deadline > c.opts.now().UnixNano()
+	c.remove(key, n.val.deadline)
+	return live
+}
+
+// Len returns the number of entries not expired, removing the expired ones.
+func (c *Cache[K, V]) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire(c.opts.now().UnixNano())
+	return int(c.entries.cap)
+}
+
+// Stats returns the counts of Cache.
+func (c *Cache[K, V]) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// expire removes the entries whose deadlines are not after now, which are the least keys of deadlines.
+func (c *Cache[K, V]) expire(now int64) {
+	for n := c.deadlines.head.next(); n != nil; n = c.deadlines.head.next() {
+		e := c.entries.get(n.val)
+		if e.val.deadline > now {
+			return
+		}
+		c.remove(n.val, e.val.deadline)
+		c.stats.Expirations++
+	}
+}
+
+// evict removes the least key of entries or of deadlines by the eviction of Cache.
+func (c *Cache[K, V]) evict() {
+	var key K
+	if c.opts.eviction == EvictByKey {
+		key = c.entries.head.next().key
+	} else {
+		key = c.deadlines.head.next().val
+	}
+	c.remove(key, c.entries.get(key).val.deadline)
+	c.stats.Evictions++
+}
+
+func (c *Cache[K, V]) remove(key K, deadline int64) {
+	c.entries.delete(key, nil)
+	c.deadlines.delete(deadlineKey(deadline, key), nil)
+}
+
+// deadlineOf returns the deadline of ttl from now in unix nanoseconds, saturating at math.MaxInt64 for never.
+func deadlineOf(now int64, ttl time.Duration) int64 {
+	if ttl <= 0 || now > math.MaxInt64-int64(ttl) {
+		return math.MaxInt64
+	}
+	return now + int64(ttl)
+}
+
+// deadlineKey returns the key of key with deadline in the deadlines of Cache.
+func deadlineKey[K constraints.Ordered](deadline int64, key K) string {
+	return NewCompositeKey(deadline, key).Encode()
+}
@@ -0,0 +1,265 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock advanced by the tests, safe for concurrent use.
+type fakeClock struct {
+	now atomic.Int64
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, c.now.Load())
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now.Add(int64(d))
+}
+
+func TestCache(t *testing.T) {
+	var (
+		clock = &fakeClock{}
+		c     = NewCache[string, int](10, time.Minute, WithClock(clock.Now))
+	)
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Second)
+	c.SetWithTTL("c", 3, 0)
+
+	if val, exist := c.Get("a"); !exist || val != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, true", val, exist)
+	}
+	if _, exist := c.Get("d"); exist {
+		t.Errorf("Get(d) exists")
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %v, want 3", c.Len())
+	}
+
+	clock.Advance(time.Second)
+	if _, exist := c.Get("b"); exist {
+		t.Errorf("Get(b) exists after its TTL")
+	}
+	clock.Advance(time.Minute)
+	if c.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", c.Len())
+	}
+	if val, exist := c.Get("c"); !exist || val != 3 {
+		t.Errorf("Get(c) = %v, %v, want 3, true as it never expires", val, exist)
+	}
+
+	// Set of an existing key replaces its value and deadline
+	c.SetWithTTL("c", 4, time.Second)
+	clock.Advance(time.Second - 1)
+	if val, _ := c.Get("c"); val != 4 {
+		t.Errorf("Get(c) = %v, want 4", val)
+	}
+	if !c.Delete("c") || c.Delete("c") {
+		t.Errorf("Delete(c) is not true once")
+	}
+
+	c.SetWithTTL("e", 5, time.Second)
+	clock.Advance(time.Second)
+	if c.Delete("e") {
+		t.Errorf("Delete(e) = true after its TTL")
+	}
+
+	want := CacheStats{Hits: 3, Misses: 2, Expirations: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if err := c.entries.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if c.entries.Cap() != c.deadlines.Cap() {
+		t.Errorf("%d entries have %d deadlines", c.entries.Cap(), c.deadlines.Cap())
+	}
+
+	if c := NewCache[int, int](0, time.Second); c != nil {
+		t.Errorf("NewCache(0) = %v, want nil", c)
+	}
+	var nilCache *Cache[int, int]
+	nilCache.Set(1, 1)
+	if _, exist := nilCache.Get(1); exist || nilCache.Len() != 0 || nilCache.Delete(1) {
+		t.Errorf("nil Cache is not empty")
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	type testCase struct {
+		name     string
+		eviction Eviction
+		ttls     map[int]time.Duration
+		advance  time.Duration
+		want     []int
+		stats    CacheStats
+	}
+	// keys 1 to 4 are set in the order 3, 1, 4, 2 into a Cache of 3 entries
+	tests := []testCase{
+		{
+			name: "TestCache_Eviction 1", eviction: EvictByExpiry,
+			ttls: map[int]time.Duration{3: 4 * time.Second, 1: 2 * time.Second, 4: 3 * time.Second, 2: time.Second},
+			want: []int{2, 3, 4}, stats: CacheStats{Evictions: 1},
+		},
+		{
+			name: "TestCache_Eviction 2", eviction: EvictByKey,
+			ttls: map[int]time.Duration{3: 4 * time.Second, 1: 2 * time.Second, 4: 3 * time.Second, 2: time.Second},
+			want: []int{2, 3, 4}, stats: CacheStats{Evictions: 1},
+		},
+		{
+			name: "TestCache_Eviction 3", eviction: EvictByExpiry,
+			ttls: map[int]time.Duration{3: time.Second, 1: 4 * time.Second, 4: 2 * time.Second, 2: time.Second},
+			want: []int{1, 2, 4}, stats: CacheStats{Evictions: 1},
+		},
+		{
+			name: "TestCache_Eviction 4", eviction: EvictByKey,
+			ttls: map[int]time.Duration{3: time.Second, 1: 4 * time.Second, 4: 2 * time.Second, 2: time.Second},
+			want: []int{2, 3, 4}, stats: CacheStats{Evictions: 1},
+		},
+		// expired entries are removed before any live one is evicted
+		{
+			name: "TestCache_Eviction 5", eviction: EvictByKey,
+			ttls:    map[int]time.Duration{3: 4 * time.Second, 1: 4 * time.Second, 4: 2 * time.Second, 2: 4 * time.Second},
+			advance: 3 * time.Second,
+			want:    []int{1, 2, 3}, stats: CacheStats{Expirations: 1},
+		},
+		// never expiring entries are evicted by expiry last
+		{
+			name: "TestCache_Eviction 6", eviction: EvictByExpiry,
+			ttls: map[int]time.Duration{3: 0, 1: 0, 4: time.Hour, 2: 0},
+			want: []int{1, 2, 3}, stats: CacheStats{Evictions: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				clock = &fakeClock{}
+				c     = NewCache[int, int](3, time.Minute, WithClock(clock.Now), WithEviction(tt.eviction))
+			)
+			for _, key := range []int{3, 1, 4} {
+				c.SetWithTTL(key, -key, tt.ttls[key])
+			}
+			clock.Advance(tt.advance)
+			c.SetWithTTL(2, -2, tt.ttls[2])
+
+			var got []int
+			for _, kv := range c.entries.Items() {
+				got = append(got, kv.Key())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keys = %v, want %v", got, tt.want)
+			}
+			if stats := c.Stats(); stats != tt.stats {
+				t.Errorf("Stats() = %+v, want %+v", stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestCache_Refresh(t *testing.T) {
+	for _, refresh := range []bool{false, true} {
+		t.Run(fmt.Sprint("TestCache_Refresh ", refresh), func(t *testing.T) {
+			var (
+				clock = &fakeClock{}
+				opts  = []CacheOption{WithClock(clock.Now)}
+			)
+			if refresh {
+				opts = append(opts, WithRefresh())
+			}
+			c := NewCache[int, int](2, 2*time.Second, opts...)
+			c.Set(1, 1)
+			c.Set(2, 2)
+
+			// a hit of 1 refreshes it past 2
+			clock.Advance(time.Second)
+			c.Get(1)
+			clock.Advance(time.Second)
+			if _, exist := c.Get(1); exist != refresh {
+				t.Errorf("Get(1) exist = %v, want %v", exist, refresh)
+			}
+			if _, exist := c.Get(2); exist {
+				t.Errorf("Get(2) exists after its TTL")
+			}
+
+			// the refreshed deadline orders eviction by expiry
+			c.Set(3, 3)
+			c.Get(3)
+			clock.Advance(time.Second)
+			c.Get(1)
+			c.Set(4, 4)
+			if _, exist := c.Get(3); exist == refresh {
+				t.Errorf("Get(3) exist = %v, want %v", exist, !refresh)
+			}
+			if c.entries.Cap() != c.deadlines.Cap() {
+				t.Errorf("%d entries have %d deadlines", c.entries.Cap(), c.deadlines.Cap())
+			}
+		})
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	var (
+		clock = &fakeClock{}
+		c     = NewCache[int, int](100, 50*time.Millisecond, WithClock(clock.Now), WithRefresh())
+		wg    sync.WaitGroup
+		gets  atomic.Uint64
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < 5000; i++ {
+				key := rnd.Intn(300)
+				switch op := rnd.Intn(100); {
+				case op < 40:
+					c.Set(key, key)
+				case op < 50:
+					c.SetWithTTL(key, key, time.Duration(rnd.Intn(100))*time.Millisecond)
+				case op < 90:
+					gets.Add(1)
+					if val, exist := c.Get(key); exist && val != key {
+						t.Errorf("Get(%v) = %v", key, val)
+					}
+				case op < 95:
+					c.Delete(key)
+				case op < 98:
+					clock.Advance(time.Millisecond)
+				default:
+					if n := c.Len(); n > 100 {
+						t.Errorf("Len() = %v, want at most 100", n)
+					}
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	if stats.Hits+stats.Misses != gets.Load() {
+		t.Errorf("Stats() = %+v for %d calls of Get", stats, gets.Load())
+	}
+	if stats.Evictions == 0 || stats.Expirations == 0 {
+		t.Errorf("Stats() = %+v, want evictions and expirations under pressure", stats)
+	}
+	if err := c.entries.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := c.deadlines.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if c.entries.Cap() != c.deadlines.Cap() || c.entries.Cap() > 100 {
+		t.Errorf("%d entries have %d deadlines", c.entries.Cap(), c.deadlines.Cap())
+	}
+	for _, kv := range c.deadlines.Items() {
+		if e, exist := c.entries.Get(kv.Val()); !exist || deadlineKey(e.deadline, kv.Val()) != kv.Key() {
+			t.Errorf("deadline of %v is stale", kv.Val())
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type (
+	// Comparable is a key type ordered by its Compare method, which returns a negative number, 0 or a positive number
+	// if the key is less than, equal to or greater than other.
+	Comparable[O any] interface {
+		Compare(other O) int
+	}
+
+	// CmpSkipList is a SkipList of keys ordered by Compare instead of the operators of constraints.Ordered,
+	// such as wrappers of big.Int. Its kv-pairs are passed as key and value, since KvPair requires ordered keys.
+	CmpSkipList[O Comparable[O], T any] struct {
+		// towers of the nodes in the order of Compare
+		core[O, T, compared[O]]
+
+		// concurrent
+		isConcurrent bool
+		sync.RWMutex
+	}
+)
+
+// NewSkipListCmp returns a CmpSkipList whose nodes have at most maxLevel+1 levels, maxLevel must be positive.
+// WithProbability and WithRandSource are honored, other options are ignored.
+func NewSkipListCmp[O Comparable[O], T any](maxLevel int32, isConcurrent bool, opts ...Option) *CmpSkipList[O, T] {
+	if maxLevel <= 0 {
+		return nil
+	}
+
+	o := newOptions(opts...)
+	if !o.valid() {
+		return nil
+	}
+
+	src := o.randSource
+	if src == nil {
+		src = rand.NewSource(time.Now().Unix())
+	}
+
+	return &CmpSkipList[O, T]{
+		core: core[O, T, compared[O]]{
+			level:    1,
+			maxLevel: maxLevel,
+			cap:      0,
+			p:        o.p,
+			head:     newHead[O, T](false, false, maxLevel),
+			r:        rand.New(src),
+		},
+		isConcurrent: isConcurrent,
+	}
+}
+
+func (sl *CmpSkipList[O, T]) Level() int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.level
+}
+
+func (sl *CmpSkipList[O, T]) Cap() int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.cap
+}
+
+func (sl *CmpSkipList[O, T]) Get(key O) (val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.get(sl.head, key); n != nil {
+		return n.val, true
+	}
+	return
+}
+
+func (sl *CmpSkipList[O, T]) Put(key O, val T) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if n := sl.get(sl.head, key); n != nil {
+		// update
+		n.val = val
+		return
+	}
+
+	randL := sl.flip(0, sl.levelLimit(sl.cap+1))
+	sl.grow(randL + 1)
+
+	n := &node[O, T]{key: key, val: val}
+	if randL < inlineLevels {
+		n.nextNodes = n.inline[:randL+1]
+	} else {
+		n.nextNodes = make([]*node[O, T], randL+1)
+	}
+	sl.link(sl.head, n, randL)
+	sl.cap++
+}
+
+func (sl *CmpSkipList[O, T]) Delete(key O) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if sl.unlink(key, nil) == nil {
+		// not exist
+		return
+	}
+	sl.cap--
+
+	// cut
+	sl.cut()
+}
+
+// Ceil returns the least key greater than or equal to target and its value.
+func (sl *CmpSkipList[O, T]) Ceil(target O) (key O, val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.ceil(target); n != nil {
+		return n.key, n.val, true
+	}
+	return
+}
+
+// Floor returns the greatest key less than or equal to target and its value.
+func (sl *CmpSkipList[O, T]) Floor(target O) (key O, val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.floor(target); n != sl.head {
+		return n.key, n.val, true
+	}
+	return
+}
+
+// AscendRange calls fn for each key in [start, end] in ascending order until fn returns false.
+// fn must not modify sl.
+func (sl *CmpSkipList[O, T]) AscendRange(start, end O, fn func(key O, val T) bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.ceil(start); n != nil && n.key.Compare(end) <= 0; n = n.nextNodes[0] {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// ForEach calls fn for each key in ascending order until fn returns false.
+// fn must not modify sl.
+func (sl *CmpSkipList[O, T]) ForEach(fn func(key O, val T) bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// Validate checks the invariants of CmpSkipList and returns the first violation found.
+func (sl *CmpSkipList[O, T]) Validate() error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if err := sl.core.validate(); err != nil {
+		return err
+	}
+
+	var cap int32
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		cap++
+	}
+	if cap != sl.cap {
+		return fmt.Errorf("skip_list: %d nodes, want cap %d", cap, sl.cap)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package skip_list
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// bigKey orders big.Int by Cmp.
+type bigKey struct{ *big.Int }
+
+func (k bigKey) Compare(other bigKey) int {
+	return k.Cmp(other.Int)
+}
+
+// descKey orders int in descending order.
+type descKey int
+
+func (k descKey) Compare(other descKey) int {
+	switch {
+	case k > other:
+		return -1
+	case k < other:
+		return 1
+	}
+	return 0
+}
+
+func TestNewSkipListCmp(t *testing.T) {
+	if sl := NewSkipListCmp[descKey, int](0, false); sl != nil {
+		t.Errorf("NewSkipListCmp(0) = %v, want nil", sl)
+	}
+	if sl := NewSkipListCmp[descKey, int](10, false, WithProbability(1)); sl != nil {
+		t.Errorf("NewSkipListCmp(WithProbability(1)) = %v, want nil", sl)
+	}
+	if got := (*CmpSkipList[descKey, int])(nil).Cap(); got != 0 {
+		t.Errorf("nil Cap() = %v, want 0", got)
+	}
+}
+
+func TestCmpSkipList(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+	}
+	testCases := []testCase{
+		{name: "TestCmpSkipList 1"},
+		{name: "TestCmpSkipList 2", isConcurrent: true},
+	}
+
+	// 2^64 + i exceeds every integer type
+	keyOf := func(i int64) bigKey {
+		k := new(big.Int).Lsh(big.NewInt(1), 64)
+		return bigKey{k.Add(k, big.NewInt(i))}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sl := NewSkipListCmp[bigKey, int64](10, tc.isConcurrent, WithRandSource(rand.NewSource(1)))
+			for _, i := range rand.New(rand.NewSource(2)).Perm(200) {
+				sl.Put(keyOf(int64(i)), int64(i))
+			}
+			sl.Put(keyOf(7), -7)
+			for i := int64(100); i < 200; i += 2 {
+				sl.Delete(keyOf(i))
+			}
+			sl.Delete(keyOf(1000))
+
+			if got := sl.Cap(); got != 150 {
+				t.Errorf("Cap() = %v, want 150", got)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if v, ok := sl.Get(keyOf(7)); !ok || v != -7 {
+				t.Errorf("Get(7) = %v, %v, want -7, true", v, ok)
+			}
+			if _, ok := sl.Get(keyOf(100)); ok {
+				t.Errorf("Get(100) = _, true after Delete(100)")
+			}
+			if k, v, ok := sl.Ceil(keyOf(100)); !ok || k.Compare(keyOf(101)) != 0 || v != 101 {
+				t.Errorf("Ceil(100) = %v, %v, %v, want 101", k, v, ok)
+			}
+			if k, v, ok := sl.Floor(keyOf(198)); !ok || k.Compare(keyOf(197)) != 0 || v != 197 {
+				t.Errorf("Floor(198) = %v, %v, %v, want 197", k, v, ok)
+			}
+			if _, _, ok := sl.Floor(keyOf(-1)); ok {
+				t.Errorf("Floor(-1) = _, _, true, want false")
+			}
+			if _, _, ok := sl.Ceil(keyOf(200)); ok {
+				t.Errorf("Ceil(200) = _, _, true, want false")
+			}
+
+			var got []int64
+			sl.AscendRange(keyOf(96), keyOf(105), func(_ bigKey, val int64) bool {
+				got = append(got, val)
+				return true
+			})
+			if want := []int64{96, 97, 98, 99, 101, 103, 105}; !reflect.DeepEqual(got, want) {
+				t.Errorf("AscendRange(96, 105) = %v, want %v", got, want)
+			}
+
+			var n int
+			sl.ForEach(func(bigKey, int64) bool {
+				n++
+				return n < 10
+			})
+			if n != 10 {
+				t.Errorf("ForEach() called fn %v times, want 10", n)
+			}
+		})
+	}
+}
+
+func TestCmpSkipList_Compare(t *testing.T) {
+	// every comparison goes through Compare, which reverses the order of int
+	sl := NewSkipListCmp[descKey, int](10, false)
+	for i := 0; i < 10; i++ {
+		sl.Put(descKey(i), i)
+	}
+
+	var got []int
+	sl.ForEach(func(_ descKey, val int) bool {
+		got = append(got, val)
+		return true
+	})
+	if want := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEach() = %v, want %v", got, want)
+	}
+	if k, _, ok := sl.Ceil(descKey(-1)); ok {
+		t.Errorf("Ceil(-1) = %v, true, want false", k)
+	}
+	if k, _, ok := sl.Floor(descKey(-1)); !ok || k != 0 {
+		t.Errorf("Floor(-1) = %v, %v, want 0, true", k, ok)
+	}
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
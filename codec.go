@@ -0,0 +1,119 @@
+package skip_list
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Codec encodes and decodes values of T, by which the binary form, SaveFile and the log of AttachLog persist values.
+// Decode must not retain b, which may be reused.
+type Codec[T any] interface {
+	Encode(val T) ([]byte, error)
+	Decode(b []byte) (T, error)
+}
+
+type (
+	// StringCodec encodes a string as its bytes.
+	StringCodec struct{}
+
+	// BytesCodec encodes a []byte as itself.
+	BytesCodec struct{}
+
+	// IntCodec encodes a signed integer as a varint and an unsigned one as a uvarint.
+	IntCodec[I constraints.Integer] struct{}
+
+	// JSONCodec encodes a value by encoding/json, which is the default of the types without a built-in codec.
+	JSONCodec[T any] struct{}
+
+	// OrderedKeyCodec encodes a key as bytes ordered by bytes.Compare as the keys are, such as for WriteSortedBlocks.
+	// Integers are 8 big-endian bytes with the sign bit flipped, floats the bits of float64 flipped to sort as the
+	// numbers do, and strings their bytes.
+	OrderedKeyCodec[O constraints.Ordered] struct{}
+
+	// codecFuncs is the Codec of the functions of WithValueCodec.
+	codecFuncs[T any] struct {
+		encode func(val T) ([]byte, error)
+		decode func(b []byte) (T, error)
+	}
+
+	// builtinCodec encodes values of a type with a binaryKind as appendBuiltin does.
+	builtinCodec[T any] struct{}
+)
+
+var (
+	_ Codec[string]  = StringCodec{}
+	_ Codec[[]byte]  = BytesCodec{}
+	_ Codec[int]     = IntCodec[int]{}
+	_ Codec[any]     = JSONCodec[any]{}
+	_ Codec[float64] = OrderedKeyCodec[float64]{}
+)
+
+// defaultCodec returns the built-in Codec of T, or JSONCodec if T has none.
+func defaultCodec[T any]() Codec[T] {
+	if binaryKind(typeOf[T]()) == reflect.Invalid {
+		return JSONCodec[T]{}
+	}
+	return builtinCodec[T]{}
+}
+
+func (StringCodec) Encode(val string) ([]byte, error) {
+	return []byte(val), nil
+}
+
+func (StringCodec) Decode(b []byte) (string, error) {
+	return string(b), nil
+}
+
+func (BytesCodec) Encode(val []byte) ([]byte, error) {
+	return val, nil
+}
+
+func (BytesCodec) Decode(b []byte) ([]byte, error) {
+	return append([]byte(nil), b...), nil
+}
+
+func (IntCodec[I]) Encode(val I) ([]byte, error) {
+	return appendBuiltin(nil, reflect.ValueOf(val)), nil
+}
+
+func (IntCodec[I]) Decode(b []byte) (val I, err error) {
+	err = decodeBuiltin(b, reflect.ValueOf(&val).Elem())
+	return val, err
+}
+
+func (JSONCodec[T]) Encode(val T) ([]byte, error) {
+	return json.Marshal(val)
+}
+
+func (JSONCodec[T]) Decode(b []byte) (val T, err error) {
+	err = json.Unmarshal(b, &val)
+	return val, err
+}
+
+func (OrderedKeyCodec[O]) Encode(key O) ([]byte, error) {
+	return appendOrdered(nil, reflect.ValueOf(key), false), nil
+}
+
+func (OrderedKeyCodec[O]) Decode(b []byte) (key O, err error) {
+	_, err = decodeOrdered(b, reflect.ValueOf(&key).Elem(), false)
+	return key, err
+}
+
+func (c codecFuncs[T]) Encode(val T) ([]byte, error) {
+	return c.encode(val)
+}
+
+func (c codecFuncs[T]) Decode(b []byte) (T, error) {
+	return c.decode(b)
+}
+
+func (builtinCodec[T]) Encode(val T) ([]byte, error) {
+	return appendBuiltin(nil, reflect.ValueOf(&val).Elem()), nil
+}
+
+func (builtinCodec[T]) Decode(b []byte) (val T, err error) {
+	err = decodeBuiltin(b, reflect.ValueOf(&val).Elem())
+	return val, err
+}
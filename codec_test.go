@@ -0,0 +1,169 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// point is a value without a built-in codec.
+type point struct {
+	X, Y int32
+}
+
+// pointCodec encodes a point as 8 little-endian bytes and counts the values it encodes.
+type pointCodec struct {
+	encoded *int
+}
+
+var errPointCodec = errors.New("point codec: want 8 bytes")
+
+func (c pointCodec) Encode(val point) ([]byte, error) {
+	*c.encoded++
+	b := binary.LittleEndian.AppendUint32(nil, uint32(val.X))
+	return binary.LittleEndian.AppendUint32(b, uint32(val.Y)), nil
+}
+
+func (c pointCodec) Decode(b []byte) (point, error) {
+	if len(b) != 8 {
+		return point{}, errPointCodec
+	}
+	return point{X: int32(binary.LittleEndian.Uint32(b)), Y: int32(binary.LittleEndian.Uint32(b[4:]))}, nil
+}
+
+func TestCodec(t *testing.T) {
+	t.Run("TestCodec 1", func(t *testing.T) {
+		testCodecRoundTrip[string](t, StringCodec{}, "", "a", "日本語", "\x00\xff")
+		testCodecRoundTrip[[]byte](t, BytesCodec{}, []byte{}, []byte("a"), []byte{0, 1, 2})
+		testCodecRoundTrip[int](t, IntCodec[int]{}, 0, 1, -1, math.MaxInt, math.MinInt)
+		testCodecRoundTrip[uint8](t, IntCodec[uint8]{}, 0, 1, math.MaxUint8)
+		testCodecRoundTrip[point](t, JSONCodec[point]{}, point{}, point{X: 1, Y: -2})
+		testCodecRoundTrip[float64](t, OrderedKeyCodec[float64]{}, 0, -1.5, math.Inf(1), math.SmallestNonzeroFloat64)
+		testCodecRoundTrip[string](t, OrderedKeyCodec[string]{}, "", "a", "a\x00b")
+	})
+
+	t.Run("TestCodec 2", func(t *testing.T) {
+		// BytesCodec does not retain b
+		b := []byte("abc")
+		val, _ := BytesCodec{}.Decode(b)
+		b[0] = 'x'
+		if string(val) != "abc" {
+			t.Errorf("Decode() = %q after modifying b, want %q", val, "abc")
+		}
+	})
+
+	t.Run("TestCodec 3", func(t *testing.T) {
+		// OrderedKeyCodec orders bytes as keys
+		ints := []int64{math.MinInt64, -1000, -1, 0, 1, 255, 256, math.MaxInt64}
+		floats := []float64{math.Inf(-1), -1e300, -1, -math.SmallestNonzeroFloat64, 0, 0.5, 1, 1e300, math.Inf(1)}
+		strs := []string{"", "\x00", "a", "a\x00", "ab", "b"}
+		testOrderedKeyCodec[int64](t, ints)
+		testOrderedKeyCodec[float64](t, floats)
+		testOrderedKeyCodec[string](t, strs)
+	})
+
+	t.Run("TestCodec 4", func(t *testing.T) {
+		// the built-in codecs of SkipList are the same as StringCodec and IntCodec
+		sl := NewSkipList[int, string](10, false)
+		sl.Put(1, "a")
+		want, err := sl.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+		sl = NewSkipList[int, string](10, false, WithCodec[string](StringCodec{}))
+		sl.Put(1, "a")
+		if got, _ := sl.MarshalBinary(); !bytes.Equal(got, want) {
+			t.Errorf("MarshalBinary() with StringCodec = %x, want %x", got, want)
+		}
+
+		if sl := NewSkipList[int, int](10, false, WithCodec[string](StringCodec{})); sl != nil {
+			t.Errorf("NewSkipList() with a mismatched codec = %v, want nil", sl)
+		}
+		if sl := NewSkipList[int, int](10, false, WithCodec[int](nil)); sl == nil || sl.codec == nil {
+			t.Errorf("NewSkipList() with a nil codec has no default codec")
+		}
+	})
+}
+
+func TestCodec_SaveFile(t *testing.T) {
+	var encoded int
+	codec := WithCodec[point](pointCodec{encoded: &encoded})
+	src := NewSkipList[int, point](10, false, codec)
+	for i := 0; i < 1000; i++ {
+		src.Put(i, point{X: int32(i), Y: int32(-i)})
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	if err := src.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+	if encoded != int(src.Cap()) {
+		t.Errorf("%d values encoded by the codec, want %d", encoded, src.Cap())
+	}
+
+	dst, err := LoadFile[int, point](path, 10, true, codec)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if dst.Cap() != src.Cap() {
+		t.Fatalf("Cap() = %v, want %v", dst.Cap(), src.Cap())
+	}
+	for _, kv := range src.Items() {
+		if val, ok := dst.Get(kv.Key()); !ok || val != kv.Val() {
+			t.Fatalf("Get(%v) = %v, %v, want %v, true", kv.Key(), val, ok, kv.Val())
+		}
+	}
+
+	// a log of the codec
+	var log bytes.Buffer
+	if err := dst.AttachLog(&log); err != nil {
+		t.Fatalf("AttachLog() error = %v", err)
+	}
+	dst.Put(-1, point{X: 7, Y: 8})
+	replayed, _ := LoadFile[int, point](path, 10, false, codec)
+	if _, err := Replay(&log, replayed); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if val, ok := replayed.Get(-1); !ok || val != (point{X: 7, Y: 8}) {
+		t.Errorf("Get(-1) after Replay() = %v, %v, want {7 8}, true", val, ok)
+	}
+
+	// values of the codec read by JSONCodec
+	if _, err := LoadFile[int, point](path, 10, false); err == nil {
+		t.Errorf("LoadFile() by JSONCodec error = nil")
+	}
+}
+
+func testCodecRoundTrip[T any](t *testing.T, c Codec[T], vals ...T) {
+	t.Helper()
+
+	for _, val := range vals {
+		b, err := c.Encode(val)
+		if err != nil {
+			t.Fatalf("Encode(%v) error = %v", val, err)
+		}
+		got, err := c.Decode(b)
+		if err != nil {
+			t.Fatalf("Decode(%x) error = %v", b, err)
+		}
+		if gb, _ := c.Encode(got); !bytes.Equal(gb, b) {
+			t.Errorf("Decode(Encode(%v)) = %v", val, got)
+		}
+	}
+}
+
+func testOrderedKeyCodec[O int64 | float64 | string](t *testing.T, keys []O) {
+	t.Helper()
+
+	var encoded = make([][]byte, len(keys))
+	for i, key := range keys {
+		encoded[i], _ = OrderedKeyCodec[O]{}.Encode(key)
+	}
+	if !sort.SliceIsSorted(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 }) {
+		t.Errorf("encoded keys of %v are not sorted: %x", keys, encoded)
+	}
+}
@@ -0,0 +1,37 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// CollectRange returns the results of fn for each key in [start, end] in key order, without building a *KvPair per key.
+// fn must not modify sl.
+func CollectRange[O constraints.Ordered, T, R any](sl *SkipList[O, T], start, end O, fn func(key O, val T) R) []R {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]R, 0)
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.ceil(start); n != nil && n.key <= end; n = n.next() {
+		res = append(res, fn(n.key, n.val))
+	}
+	return res
+}
+
+// SlidingAggregate returns the results of agg for each window Windows yields for size in key order, such as the sums
+// of a rolling sum, or an empty slice if there is none. agg must not modify sl.
+func SlidingAggregate[O constraints.Ordered, T, A any](sl *SkipList[O, T], size int, agg func(window []*KvPair[O, T]) A) []A {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]A, 0)
+	for window := range sl.Windows(size, false) {
+		res = append(res, agg(window))
+	}
+	return res
+}
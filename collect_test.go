@@ -0,0 +1,120 @@
+package skip_list
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCollectRange(t *testing.T) {
+	// reading is a projection of a kv-pair
+	type reading struct {
+		Sensor string
+		Value  float64
+	}
+	type testCase struct {
+		name       string
+		sl         *SkipList[int, float64]
+		start, end int
+		want       []reading
+	}
+
+	var sl = NewSkipList[int, float64](10, true, WithTombstones())
+	for i := 0; i < 10; i++ {
+		sl.Put(i, float64(i)/2)
+	}
+	sl.Delete(5)
+
+	tests := []testCase{
+		{
+			name:  "TestCollectRange 1",
+			sl:    sl,
+			start: 3,
+			end:   7,
+			want:  []reading{{"s3", 1.5}, {"s4", 2}, {"s6", 3}, {"s7", 3.5}},
+		},
+		{
+			name:  "TestCollectRange 2",
+			sl:    sl,
+			start: -10,
+			end:   1,
+			want:  []reading{{"s0", 0}, {"s1", 0.5}},
+		},
+		{
+			name:  "TestCollectRange 3",
+			sl:    sl,
+			start: 5,
+			end:   5,
+			want:  []reading{},
+		},
+		{
+			name:  "TestCollectRange 4",
+			sl:    sl,
+			start: 7,
+			end:   3,
+			want:  []reading{},
+		},
+		{
+			name:  "TestCollectRange 5",
+			sl:    nil,
+			start: 0,
+			end:   10,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollectRange(tt.sl, tt.start, tt.end, func(key int, val float64) reading {
+				return reading{Sensor: "s" + strconv.Itoa(key), Value: val}
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CollectRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		CollectRange(sl, 0, 9, func(key int, val float64) int { return key })
+	}); allocs > 5 {
+		t.Errorf("CollectRange() allocates %v times, want no allocation per key", allocs)
+	}
+}
+
+func TestSlidingAggregate(t *testing.T) {
+	type testCase struct {
+		name string
+		sl   *SkipList[int, int]
+		size int
+		want []int
+	}
+
+	// a time series of integer readings keyed by second, with a gap
+	var sl = NewSkipList[int, int](10, true, WithTombstones())
+	for i, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+		sl.Put(i*10, v)
+	}
+	sl.Delete(30)
+	sum := func(window []*KvPair[int, int]) int {
+		var s int
+		for _, kv := range window {
+			s += kv.Val()
+		}
+		return s
+	}
+
+	tests := []testCase{
+		{name: "TestSlidingAggregate 1", sl: sl, size: 3, want: []int{8, 10, 18, 16, 17}},
+		{name: "TestSlidingAggregate 2", sl: sl, size: 1, want: []int{3, 1, 4, 5, 9, 2, 6}},
+		{name: "TestSlidingAggregate 3", sl: sl, size: 7, want: []int{30}},
+		{name: "TestSlidingAggregate 4", sl: sl, size: 8, want: []int{}},
+		{name: "TestSlidingAggregate 5", sl: sl, size: 0, want: []int{}},
+		{name: "TestSlidingAggregate 6", sl: nil, size: 3, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlidingAggregate(tt.sl, tt.size, sum); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SlidingAggregate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
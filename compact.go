@@ -0,0 +1,90 @@
+package skip_list
+
+import "math"
+
+// Compact unlinks tombstones unless any ReadView is open, and rebuilds the towers above level 0 so that every base^i-th node has i+1 levels,
+// where base is 1/p rounded. Keys and values are untouched.
+func (sl *SkipList[O, T]) Compact() {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.compact()
+}
+
+func (sl *SkipList[O, T]) compact() {
+	sl.resetFinger()
+
+	var base = int(math.Round(1 / sl.p))
+	if base < 2 {
+		base = 2
+	}
+
+	// level of the compacted SkipList
+	var level int32 = 1
+	for k := base; k <= int(sl.cap) && level <= sl.maxLevel; k *= base {
+		level++
+	}
+	sl.resize(sl.head, level)
+	sl.level = level
+
+	// tails[l] is the last node on level l
+	var tails = make([]*node[O, T], level)
+	for l := range tails {
+		tails[l] = sl.head
+	}
+	var (
+		k          = 0
+		tombstones int32
+		keep       = sl.keepsHistory()
+	)
+	for n := sl.head.nextNodes[0]; n != nil; {
+		next := n.nextNodes[0]
+		if n.deleted && !keep {
+			// unlink tombstone
+			tails[0].nextNodes[0] = next
+			sl.freeNode(n)
+			n = next
+			continue
+		}
+
+		if n.deleted {
+			// kept for open ReadViews
+			tombstones++
+		}
+
+		k++
+		var h int32 = 1
+		for i := k; i%base == 0 && h < level; i /= base {
+			h++
+		}
+
+		sl.resize(n, h)
+		for l := int32(0); l < h; l++ {
+			tails[l].nextNodes[l] = n
+			tails[l] = n
+		}
+		n = next
+	}
+	for l := int32(1); l < level; l++ {
+		tails[l].nextNodes[l] = nil
+	}
+	sl.tombstoneCap = tombstones
+}
+
+// resize sets the tower of n to h levels, reusing its allocation if possible.
+func (sl *SkipList[O, T]) resize(n *node[O, T], h int32) {
+	if int(h) <= cap(n.nextNodes) {
+		n.nextNodes = n.nextNodes[:h]
+		return
+	}
+
+	nextNodes := sl.newTower(h)
+	copy(nextNodes, n.nextNodes)
+	n.nextNodes = nextNodes
+}
@@ -0,0 +1,93 @@
+package skip_list
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestSkipList_Compact(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name          string
+		sl            *SkipList[O, T]
+		wantHistogram []int32
+	}
+
+	var churned = NewSkipList[int, int](10, false)
+	for i := 0; i < 3000; i++ {
+		churned.Put(i, i)
+	}
+	for i := 0; i < 3000; i += 3 {
+		churned.Delete(i)
+	}
+	for i := 3000; i < 3005; i++ {
+		churned.Put(i, i)
+	}
+
+	var small = NewSkipList[int, int](2, false)
+	for i := 0; i < 10; i++ {
+		small.Put(i, i)
+	}
+
+	var quarter = NewSkipList[int, int](10, false, WithProbability(0.25))
+	for i := 0; i < 100; i++ {
+		quarter.Put(i, i)
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name:          "TestSkipList_Compact 1",
+			sl:            NewSkipList[int, int](10, false),
+			wantHistogram: []int32{0},
+		},
+		{
+			name:          "TestSkipList_Compact 2",
+			sl:            churned,
+			wantHistogram: []int32{1003, 501, 251, 125, 63, 31, 16, 8, 4, 2, 1},
+		},
+		{
+			name:          "TestSkipList_Compact 3",
+			sl:            small,
+			wantHistogram: []int32{5, 3, 2},
+		},
+		{
+			name:          "TestSkipList_Compact 4",
+			sl:            quarter,
+			wantHistogram: []int32{75, 19, 5, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.sl.Range(-1, 1<<20)
+			tt.sl.Compact()
+
+			if got := tt.sl.Stats().LevelHistogram; !reflect.DeepEqual(got, tt.wantHistogram) {
+				t.Errorf("Stats().LevelHistogram = %v, want %v", got, tt.wantHistogram)
+			}
+			if got := tt.sl.Range(-1, 1<<20); !reflect.DeepEqual(got, want) {
+				t.Errorf("Range() = %v, want %v", got, want)
+			}
+			for _, kv := range want {
+				if v, ok := tt.sl.Get(kv.key); !ok || v != kv.val {
+					t.Fatalf("Get(%v) = %v, %v, want %v, true", kv.key, v, ok, kv.val)
+				}
+				if got, ok := tt.sl.Ceil(kv.key); !ok || got.key != kv.key {
+					t.Fatalf("Ceil(%v) = %v, %v", kv.key, got, ok)
+				}
+				if got, ok := tt.sl.Floor(kv.key); !ok || got.key != kv.key {
+					t.Fatalf("Floor(%v) = %v, %v", kv.key, got, ok)
+				}
+			}
+			if _, ok := tt.sl.Get(-1); ok {
+				t.Errorf("Get(-1) exists")
+			}
+
+			tt.sl.Put(-1, -1)
+			tt.sl.Delete(1)
+			if v, ok := tt.sl.Get(-1); !ok || v != -1 {
+				t.Errorf("Get(-1) = %v, %v, want -1, true", v, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrCorruptCompositeKey = errors.New("skip_list: corrupt composite key")
+
+// CompositeKey is a key of two parts ordered by First and then by Second, which breaks ties of duplicate First
+// deterministically. As constraints.Ordered admits no struct, it is used as the string key of a SkipList by Encode,
+// whose byte order is the order of CompositeKey, or as the key of a CmpSkipList by Compare.
+type CompositeKey[A, B constraints.Ordered] struct {
+	First  A
+	Second B
+}
+
+// NewCompositeKey returns the CompositeKey of first and second.
+func NewCompositeKey[A, B constraints.Ordered](first A, second B) CompositeKey[A, B] {
+	return CompositeKey[A, B]{First: first, Second: second}
+}
+
+// Compare returns -1, 0 or 1 if k is less than, equal to or greater than other.
+func (k CompositeKey[A, B]) Compare(other CompositeKey[A, B]) int {
+	switch {
+	case k.First < other.First:
+		return -1
+	case k.First > other.First:
+		return 1
+	case k.Second < other.Second:
+		return -1
+	case k.Second > other.Second:
+		return 1
+	}
+	return 0
+}
+
+// Encode returns k as a string ordered as k is by Compare, except that NaN and -0 are ordered by their bits.
+// Integers are encoded as 8 big-endian bytes with the sign bit flipped, floats as the bits of float64 flipped to sort
+// as the numbers do, and a string First is terminated by "\x00\x01" with each "\x00" in it escaped as "\x00\xff".
+func (k CompositeKey[A, B]) Encode() string {
+	var b []byte
+	b = appendOrdered(b, reflect.ValueOf(k.First), true)
+	b = appendOrdered(b, reflect.ValueOf(k.Second), false)
+	return string(b)
+}
+
+// DecodeCompositeKey returns the CompositeKey Encode returns as s.
+func DecodeCompositeKey[A, B constraints.Ordered](s string) (k CompositeKey[A, B], err error) {
+	var b = []byte(s)
+	if b, err = decodeOrdered(b, reflect.ValueOf(&k.First).Elem(), true); err != nil {
+		return k, err
+	}
+	if b, err = decodeOrdered(b, reflect.ValueOf(&k.Second).Elem(), false); err != nil {
+		return k, err
+	}
+	if len(b) != 0 {
+		return k, fmt.Errorf("%w: %d bytes after it", ErrCorruptCompositeKey, len(b))
+	}
+	return k, nil
+}
+
+// appendOrdered appends the order-preserving encoding of v, terminating a string if it is not last.
+func appendOrdered(dst []byte, v reflect.Value, terminated bool) []byte {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.BigEndian.AppendUint64(dst, uint64(v.Int())^1<<63)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.BigEndian.AppendUint64(dst, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		bits := math.Float64bits(v.Float())
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		return binary.BigEndian.AppendUint64(dst, bits)
+	default:
+		if !terminated {
+			return append(dst, v.String()...)
+		}
+		dst = append(dst, strings.ReplaceAll(v.String(), "\x00", "\x00\xff")...)
+		return append(dst, 0, 1)
+	}
+}
+
+// decodeOrdered sets v to the value appendOrdered encodes at the start of b, and returns the rest of b.
+func decodeOrdered(b []byte, v reflect.Value, terminated bool) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if len(b) < 8 {
+			return nil, fmt.Errorf("%w: %d bytes of a number", ErrCorruptCompositeKey, len(b))
+		}
+		x := binary.BigEndian.Uint64(b)
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if x&(1<<63) != 0 {
+				x &^= 1 << 63
+			} else {
+				x = ^x
+			}
+			v.SetFloat(math.Float64frombits(x))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v.OverflowUint(x) {
+				return nil, fmt.Errorf("%w: %d overflows %v", ErrCorruptCompositeKey, x, v.Type())
+			}
+			v.SetUint(x)
+		default:
+			i := int64(x ^ 1<<63)
+			if v.OverflowInt(i) {
+				return nil, fmt.Errorf("%w: %d overflows %v", ErrCorruptCompositeKey, i, v.Type())
+			}
+			v.SetInt(i)
+		}
+		return b[8:], nil
+	default:
+		if !terminated {
+			v.SetString(string(b))
+			return nil, nil
+		}
+		var s []byte
+		for {
+			i := bytes.IndexByte(b, 0)
+			if i < 0 || i+1 == len(b) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrCorruptCompositeKey)
+			}
+			s = append(s, b[:i]...)
+			switch b[i+1] {
+			case 1:
+				v.SetString(string(s))
+				return b[i+2:], nil
+			case 0xff:
+				s = append(s, 0)
+				b = b[i+2:]
+			default:
+				return nil, fmt.Errorf("%w: invalid escape %#x", ErrCorruptCompositeKey, b[i+1])
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package skip_list
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+// testCompositeKey puts keys into a SkipList by Encode and a CmpSkipList, and checks that both are in the order of Compare.
+func testCompositeKey[A, B constraints.Ordered](t *testing.T, keys []CompositeKey[A, B]) {
+	t.Helper()
+
+	var (
+		sl  = NewSkipList[string, int](10, false)
+		cmp = NewSkipListCmp[CompositeKey[A, B], int](10, false)
+	)
+	for i, k := range keys {
+		sl.Put(k.Encode(), i)
+		cmp.Put(k, i)
+	}
+
+	want := append([]CompositeKey[A, B](nil), keys...)
+	sort.Slice(want, func(i, j int) bool { return want[i].Compare(want[j]) < 0 })
+
+	var got []CompositeKey[A, B]
+	for _, kv := range sl.Items() {
+		k, err := DecodeCompositeKey[A, B](kv.Key())
+		if err != nil {
+			t.Fatalf("DecodeCompositeKey() error = %v", err)
+		}
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+
+	got = got[:0]
+	cmp.ForEach(func(k CompositeKey[A, B], _ int) bool {
+		got = append(got, k)
+		return true
+	})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEach() = %v, want %v", got, want)
+	}
+}
+
+func TestCompositeKey(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	words := []string{"", "a", "a\x00", "a\x00b", "ab", "b", "\x00", "\x00\x01", "\xff"}
+
+	t.Run("TestCompositeKey 1", func(t *testing.T) {
+		// duplicate First ordered by Second
+		var keys []CompositeKey[string, int]
+		for _, w := range words {
+			for i := -3; i <= 3; i++ {
+				keys = append(keys, NewCompositeKey(w, i*1000))
+			}
+		}
+		r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		testCompositeKey(t, keys)
+	})
+
+	t.Run("TestCompositeKey 2", func(t *testing.T) {
+		var keys []CompositeKey[int64, string]
+		for i := 0; i < 200; i++ {
+			keys = append(keys, NewCompositeKey(r.Int63n(20)-10, words[r.Intn(len(words))]))
+		}
+		keys = append(keys, NewCompositeKey[int64, string](math.MinInt64, ""), NewCompositeKey[int64, string](math.MaxInt64, ""))
+		testCompositeKey(t, dedupCompositeKeys(keys))
+	})
+
+	t.Run("TestCompositeKey 3", func(t *testing.T) {
+		var keys []CompositeKey[float64, uint8]
+		for i := 0; i < 200; i++ {
+			keys = append(keys, NewCompositeKey(r.NormFloat64()*1e3, uint8(r.Intn(4))))
+		}
+		keys = append(keys, NewCompositeKey[float64, uint8](math.Inf(-1), 0), NewCompositeKey[float64, uint8](math.Inf(1), 255))
+		testCompositeKey(t, dedupCompositeKeys(keys))
+	})
+
+	t.Run("TestCompositeKey 4", func(t *testing.T) {
+		var keys []CompositeKey[int8, float32]
+		for i := 0; i < 200; i++ {
+			keys = append(keys, NewCompositeKey(int8(r.Intn(256)-128), float32(r.NormFloat64())))
+		}
+		testCompositeKey(t, dedupCompositeKeys(keys))
+	})
+}
+
+func TestDecodeCompositeKey(t *testing.T) {
+	type testCase struct {
+		name string
+		s    string
+	}
+	testCases := []testCase{
+		{name: "TestDecodeCompositeKey 1", s: "a"},
+		{name: "TestDecodeCompositeKey 2", s: "a\x00"},
+		{name: "TestDecodeCompositeKey 3", s: "a\x00\x02"},
+		{name: "TestDecodeCompositeKey 4", s: "a\x00\x01\x00"},
+		{name: "TestDecodeCompositeKey 5", s: "a\x00\x01" + NewCompositeKey[int8, int64](0, 300).Encode()[8:] + "x"},
+		{name: "TestDecodeCompositeKey 6", s: "a\x00\x01" + NewCompositeKey[int8, int64](0, 300).Encode()[8:]},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := DecodeCompositeKey[string, int8](tc.s); !errors.Is(err, ErrCorruptCompositeKey) {
+				t.Errorf("DecodeCompositeKey() error = %v, want %v", err, ErrCorruptCompositeKey)
+			}
+		})
+	}
+}
+
+// dedupCompositeKeys returns keys without duplicates, which a SkipList keeps once.
+func dedupCompositeKeys[A, B constraints.Ordered](keys []CompositeKey[A, B]) []CompositeKey[A, B] {
+	var (
+		seen = make(map[CompositeKey[A, B]]bool)
+		res  []CompositeKey[A, B]
+	)
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			res = append(res, k)
+		}
+	}
+	return res
+}
@@ -0,0 +1,431 @@
+package skip_list
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+// defaultRangeChunk is the number of keys copied per read lock by the scans of ConcurrentSkipList by default.
+const defaultRangeChunk = 1024
+
+type (
+	// ConcurrentSkipList guards a SkipList by a sync.RWMutex, under which its compound operations are atomic.
+	// WithOptimisticLocking, it is backed by a lazy SkipList locking only the predecessors of a write instead.
+	ConcurrentSkipList[O constraints.Ordered, T any] struct {
+		sl *SkipList[O, T]
+		mu sync.RWMutex
+
+		// Get of sl moves its finger, which is written under the write lock
+		finger bool
+
+		// number of keys copied per read lock by scans
+		chunk int
+
+		// replaces sl and mu if not nil
+		opt *optimisticList[O, T]
+
+		// computes of GetOrCompute in flight by key, guarded apart from mu
+		flights   map[O]*flight[T]
+		flightsMu sync.Mutex
+	}
+
+	// flight is a compute of GetOrCompute whose result is shared by the callers waiting for it.
+	flight[T any] struct {
+		done sync.WaitGroup
+		val  T
+		err  error
+	}
+)
+
+var ErrComputePanicked = errors.New("skip_list: compute of GetOrCompute panicked")
+
+// NewConcurrent returns a ConcurrentSkipList of NewSkipList(maxLevel, false, opts...), nil if it is invalid.
+func NewConcurrent[O constraints.Ordered, T any](maxLevel int32, opts ...Option) *ConcurrentSkipList[O, T] {
+	o := newOptions(opts...)
+	if o.optimisticLocking {
+		if maxLevel < 0 || !o.valid() {
+			return nil
+		}
+		return &ConcurrentSkipList[O, T]{opt: newOptimisticList[O, T](maxLevel, o.p)}
+	}
+
+	sl := NewSkipList[O, T](maxLevel, false, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &ConcurrentSkipList[O, T]{sl: sl, finger: sl.finger != nil, chunk: o.rangeChunk}
+}
+
+func (c *ConcurrentSkipList[O, T]) Level() int32 {
+	if c == nil {
+		return 0
+	}
+	if c.opt != nil {
+		return atomic.LoadInt32(&c.opt.level)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Level()
+}
+
+func (c *ConcurrentSkipList[O, T]) Cap() int32 {
+	if c == nil {
+		return 0
+	}
+	if c.opt != nil {
+		return atomic.LoadInt32(&c.opt.cap)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Cap()
+}
+
+func (c *ConcurrentSkipList[O, T]) Get(key O) (val T, exist bool) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		return c.opt.get(key)
+	}
+
+	if c.finger {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	} else {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	// readers share the read lock, so gets of sl is counted atomically
+	atomic.AddUint64(&c.sl.gets, 1)
+
+	if n := c.sl.get(key); n != nil && !n.deleted {
+		return n.val, true
+	}
+	return
+}
+
+// Contains returns whether key exists.
+func (c *ConcurrentSkipList[O, T]) Contains(key O) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+// Range searches the *KvPair of key in [start, end]. They are copied out in chunks of WithRangeChunk keys,
+// releasing the read lock between chunks so that writers are not starved, and the next chunk resumes after the last key copied.
+// Thus Range is not a snapshot under concurrent writes: every key present throughout the call is returned once in order,
+// and keys written during it may or may not be.
+func (c *ConcurrentSkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if c == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	c.scan(&start, &end, func(key O, val T) bool {
+		res = append(res, newKvPair(key, val))
+		return true
+	})
+	return res
+}
+
+// ForEach calls fn for each key in order until fn returns false, outside the lock and as weakly consistent as Range.
+func (c *ConcurrentSkipList[O, T]) ForEach(fn func(key O, val T) bool) {
+	if c == nil {
+		return
+	}
+
+	c.scan(nil, nil, fn)
+}
+
+// AscendRange calls fn for each key in [start, end] in ascending order until fn returns false, as weakly consistent as ForEach.
+func (c *ConcurrentSkipList[O, T]) AscendRange(start, end O, fn func(key O, val T) bool) {
+	if c == nil {
+		return
+	}
+
+	c.scan(&start, &end, fn)
+}
+
+// scan calls fn for each key in [start, end] in order until fn returns false, where nil bounds are unbounded.
+func (c *ConcurrentSkipList[O, T]) scan(start, end *O, fn func(key O, val T) bool) {
+	if c.opt != nil {
+		// reads take no lock
+		n := c.opt.head.nextNodes[0].Load()
+		if start != nil {
+			n = c.opt.ceil(*start)
+		}
+		c.opt.forEach(n, func(key O, val T) bool {
+			return (end == nil || key <= *end) && fn(key, val)
+		})
+		return
+	}
+
+	c.sl.scanChunks(start, end, c.chunk, c.mu.RLock, c.mu.RUnlock, fn)
+}
+
+// Items returns all *KvPair in key order.
+func (c *ConcurrentSkipList[O, T]) Items() []*KvPair[O, T] {
+	if c == nil {
+		return nil
+	}
+	if c.opt != nil {
+		var res = make([]*KvPair[O, T], 0)
+		c.opt.forEach(c.opt.head.nextNodes[0].Load(), func(key O, val T) bool {
+			res = append(res, newKvPair(key, val))
+			return true
+		})
+		return res
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Items()
+}
+
+// Ceil returns *KvPair of the least key greater than or equal to target.
+func (c *ConcurrentSkipList[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
+	if c == nil {
+		return nil, false
+	}
+	if c.opt != nil {
+		if n := c.opt.ceil(target); n != nil {
+			return newKvPair(n.key, *n.val.Load()), true
+		}
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Ceil(target)
+}
+
+// Floor returns *KvPair of the greatest key less than or equal to target.
+func (c *ConcurrentSkipList[O, T]) Floor(target O) (*KvPair[O, T], bool) {
+	if c == nil {
+		return nil, false
+	}
+	if c.opt != nil {
+		if n := c.opt.floor(target); n != nil {
+			return newKvPair(n.key, *n.val.Load()), true
+		}
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Floor(target)
+}
+
+// Min returns *KvPair of the least key.
+func (c *ConcurrentSkipList[O, T]) Min() (*KvPair[O, T], bool) {
+	if c == nil {
+		return nil, false
+	}
+	if c.opt != nil {
+		var res *KvPair[O, T]
+		c.opt.forEach(c.opt.head.nextNodes[0].Load(), func(key O, val T) bool {
+			res = newKvPair(key, val)
+			return false
+		})
+		return res, res != nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Min()
+}
+
+// Max returns *KvPair of the greatest key.
+func (c *ConcurrentSkipList[O, T]) Max() (*KvPair[O, T], bool) {
+	if c == nil {
+		return nil, false
+	}
+	if c.opt != nil {
+		if n := c.opt.last(); n != nil {
+			return newKvPair(n.key, *n.val.Load()), true
+		}
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.sl.Max()
+}
+
+func (c *ConcurrentSkipList[O, T]) Put(key O, val T) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		c.opt.put(key, val, true)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sl.Put(key, val)
+}
+
+func (c *ConcurrentSkipList[O, T]) Delete(key O) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		c.opt.delete(key, nil)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sl.Delete(key)
+}
+
+// GetOrPut returns the value of key if exist, or puts val and returns it otherwise. loaded reports whether key exists.
+func (c *ConcurrentSkipList[O, T]) GetOrPut(key O, val T) (actual T, loaded bool) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		return c.opt.put(key, val, false)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if actual, loaded = c.sl.Get(key); loaded {
+		return actual, true
+	}
+	c.sl.Put(key, val)
+	return val, false
+}
+
+// LoadAndDelete deletes key, and returns its value and whether it exists.
+func (c *ConcurrentSkipList[O, T]) LoadAndDelete(key O) (val T, loaded bool) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		return c.opt.delete(key, nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if val, loaded = c.sl.Get(key); loaded {
+		c.sl.Delete(key)
+	}
+	return
+}
+
+// Swap puts val on key, and returns the previous value and whether key existed.
+func (c *ConcurrentSkipList[O, T]) Swap(key O, val T) (previous T, loaded bool) {
+	if c == nil {
+		return
+	}
+	if c.opt != nil {
+		return c.opt.put(key, val, true)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous, loaded = c.sl.Get(key)
+	c.sl.Put(key, val)
+	return
+}
+
+// CompareAndSwap sets the value of key to new if its value equals old by eq, and returns whether it is set.
+func (c *ConcurrentSkipList[O, T]) CompareAndSwap(key O, old, new T, eq func(a, b T) bool) bool {
+	if c == nil {
+		return false
+	}
+	if c.opt != nil {
+		return c.opt.compareAndSwap(key, func(val T) bool { return eq(val, old) }, new)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sl.CompareAndSwap(key, old, new, eq)
+}
+
+// CompareAndDelete deletes key if its value equals old by eq, and returns whether it is deleted.
+func (c *ConcurrentSkipList[O, T]) CompareAndDelete(key O, old T, eq func(a, b T) bool) bool {
+	if c == nil {
+		return false
+	}
+	if c.opt != nil {
+		_, deleted := c.opt.delete(key, func(val T) bool { return eq(val, old) })
+		return deleted
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sl.CompareAndDelete(key, old, eq)
+}
+
+// GetOrCompute returns the value of key if exist, or puts the value returned by compute and returns it otherwise.
+// compute runs at most once per missing key at a time: other callers for the key wait for it and receive its result.
+// An error is returned to them but neither cached nor put, and a panicking compute gives them ErrComputePanicked.
+// Computes of different keys run concurrently, as neither lock of ConcurrentSkipList is held during them.
+func (c *ConcurrentSkipList[O, T]) GetOrCompute(key O, compute func() (T, error)) (T, error) {
+	if c == nil {
+		var zero T
+		return zero, nil
+	}
+
+	if val, ok := c.Get(key); ok {
+		return val, nil
+	}
+
+	c.flightsMu.Lock()
+	if f, ok := c.flights[key]; ok {
+		c.flightsMu.Unlock()
+		f.done.Wait()
+		return f.val, f.err
+	}
+	if c.flights == nil {
+		c.flights = make(map[O]*flight[T])
+	}
+	f := &flight[T]{err: ErrComputePanicked}
+	f.done.Add(1)
+	c.flights[key] = f
+	c.flightsMu.Unlock()
+
+	defer func() {
+		c.flightsMu.Lock()
+		delete(c.flights, key)
+		c.flightsMu.Unlock()
+		f.done.Done()
+	}()
+
+	// the previous flight may have put key since Get
+	if val, ok := c.Get(key); ok {
+		f.val, f.err = val, nil
+		return val, nil
+	}
+
+	val, err := compute()
+	if err != nil {
+		var zero T
+		f.val, f.err = zero, err
+		return zero, err
+	}
+	f.val, _ = c.GetOrPut(key, val)
+	f.err = nil
+	return f.val, nil
+}
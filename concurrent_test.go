@@ -0,0 +1,542 @@
+package skip_list
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewConcurrent(t *testing.T) {
+	if c := NewConcurrent[int, int](-1); c != nil {
+		t.Errorf("NewConcurrent(-1) = %v, want nil", c)
+	}
+	if c := NewConcurrent[int, int](10, WithProbability(1)); c != nil {
+		t.Errorf("NewConcurrent(WithProbability(1)) = %v, want nil", c)
+	}
+	if c := NewConcurrent[int, int](-1, WithOptimisticLocking()); c != nil {
+		t.Errorf("NewConcurrent(-1, WithOptimisticLocking()) = %v, want nil", c)
+	}
+	if c := NewConcurrent[int, int](10, WithOptimisticLocking(), WithProbability(0)); c != nil {
+		t.Errorf("NewConcurrent(WithOptimisticLocking(), WithProbability(0)) = %v, want nil", c)
+	}
+
+	var c *ConcurrentSkipList[int, int]
+	c.Put(1, 1)
+	c.Delete(1)
+	c.ForEach(func(_, _ int) bool { return true })
+	if _, ok := c.Get(1); ok || c.Contains(1) || c.Cap() != 0 || c.Level() != 0 || c.Range(0, 1) != nil || c.Items() != nil {
+		t.Errorf("nil ConcurrentSkipList is not empty")
+	}
+	if _, loaded := c.GetOrPut(1, 1); loaded {
+		t.Errorf("GetOrPut() loaded = true on nil ConcurrentSkipList")
+	}
+}
+
+func TestConcurrentSkipList(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestConcurrentSkipList 1",
+		},
+		{
+			name: "TestConcurrentSkipList 2",
+			opts: []Option{WithFinger(), WithNodePool()},
+		},
+		{
+			name: "TestConcurrentSkipList 3",
+			opts: []Option{WithTombstones(), WithArena()},
+		},
+		{
+			name: "TestConcurrentSkipList 4",
+			opts: []Option{WithOptimisticLocking()},
+		},
+		{
+			name: "TestConcurrentSkipList 5",
+			opts: []Option{WithOptimisticLocking(), WithProbability(0.25)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the same operations on SkipList and ConcurrentSkipList give the same results
+			var (
+				r    = rand.New(rand.NewSource(1))
+				c    = NewConcurrent[int, int](0, tt.opts...)
+				want = NewSkipList[int, int](0, false)
+			)
+			for i := 0; i < 20000; i++ {
+				key := r.Intn(500)
+				switch r.Intn(6) {
+				case 0, 1:
+					c.Put(key, i)
+					want.Put(key, i)
+				case 2:
+					c.Delete(key)
+					want.Delete(key)
+				case 3:
+					got, gotOk := c.Get(key)
+					val, ok := want.Get(key)
+					if got != val || gotOk != ok || c.Contains(key) != ok {
+						t.Fatalf("Get(%v) = %v, %v, want %v, %v", key, got, gotOk, val, ok)
+					}
+				case 4:
+					got, _ := c.Ceil(key)
+					ceil, _ := want.Ceil(key)
+					if !reflect.DeepEqual(got, ceil) {
+						t.Fatalf("Ceil(%v) = %v, want %v", key, got, ceil)
+					}
+					got, _ = c.Floor(key)
+					floor, _ := want.Floor(key)
+					if !reflect.DeepEqual(got, floor) {
+						t.Fatalf("Floor(%v) = %v, want %v", key, got, floor)
+					}
+				case 5:
+					if got, want := c.Range(key, key+20), want.Range(key, key+20); !reflect.DeepEqual(got, want) {
+						t.Fatalf("Range(%v, %v) = %v, want %v", key, key+20, got, want)
+					}
+				}
+			}
+			if got := c.Cap(); got != want.Cap() {
+				t.Errorf("Cap() = %v, want %v", got, want.Cap())
+			}
+			if got := c.Items(); !reflect.DeepEqual(got, want.Items()) {
+				t.Errorf("Items() = %v, want %v", got, want.Items())
+			}
+			if err := c.validate(); err != nil {
+				t.Errorf("validate() error = %v", err)
+			}
+		})
+	}
+}
+
+// validate validates the SkipList or the optimisticList backing c.
+func (c *ConcurrentSkipList[O, T]) validate() error {
+	if c.opt != nil {
+		return c.opt.validate()
+	}
+	return c.sl.Validate()
+}
+
+func TestConcurrentSkipList_GetOrPut(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		c := NewConcurrent[int, int](10, opts...)
+		c.Put(1, 10)
+		testConcurrentSkipListGetOrPut(t, c)
+	}
+}
+
+func testConcurrentSkipListGetOrPut(t *testing.T, c *ConcurrentSkipList[int, int]) {
+	type testCase struct {
+		name       string
+		key, val   int
+		wantActual int
+		wantLoaded bool
+	}
+	tests := []testCase{
+		{
+			name:       "TestConcurrentSkipList_GetOrPut 1",
+			key:        1,
+			val:        100,
+			wantActual: 10,
+			wantLoaded: true,
+		},
+		{
+			name:       "TestConcurrentSkipList_GetOrPut 2",
+			key:        2,
+			val:        20,
+			wantActual: 20,
+			wantLoaded: false,
+		},
+		{
+			name:       "TestConcurrentSkipList_GetOrPut 3",
+			key:        2,
+			val:        200,
+			wantActual: 20,
+			wantLoaded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, loaded := c.GetOrPut(tt.key, tt.val)
+			if actual != tt.wantActual || loaded != tt.wantLoaded {
+				t.Errorf("GetOrPut() = %v, %v, want %v, %v", actual, loaded, tt.wantActual, tt.wantLoaded)
+			}
+		})
+	}
+}
+
+func TestConcurrentSkipList_LoadAndDelete(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		c := NewConcurrent[int, int](10, opts...)
+		c.Put(1, 10)
+		c.Put(2, 20)
+		testConcurrentSkipListLoadAndDelete(t, c)
+	}
+}
+
+func testConcurrentSkipListLoadAndDelete(t *testing.T, c *ConcurrentSkipList[int, int]) {
+	type testCase struct {
+		name       string
+		key        int
+		wantVal    int
+		wantLoaded bool
+	}
+	tests := []testCase{
+		{
+			name:       "TestConcurrentSkipList_LoadAndDelete 1",
+			key:        1,
+			wantVal:    10,
+			wantLoaded: true,
+		},
+		{
+			name:       "TestConcurrentSkipList_LoadAndDelete 2",
+			key:        1,
+			wantLoaded: false,
+		},
+		{
+			name:       "TestConcurrentSkipList_LoadAndDelete 3",
+			key:        3,
+			wantLoaded: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, loaded := c.LoadAndDelete(tt.key)
+			if val != tt.wantVal || loaded != tt.wantLoaded {
+				t.Errorf("LoadAndDelete() = %v, %v, want %v, %v", val, loaded, tt.wantVal, tt.wantLoaded)
+			}
+		})
+	}
+	if got, want := c.Items(), []*KvPair[int, int]{{2, 20}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestConcurrentSkipList_Stress(t *testing.T) {
+	for _, opts := range [][]Option{{WithFinger()}, {WithOptimisticLocking()}} {
+		testConcurrentSkipListStress(t, NewConcurrent[int, int](0, opts...))
+	}
+}
+
+func testConcurrentSkipListStress(t *testing.T, c *ConcurrentSkipList[int, int]) {
+	const (
+		keys    = 64
+		workers = 16
+		ops     = 2000
+	)
+
+	var (
+		wg sync.WaitGroup
+		// puts[k] - deletes[k] by GetOrPut and LoadAndDelete, which are atomic
+		balance [keys]int64
+		mu      sync.Mutex
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < ops; i++ {
+				key := r.Intn(keys)
+				switch r.Intn(5) {
+				case 0:
+					if _, loaded := c.GetOrPut(key, key); !loaded {
+						mu.Lock()
+						balance[key]++
+						mu.Unlock()
+					}
+				case 1:
+					if val, loaded := c.LoadAndDelete(key); loaded {
+						if val != key {
+							t.Errorf("LoadAndDelete(%v) = %v", key, val)
+						}
+						mu.Lock()
+						balance[key]--
+						mu.Unlock()
+					}
+				case 2:
+					if val, ok := c.Get(key); ok && val != key {
+						t.Errorf("Get(%v) = %v", key, val)
+					}
+				case 3:
+					kvs := c.Range(key, key+8)
+					for j := 1; j < len(kvs); j++ {
+						if kvs[j-1].key >= kvs[j].key {
+							t.Errorf("Range(%v) is not ascending", key)
+						}
+					}
+				case 4:
+					c.Ceil(key)
+					c.Floor(key)
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	for key, b := range balance {
+		if got := c.Contains(key); got != (b == 1) || b < 0 || b > 1 {
+			t.Errorf("Contains(%v) = %v, balance %v", key, got, b)
+		}
+	}
+	if err := c.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
+
+func TestConcurrentSkipList_Range(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestConcurrentSkipList_Range 1",
+			opts: []Option{WithRangeChunk(1)},
+		},
+		{
+			name: "TestConcurrentSkipList_Range 2",
+			opts: []Option{WithRangeChunk(7), WithTombstones()},
+		},
+		{
+			name: "TestConcurrentSkipList_Range 3",
+		},
+		{
+			name: "TestConcurrentSkipList_Range 4",
+			opts: []Option{WithOptimisticLocking()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const keys = 2000
+
+			var (
+				c    = NewConcurrent[int, int](0, tt.opts...)
+				wg   sync.WaitGroup
+				done int32
+			)
+			// even keys exist throughout, odd keys are churned
+			for i := 0; i < keys; i += 2 {
+				c.Put(i, i)
+			}
+			for w := 0; w < 4; w++ {
+				wg.Add(1)
+				go func(seed int64) {
+					defer wg.Done()
+					r := rand.New(rand.NewSource(seed))
+					for atomic.LoadInt32(&done) == 0 {
+						key := r.Intn(keys/2)*2 + 1
+						if r.Intn(2) == 0 {
+							c.Put(key, key)
+						} else {
+							c.Delete(key)
+						}
+					}
+				}(int64(w))
+			}
+
+			check := func(name string, got []int, from int) {
+				for j := 1; j < len(got); j++ {
+					if got[j-1] >= got[j] {
+						t.Fatalf("%s is not ascending at %v", name, got[j-1])
+					}
+				}
+				var even int
+				for _, key := range got {
+					if key%2 == 0 {
+						if key != from+2*even {
+							t.Fatalf("%s misses %v", name, from+2*even)
+						}
+						even++
+					}
+				}
+				if want := (keys - from) / 2; even != want {
+					t.Fatalf("%s has %v even keys, want %v", name, even, want)
+				}
+			}
+			for i := 0; i < 20; i++ {
+				var got []int
+				for _, kv := range c.Range(100, keys) {
+					got = append(got, kv.key)
+				}
+				check("Range()", got, 100)
+
+				got = got[:0]
+				c.ForEach(func(key, _ int) bool {
+					got = append(got, key)
+					return true
+				})
+				check("ForEach()", got, 0)
+			}
+			atomic.StoreInt32(&done, 1)
+			wg.Wait()
+
+			var visited int
+			c.ForEach(func(_, _ int) bool {
+				visited++
+				return visited < 3
+			})
+			if visited != 3 {
+				t.Errorf("ForEach() visited %v keys, want 3", visited)
+			}
+		})
+	}
+
+	if c := NewConcurrent[int, int](10, WithRangeChunk(0)); c != nil {
+		t.Errorf("NewConcurrent(WithRangeChunk(0)) = %v, want nil", c)
+	}
+}
+
+func TestConcurrentSkipList_GetOrCompute(t *testing.T) {
+	const callers = 100
+
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		t.Run("TestConcurrentSkipList_GetOrCompute 1", func(t *testing.T) {
+			// one missing key
+			var (
+				c     = NewConcurrent[int, int](0, opts...)
+				calls int32
+				wg    sync.WaitGroup
+				start = make(chan struct{})
+			)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					val, err := c.GetOrCompute(1, func() (int, error) {
+						atomic.AddInt32(&calls, 1)
+						time.Sleep(10 * time.Millisecond)
+						return 10, nil
+					})
+					if val != 10 || err != nil {
+						t.Errorf("GetOrCompute() = %v, %v, want 10, nil", val, err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+			if calls != 1 {
+				t.Errorf("compute is called %v times, want 1", calls)
+			}
+		})
+
+		t.Run("TestConcurrentSkipList_GetOrCompute 2", func(t *testing.T) {
+			// many missing keys, computed concurrently
+			const keys = 16
+
+			var (
+				c     = NewConcurrent[int, int](0, opts...)
+				calls [keys]int32
+				wg    sync.WaitGroup
+				start = make(chan struct{})
+			)
+			for i := 0; i < callers*keys/4; i++ {
+				wg.Add(1)
+				go func(key int) {
+					defer wg.Done()
+					<-start
+					val, err := c.GetOrCompute(key, func() (int, error) {
+						atomic.AddInt32(&calls[key], 1)
+						time.Sleep(10 * time.Millisecond)
+						return -key, nil
+					})
+					if val != -key || err != nil {
+						t.Errorf("GetOrCompute(%v) = %v, %v, want %v, nil", key, val, err, -key)
+					}
+				}(i % keys)
+			}
+			close(start)
+			wg.Wait()
+			for key, n := range calls {
+				if n != 1 {
+					t.Errorf("compute of %v is called %v times, want 1", key, n)
+				}
+			}
+			if got := c.Cap(); got != keys {
+				t.Errorf("Cap() = %v, want %v", got, keys)
+			}
+		})
+
+		t.Run("TestConcurrentSkipList_GetOrCompute 3", func(t *testing.T) {
+			// errors are shared but neither cached nor put
+			var (
+				c     = NewConcurrent[int, int](0, opts...)
+				calls int32
+				wg    sync.WaitGroup
+				start = make(chan struct{})
+				errC  = errors.New("compute failed")
+			)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					if _, err := c.GetOrCompute(1, func() (int, error) {
+						atomic.AddInt32(&calls, 1)
+						time.Sleep(10 * time.Millisecond)
+						return 0, errC
+					}); err != errC {
+						t.Errorf("GetOrCompute() error = %v, want %v", err, errC)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+			if calls != 1 {
+				t.Errorf("compute is called %v times, want 1", calls)
+			}
+			if c.Contains(1) {
+				t.Errorf("Contains(1) = true after a failed compute")
+			}
+
+			val, err := c.GetOrCompute(1, func() (int, error) { return 10, nil })
+			if val != 10 || err != nil {
+				t.Errorf("GetOrCompute() = %v, %v, want 10, nil", val, err)
+			}
+			// no compute for an existing key
+			val, err = c.GetOrCompute(1, func() (int, error) { return 0, errC })
+			if val != 10 || err != nil {
+				t.Errorf("GetOrCompute() = %v, %v, want 10, nil", val, err)
+			}
+		})
+
+		t.Run("TestConcurrentSkipList_GetOrCompute 4", func(t *testing.T) {
+			// a panicking compute releases its waiters
+			var (
+				c       = NewConcurrent[int, int](0, opts...)
+				entered = make(chan struct{})
+				release = make(chan struct{})
+				waited  = make(chan error)
+			)
+			go func() {
+				defer func() { recover() }()
+				c.GetOrCompute(1, func() (int, error) {
+					close(entered)
+					<-release
+					panic("compute")
+				})
+			}()
+			<-entered
+			go func() {
+				_, err := c.GetOrCompute(1, func() (int, error) { return 1, nil })
+				waited <- err
+			}()
+			// let the waiter block on the flight
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+			if err := <-waited; err != ErrComputePanicked && err != nil {
+				t.Errorf("GetOrCompute() error = %v, want %v or nil", err, ErrComputePanicked)
+			}
+			if val, err := c.GetOrCompute(1, func() (int, error) { return 1, nil }); val != 1 || err != nil {
+				t.Errorf("GetOrCompute() = %v, %v, want 1, nil", val, err)
+			}
+		})
+	}
+}
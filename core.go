@@ -0,0 +1,267 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+)
+
+type (
+	// comparer orders keys, compare returns a negative number, 0 or a positive number
+	// if a is less than, equal to or greater than b.
+	comparer[O any] interface {
+		compare(a, b O) int
+	}
+
+	// compared orders keys by Compare, as CmpSkipList does.
+	compared[O Comparable[O]] struct{}
+
+	// core is the towers of nodes of CmpSkipList, which are searched and linked in the order of C.
+	// SkipList searches by the operators of constraints.Ordered instead, as a call through C is several times slower.
+	core[O any, T any, C comparer[O]] struct {
+		level, maxLevel, cap int32
+
+		// probability of promoting a node to the next level
+		p float64
+
+		// head node of the towers
+		head *node[O, T]
+
+		// randomly generate level when inserting a node
+		r *rand.Rand
+
+		cmp C
+	}
+)
+
+func (compared[O]) compare(a, b O) int {
+	return a.Compare(b)
+}
+
+// get searches key starting from n, whose key must be less than key unless n is head.
+func (c *core[O, T, C]) get(n *node[O, T], key O) *node[O, T] {
+	move := n
+	for l := len(n.nextNodes) - 1; l >= 0; l-- {
+		var sign = 1
+		for move.nextNodes[l] != nil {
+			if sign = c.cmp.compare(move.nextNodes[l].key, key); sign >= 0 {
+				break
+			}
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if sign == 0 {
+			// exist
+			return move.nextNodes[l]
+		}
+
+		// search down
+	}
+	// not exist
+	return nil
+}
+
+// ceil returns the node of the least key greater than or equal to target, including tombstones, or nil if not exist.
+func (c *core[O, T, C]) ceil(target O) *node[O, T] {
+	move := c.head
+	for l := c.level - 1; l >= 0; l-- {
+		var sign = 1
+		for move.nextNodes[l] != nil {
+			if sign = c.cmp.compare(move.nextNodes[l].key, target); sign >= 0 {
+				break
+			}
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if sign == 0 {
+			// equal
+			return move.nextNodes[l]
+		}
+
+		// search down
+	}
+	// move.nextNodes[0] is ceil || move.nextNodes[0] == nil(tail node means ceil is not exist)
+	return move.nextNodes[0]
+}
+
+// floor returns the node of the greatest key less than or equal to target, including tombstones, or head if not exist.
+func (c *core[O, T, C]) floor(target O) *node[O, T] {
+	move := c.head
+	for l := c.level - 1; l >= 0; l-- {
+		var sign = 1
+		for move.nextNodes[l] != nil {
+			if sign = c.cmp.compare(move.nextNodes[l].key, target); sign >= 0 {
+				break
+			}
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if sign == 0 {
+			// equal
+			return move.nextNodes[l]
+		}
+
+		// search down
+	}
+	// move is floor || move == head(head node means floor is not exist)
+	return move
+}
+
+// lower returns the greatest node whose key is less than target, or head if not exist.
+func (c *core[O, T, C]) lower(target O) *node[O, T] {
+	move := c.head
+	for l := c.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && c.cmp.compare(move.nextNodes[l].key, target) < 0 {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		// search down
+	}
+	return move
+}
+
+// link inserts newNode on levels [0, randL] searching from n, whose key must be less than the key of newNode
+// unless n is head, and whose tower must be higher than randL.
+func (c *core[O, T, C]) link(n, newNode *node[O, T], randL int32) {
+	move := n
+	for l := int32(len(n.nextNodes)) - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && c.cmp.compare(move.nextNodes[l].key, newNode.key) < 0 {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if l <= randL {
+			// insert
+			newNode.nextNodes[l] = move.nextNodes[l]
+			move.nextNodes[l] = newNode
+		}
+
+		// search down
+	}
+}
+
+// unlink unlinks the node of key on every level if cond is nil or returns true for it, and returns it,
+// or nil if not exist or the condition is not met. Neither cap nor level is adjusted.
+func (c *core[O, T, C]) unlink(key O, cond func(n *node[O, T]) bool) *node[O, T] {
+	var deleteNode *node[O, T]
+	move := c.head
+	for l := c.level - 1; l >= 0; l-- {
+		var sign = 1
+		for move.nextNodes[l] != nil {
+			if sign = c.cmp.compare(move.nextNodes[l].key, key); sign >= 0 {
+				break
+			}
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if sign == 0 {
+			if deleteNode == nil {
+				if cond != nil && !cond(move.nextNodes[l]) {
+					// condition is not met
+					return nil
+				}
+				deleteNode = move.nextNodes[l]
+			}
+			// delete
+			move.nextNodes[l] = move.nextNodes[l].nextNodes[l]
+		}
+
+		// search down
+	}
+	return deleteNode
+}
+
+// flip continues the coin flips from level randL up to limit, and returns the level reached.
+func (c *core[O, T, C]) flip(randL, limit int32) int32 {
+	for c.r.Float64() < c.p && randL < limit {
+		randL++
+	}
+	return randL
+}
+
+// levelLimit returns the highest level of the coin flips for n nodes, which is floor(log2(n)) bounded by maxLevel.
+// Thus the head of a small CmpSkipList does not carry useless levels.
+func (c *core[O, T, C]) levelLimit(n int32) int32 {
+	if limit := int32(bits.Len32(uint32(n))) - 1; limit < c.maxLevel {
+		return limit
+	}
+	return c.maxLevel
+}
+
+func (c *core[O, T, C]) grow(newL int32) {
+	if c.level < newL {
+		c.head.nextNodes = append(c.head.nextNodes, make([]*node[O, T], newL-c.level)...)
+		c.level = newL
+	}
+}
+
+func (c *core[O, T, C]) cut() {
+	var dif int32
+	for l := c.level - 1; l > 0; l-- {
+		if c.head.nextNodes[l] != nil {
+			break
+		}
+		dif++
+	}
+	c.head.nextNodes = c.head.nextNodes[:c.level-dif]
+
+	c.level -= dif
+}
+
+// validate checks the invariants of the towers, leaving cap to the caller, and returns the first violation found.
+func (c *core[O, T, C]) validate() error {
+	if c.level < 1 || c.level > c.maxLevel+1 {
+		return fmt.Errorf("skip_list: level %d out of [1, %d]", c.level, c.maxLevel+1)
+	}
+	if len(c.head.nextNodes) != int(c.level) {
+		return fmt.Errorf("skip_list: head has %d levels, want %d", len(c.head.nextNodes), c.level)
+	}
+	if c.level > 1 && c.head.nextNodes[c.level-1] == nil {
+		return fmt.Errorf("skip_list: top level %d is empty", c.level-1)
+	}
+
+	// heights[l] is the number of nodes with more than l levels
+	var heights = make([]int32, c.level)
+	for n := c.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if h := len(n.nextNodes); h < 1 || h > int(c.level) {
+			return fmt.Errorf("skip_list: node %v has %d levels, want [1, %d]", n.key, h, c.level)
+		}
+		for l := range n.nextNodes {
+			heights[l]++
+		}
+	}
+
+	for l := int32(0); l < c.level; l++ {
+		var (
+			count int32
+			lower = c.head
+		)
+		for n := c.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			if len(n.nextNodes) <= int(l) {
+				return fmt.Errorf("skip_list: node %v on level %d has %d levels", n.key, l, len(n.nextNodes))
+			}
+			if next := n.nextNodes[l]; next != nil && c.cmp.compare(next.key, n.key) <= 0 {
+				return fmt.Errorf("skip_list: level %d is not ascending at %v", l, n.key)
+			}
+			if l > 0 {
+				// every node on level l is on level l-1
+				for lower != nil && lower != n {
+					lower = lower.nextNodes[l-1]
+				}
+				if lower == nil {
+					return fmt.Errorf("skip_list: node %v on level %d is not on level %d", n.key, l, l-1)
+				}
+			}
+			count++
+		}
+		if count != heights[l] {
+			return fmt.Errorf("skip_list: %d nodes on level %d, want %d", count, l, heights[l])
+		}
+	}
+	return nil
+}
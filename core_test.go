@@ -0,0 +1,76 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// ascKey orders int in ascending order by Compare.
+type ascKey int
+
+func (k ascKey) Compare(other ascKey) int {
+	switch {
+	case k < other:
+		return -1
+	case k > other:
+		return 1
+	}
+	return 0
+}
+
+func TestCore(t *testing.T) {
+	// CmpSkipList on the core flips the coins as SkipList does, so the same writes build the same towers
+	var (
+		sl  = NewSkipList[int, int](10, false, WithRandSource(rand.NewSource(1)))
+		csl = NewSkipListCmp[ascKey, int](10, false, WithRandSource(rand.NewSource(1)))
+		rnd = rand.New(rand.NewSource(2))
+	)
+	for i := 0; i < 5000; i++ {
+		key, val := rnd.Intn(1000), rnd.Int()
+		if rnd.Intn(3) == 0 {
+			sl.Delete(key)
+			csl.Delete(ascKey(key))
+		} else {
+			sl.Put(key, val)
+			csl.Put(ascKey(key), val)
+		}
+	}
+
+	if err := csl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if sl.Level() != csl.Level() || sl.Cap() != csl.Cap() {
+		t.Errorf("CmpSkipList Level(), Cap() = %v, %v, want %v, %v", csl.Level(), csl.Cap(), sl.Level(), sl.Cap())
+	}
+	for l := int32(0); l < sl.Level(); l++ {
+		var got, want []int
+		for n := csl.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			got = append(got, int(n.key))
+		}
+		for n := sl.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			want = append(want, n.key)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("level %d of CmpSkipList = %v, want %v", l, got, want)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		target := rnd.Intn(1100) - 50
+		if kv, ok := sl.Ceil(target); ok {
+			if k, v, _ := csl.Ceil(ascKey(target)); int(k) != kv.key || v != kv.val {
+				t.Fatalf("CmpSkipList Ceil(%v) = %v, %v, want %v", target, k, v, kv)
+			}
+		} else if _, _, ok := csl.Ceil(ascKey(target)); ok {
+			t.Fatalf("CmpSkipList Ceil(%v) exists", target)
+		}
+		if kv, ok := sl.Floor(target); ok {
+			if k, v, _ := csl.Floor(ascKey(target)); int(k) != kv.key || v != kv.val {
+				t.Fatalf("CmpSkipList Floor(%v) = %v, %v, want %v", target, k, v, kv)
+			}
+		} else if _, _, ok := csl.Floor(ascKey(target)); ok {
+			t.Fatalf("CmpSkipList Floor(%v) exists", target)
+		}
+	}
+}
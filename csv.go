@@ -0,0 +1,135 @@
+package skip_list
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrCSVHeader = errors.New(`skip_list: CSV header is not "key,value"`)
+
+// csvHeader is the header record of ExportCSV.
+var csvHeader = []string{"key", "value"}
+
+// WriteCSV writes one record per kv-pair in key order, formatted by format.
+func (sl *SkipList[O, T]) WriteCSV(w io.Writer, format func(key O, val T) []string) error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	cw := csv.NewWriter(w)
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if err := cw.Write(format(n.key, n.val)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV puts every record of r parsed by parse into SkipList.
+// Records before a malformed one are kept, and the error reports the line of the malformed record.
+func (sl *SkipList[O, T]) ReadCSV(r io.Reader, parse func(record []string) (O, T, error)) error {
+	if sl == nil {
+		return nil
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		key, val, err := parse(record)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		sl.Put(key, val)
+	}
+}
+
+// ExportCSV writes the header "key,value" and then one record of the key and the value per kv-pair in key order,
+// formatted by encodeKey and encodeVal and quoted by encoding/csv, which reads "\r\n" in a quoted field back as "\n".
+func (sl *SkipList[O, T]) ExportCSV(w io.Writer, encodeKey func(key O) string, encodeVal func(val T) string) error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if err := cw.Write([]string{encodeKey(n.key), encodeVal(n.val)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV returns a SkipList of the records after the header ExportCSV writes to r, parsed by decodeKey and decodeVal and built in
+// one pass as BulkLoad does. Unsorted records are sorted first, and the last one of duplicate keys wins.
+// The error of a malformed record reports its line, and the first record must be the header "key,value",
+// otherwise ErrCSVHeader is returned rather than dropping a record.
+func ImportCSV[O constraints.Ordered, T any](r io.Reader, decodeKey func(s string) (O, error), decodeVal func(s string) (T, error),
+	maxLevel int32, isConcurrent bool, opts ...Option) (*SkipList[O, T], error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	header, err := cr.Read()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if err == nil && !slices.Equal(header, csvHeader) {
+		line, _ := cr.FieldPos(0)
+		return nil, fmt.Errorf("line %d: %w", line, ErrCSVHeader)
+	}
+
+	var pairs []*KvPair[O, T]
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		key, err := decodeKey(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: key: %w", line, err)
+		}
+		val, err := decodeVal(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: value: %w", line, err)
+		}
+		pairs = append(pairs, newKvPair(key, val))
+	}
+
+	sl := BulkLoad(maxLevel, isConcurrent, pairs, opts...)
+	if sl == nil {
+		return nil, ErrInvalidConfig
+	}
+	return sl, nil
+}
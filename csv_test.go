@@ -0,0 +1,256 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestSkipList_WriteCSV(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name   string
+		sl     *SkipList[O, T]
+		format func(key O, val T) []string
+		want   [][]string
+	}
+
+	var sl = NewSkipList[int, string](10, false)
+	sl.Put(3, "c")
+	sl.Put(1, "a, \"quoted\"")
+	sl.Put(2, "b")
+
+	format := func(key int, val string) []string {
+		return []string{strconv.Itoa(key), val}
+	}
+	tests := []testCase[int, string]{
+		{
+			name:   "TestSkipList_WriteCSV 1",
+			sl:     NewSkipList[int, string](10, false),
+			format: format,
+			want:   nil,
+		},
+		{
+			name:   "TestSkipList_WriteCSV 2",
+			sl:     sl,
+			format: format,
+			want:   [][]string{{"1", "a, \"quoted\""}, {"2", "b"}, {"3", "c"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.sl.WriteCSV(&buf, tt.format); err != nil {
+				t.Fatalf("WriteCSV() error = %v", err)
+			}
+			got, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WriteCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_ReadCSV(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name    string
+		input   string
+		want    []*KvPair[O, T]
+		wantErr string
+	}
+
+	parse := func(record []string) (int, string, error) {
+		if len(record) != 2 {
+			return 0, "", fmt.Errorf("want 2 fields, got %d", len(record))
+		}
+		key, err := strconv.Atoi(record[0])
+		return key, record[1], err
+	}
+	tests := []testCase[int, string]{
+		{
+			name:  "TestSkipList_ReadCSV 1",
+			input: "3,c\n1,\"a, \"\"quoted\"\"\"\n2,b\n",
+			want:  []*KvPair[int, string]{{1, "a, \"quoted\""}, {2, "b"}, {3, "c"}},
+		},
+		{
+			name:    "TestSkipList_ReadCSV 2",
+			input:   "1,a\n2,b\nx,c\n4,d\n",
+			want:    []*KvPair[int, string]{{1, "a"}, {2, "b"}},
+			wantErr: "line 3: ",
+		},
+		{
+			name:    "TestSkipList_ReadCSV 3",
+			input:   "1,a\n2\n",
+			want:    []*KvPair[int, string]{{1, "a"}},
+			wantErr: "line 2: want 2 fields, got 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, string](10, false)
+			err := sl.ReadCSV(strings.NewReader(tt.input), parse)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ReadCSV() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Fatalf("ReadCSV() error = %v, want prefix %q", err, tt.wantErr)
+			}
+			if got := sl.Range(0, 10); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("TestSkipList_ReadCSV 4", func(t *testing.T) {
+		src := NewSkipList[int, string](10, false)
+		for i := 0; i < 100; i++ {
+			src.Put(i, strconv.Itoa(i*i))
+		}
+		var buf bytes.Buffer
+		if err := src.WriteCSV(&buf, func(key int, val string) []string {
+			return []string{strconv.Itoa(key), val}
+		}); err != nil {
+			t.Fatalf("WriteCSV() error = %v", err)
+		}
+
+		dst := NewSkipList[int, string](10, false)
+		if err := dst.ReadCSV(&buf, parse); err != nil {
+			t.Fatalf("ReadCSV() error = %v", err)
+		}
+		if got, want := dst.Range(0, 100), src.Range(0, 100); !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadCSV() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSkipList_ExportCSV(t *testing.T) {
+	type testCase struct {
+		name string
+		vals []string
+	}
+	tests := []testCase{
+		{name: "TestSkipList_ExportCSV 1", vals: []string{"plain", "a, b", "say \"hi\"", "two\nlines", "\"\"", ""}},
+		{name: "TestSkipList_ExportCSV 2", vals: []string{"tab\tend", " leading space", ",", "\n"}},
+		{name: "TestSkipList_ExportCSV 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[string, string](10, false)
+			for i, v := range tt.vals {
+				// keys with commas, quotes and newlines as well
+				sl.Put(strconv.Itoa(i)+","+v, v)
+			}
+
+			var buf bytes.Buffer
+			identity := func(s string) string { return s }
+			if err := sl.ExportCSV(&buf, identity, identity); err != nil {
+				t.Fatalf("ExportCSV() error = %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), "key,value\n") {
+				t.Errorf("ExportCSV() = %q, want the header first", buf.String())
+			}
+
+			decode := func(s string) (string, error) { return s, nil }
+			got, err := ImportCSV(&buf, decode, decode, 10, false)
+			if err != nil {
+				t.Fatalf("ImportCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Items(), sl.Items()) {
+				t.Errorf("ImportCSV() = %v, want %v", got.Items(), sl.Items())
+			}
+		})
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name    string
+		input   string
+		want    []*KvPair[O, T]
+		wantErr string
+	}
+
+	decodeVal := func(s string) (string, error) {
+		if s == "bad" {
+			return "", errors.New("bad value")
+		}
+		return s, nil
+	}
+	tests := []testCase[int, string]{
+		{
+			// unsorted with a duplicate
+			name:  "TestImportCSV 1",
+			input: "key,value\n3,c\n1,\"a,\n\"\"b\"\"\"\n2,b\n3,last\n",
+			want:  []*KvPair[int, string]{{1, "a,\n\"b\""}, {2, "b"}, {3, "last"}},
+		},
+		{
+			name:  "TestImportCSV 2",
+			input: "",
+			want:  []*KvPair[int, string]{},
+		},
+		{
+			name:    "TestImportCSV 3",
+			input:   "key,value\n1,a\n\"multi\nline\",b\n",
+			wantErr: "line 3: key: ",
+		},
+		{
+			name:    "TestImportCSV 4",
+			input:   "key,value\n1,a\n2,bad\n",
+			wantErr: "line 3: value: bad value",
+		},
+		{
+			name:    "TestImportCSV 5",
+			input:   "key,value\n1,a,extra\n",
+			wantErr: "record on line 2: wrong number of fields",
+		},
+		// a headerless file loses no record
+		{
+			name:    "TestImportCSV 6",
+			input:   "1,a\n2,b\n",
+			wantErr: `line 1: skip_list: CSV header is not "key,value"`,
+		},
+		{
+			name:    "TestImportCSV 7",
+			input:   "value,key\n1,a\n",
+			wantErr: `line 1: skip_list: CSV header is not "key,value"`,
+		},
+		{
+			name:  "TestImportCSV 8",
+			input: "key,value\n",
+			want:  []*KvPair[int, string]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportCSV(strings.NewReader(tt.input), strconv.Atoi, decodeVal, 10, false)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ImportCSV() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Items(), tt.want) {
+				t.Errorf("ImportCSV() = %v, want %v", got.Items(), tt.want)
+			}
+		})
+	}
+
+	if _, err := ImportCSV(strings.NewReader("1,a\n"), strconv.Atoi, decodeVal, 10, false); !errors.Is(err, ErrCSVHeader) {
+		t.Errorf("ImportCSV() error = %v, want %v", err, ErrCSVHeader)
+	}
+	if _, err := ImportCSV(strings.NewReader(""), strconv.Atoi, decodeVal, -1, false); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("ImportCSV() error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,59 @@
+package skip_list
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpDOT writes the structure of SkipList in Graphviz DOT language, tombstones are dashed.
+func (sl *SkipList[O, T]) DumpDOT(w io.Writer) error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	// name of every node, head node is "head"
+	var names = map[*node[O, T]]string{sl.head: "head"}
+	for n, i := sl.head.nextNodes[0], 1; n != nil; n, i = n.nextNodes[0], i+1 {
+		names[n] = fmt.Sprintf("n%d", i)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph SkipList {\n\trankdir=LR;\n\tnode [shape=record];\n")
+	for n := sl.head; n != nil; n = n.nextNodes[0] {
+		var label = "head"
+		if n != sl.head {
+			label = dotEscape(fmt.Sprint(n.key))
+		}
+		for l := len(n.nextNodes) - 1; l >= 0; l-- {
+			label += fmt.Sprintf("|<l%d>", l)
+		}
+		if n.deleted {
+			// tombstone
+			fmt.Fprintf(bw, "\t%s [label=\"%s\", style=dashed];\n", names[n], label)
+			continue
+		}
+		fmt.Fprintf(bw, "\t%s [label=\"%s\"];\n", names[n], label)
+	}
+	for n := sl.head; n != nil; n = n.nextNodes[0] {
+		for l := len(n.nextNodes) - 1; l >= 0; l-- {
+			if next := n.nextNodes[l]; next != nil {
+				fmt.Fprintf(bw, "\t%s:l%d -> %s:l%d;\n", names[n], l, names[next], l)
+			}
+		}
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `|`, `\|`, `{`, `\{`, `}`, `\}`, `<`, `\<`, `>`, `\>`)
+
+func dotEscape(s string) string {
+	return dotEscaper.Replace(s)
+}
@@ -0,0 +1,65 @@
+package skip_list
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestSkipList_DumpDOT(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		want string
+	}
+
+	var sl = NewSkipList[string, int](10, false, WithLevelFunc(func(n int) int { return n % 3 }))
+	sl.Put("a", 1)
+	sl.Put("b", 2)
+	sl.Put("c|d", 3)
+	sl.Put("e", 4)
+
+	tests := []testCase[string, int]{
+		{
+			name: "TestSkipList_DumpDOT 1",
+			sl:   NewSkipList[string, int](10, false),
+			want: "digraph SkipList {\n" +
+				"\trankdir=LR;\n" +
+				"\tnode [shape=record];\n" +
+				"\thead [label=\"head|<l0>\"];\n" +
+				"}\n",
+		},
+		{
+			name: "TestSkipList_DumpDOT 2",
+			sl:   sl,
+			want: "digraph SkipList {\n" +
+				"\trankdir=LR;\n" +
+				"\tnode [shape=record];\n" +
+				"\thead [label=\"head|<l2>|<l1>|<l0>\"];\n" +
+				"\tn1 [label=\"a|<l0>\"];\n" +
+				"\tn2 [label=\"b|<l1>|<l0>\"];\n" +
+				"\tn3 [label=\"c\\|d|<l2>|<l1>|<l0>\"];\n" +
+				"\tn4 [label=\"e|<l0>\"];\n" +
+				"\thead:l2 -> n3:l2;\n" +
+				"\thead:l1 -> n2:l1;\n" +
+				"\thead:l0 -> n1:l0;\n" +
+				"\tn1:l0 -> n2:l0;\n" +
+				"\tn2:l1 -> n3:l1;\n" +
+				"\tn2:l0 -> n3:l0;\n" +
+				"\tn3:l0 -> n4:l0;\n" +
+				"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.sl.DumpDOT(&buf); err != nil {
+				t.Fatalf("DumpDOT() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("DumpDOT() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
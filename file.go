@@ -0,0 +1,107 @@
+package skip_list
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/constraints"
+)
+
+// DefaultFileMode is the permission bits of the files SaveFile writes.
+const DefaultFileMode fs.FileMode = 0o644
+
+var ErrInvalidConfig = errors.New("skip_list: invalid maxLevel or options")
+
+// SaveFile writes kv-pairs in the binary form WriteTo streams to path with DefaultFileMode, see SaveFileMode.
+func (sl *SkipList[O, T]) SaveFile(path string) error {
+	return sl.SaveFileMode(path, DefaultFileMode)
+}
+
+// SaveFileMode writes kv-pairs in the binary form WriteTo streams to a temporary file in the directory of path,
+// syncs it and renames it over path with the permission bits perm, so that path holds either the old or the new
+// snapshot, never a part of it. A failing write removes the temporary file and leaves path untouched.
+func (sl *SkipList[O, T]) SaveFileMode(path string, perm fs.FileMode) error {
+	if sl == nil {
+		return nil
+	}
+
+	return writeFileAtomic(path, perm, func(w io.Writer) error {
+		_, err := sl.WriteTo(w)
+		return err
+	})
+}
+
+// LoadFile returns a SkipList of the kv-pairs SaveFile writes to path, built in one pass as BulkLoad does.
+// The snapshot is verified by its checksum, and a file with bytes after it is rejected with ErrCorruptBinary.
+func LoadFile[O constraints.Ordered, T any](path string, maxLevel int32, isConcurrent bool, opts ...Option) (*SkipList[O, T], error) {
+	sl := NewSkipList[O, T](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil, ErrInvalidConfig
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	n, err := sl.ReadFrom(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if n != info.Size() {
+		return nil, fmt.Errorf("%s: %w: %d bytes after the snapshot", path, ErrCorruptBinary, info.Size()-n)
+	}
+	return sl, nil
+}
+
+// writeFileAtomic calls write with a temporary file in the directory of path, and renames it over path with perm
+// once write returns nil and the file is synced. Otherwise the temporary file is removed.
+func writeFileAtomic(path string, perm fs.FileMode, write func(w io.Writer) error) (err error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	f, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	if err = write(f); err != nil {
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	// persist the rename, which is not supported by every platform
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
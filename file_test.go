@@ -0,0 +1,153 @@
+package skip_list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSkipList_SaveFile(t *testing.T) {
+	src := NewSkipList[string, []byte](0, false)
+	for i := 0; i < 5000; i++ {
+		src.Put(string(rune('a'+i%26))+string(rune(i)), bytes.Repeat([]byte{byte(i)}, i%7))
+	}
+
+	t.Run("TestSkipList_SaveFile 1", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot")
+		if err := src.SaveFile(path); err != nil {
+			t.Fatalf("SaveFile() error = %v", err)
+		}
+		dst, err := LoadFile[string, []byte](path, 0, false)
+		if err != nil {
+			t.Fatalf("LoadFile() error = %v", err)
+		}
+		if dst.Cap() != src.Cap() {
+			t.Fatalf("Cap() = %v, want %v", dst.Cap(), src.Cap())
+		}
+		for _, kv := range src.Items() {
+			if val, ok := dst.Get(kv.Key()); !ok || !bytes.Equal(val, kv.Val()) {
+				t.Fatalf("Get(%q) = %v, %v, want %v, true", kv.Key(), val, ok, kv.Val())
+			}
+		}
+		if err := dst.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("TestSkipList_SaveFile 2", func(t *testing.T) {
+		// an empty list
+		path := filepath.Join(t.TempDir(), "snapshot")
+		if err := NewSkipList[int, string](10, false).SaveFile(path); err != nil {
+			t.Fatalf("SaveFile() error = %v", err)
+		}
+		dst, err := LoadFile[int, string](path, 10, true)
+		if err != nil || dst.Cap() != 0 {
+			t.Errorf("LoadFile() = %v, %v, want an empty list", dst, err)
+		}
+	})
+
+	t.Run("TestSkipList_SaveFile 3", func(t *testing.T) {
+		// permissions and overwriting
+		dir := t.TempDir()
+		path := filepath.Join(dir, "snapshot")
+		sl := NewSkipList[int, string](10, false)
+		sl.Put(1, "1")
+		if err := sl.SaveFileMode(path, 0o600); err != nil {
+			t.Fatalf("SaveFileMode() error = %v", err)
+		}
+		sl.Put(2, "2")
+		if err := sl.SaveFileMode(path, 0o640); err != nil {
+			t.Fatalf("SaveFileMode() error = %v", err)
+		}
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o640 {
+			t.Errorf("Stat() = %v, %v, want mode %v", info.Mode(), err, os.FileMode(0o640))
+		}
+		dst, err := LoadFile[int, string](path, 10, false)
+		if err != nil || !reflect.DeepEqual(dst.Items(), sl.Items()) {
+			t.Errorf("LoadFile() = %v, %v, want %v", dst.Items(), err, sl.Items())
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("ReadDir() = %v, want only the snapshot", entries)
+		}
+	})
+
+	t.Run("TestSkipList_SaveFile 4", func(t *testing.T) {
+		// a write failing mid-way leaves the old snapshot untouched
+		dir := t.TempDir()
+		path := filepath.Join(dir, "snapshot")
+		old := NewSkipList[int, string](10, false)
+		old.Put(1, "1")
+		if err := old.SaveFile(path); err != nil {
+			t.Fatalf("SaveFile() error = %v", err)
+		}
+		want, _ := os.ReadFile(path)
+
+		data, _ := src.MarshalBinary()
+		err := writeFileAtomic(path, DefaultFileMode, func(w io.Writer) error {
+			_, err := src.WriteTo(io.MultiWriter(&failingWriter{n: len(data) / 2}, w))
+			return err
+		})
+		if !errors.Is(err, errFailingWriter) {
+			t.Fatalf("writeFileAtomic() error = %v, want %v", err, errFailingWriter)
+		}
+		if got, _ := os.ReadFile(path); !bytes.Equal(got, want) {
+			t.Errorf("ReadFile() = %v, want the old snapshot %v", got, want)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("ReadDir() = %v, want only the snapshot", entries)
+		}
+	})
+
+	t.Run("TestSkipList_SaveFile 5", func(t *testing.T) {
+		// a missing directory
+		if err := src.SaveFile(filepath.Join(t.TempDir(), "missing", "snapshot")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("SaveFile() error = %v, want %v", err, os.ErrNotExist)
+		}
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	sl := NewSkipList[int, string](10, false)
+	for i := 0; i < 10; i++ {
+		sl.Put(i, "v")
+	}
+	path := filepath.Join(dir, "snapshot")
+	if err := sl.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+
+	write := func(name string, data []byte) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	type testCase struct {
+		name     string
+		path     string
+		maxLevel int32
+		wantErr  error
+	}
+	testCases := []testCase{
+		{name: "TestLoadFile 1", path: filepath.Join(dir, "missing"), maxLevel: 10, wantErr: os.ErrNotExist},
+		{name: "TestLoadFile 2", path: write("truncated", data[:len(data)-3]), maxLevel: 10, wantErr: ErrTruncated},
+		{name: "TestLoadFile 3", path: write("trailing", append(append([]byte(nil), data...), 0)), maxLevel: 10, wantErr: ErrCorruptBinary},
+		{name: "TestLoadFile 4", path: path, maxLevel: -1, wantErr: ErrInvalidConfig},
+		{name: "TestLoadFile 5", path: write("empty", nil), maxLevel: 10, wantErr: ErrTruncated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, err := LoadFile[int, string](tc.path, tc.maxLevel, false); !errors.Is(err, tc.wantErr) || got != nil {
+				t.Errorf("LoadFile() = %v, %v, want nil, %v", got, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package skip_list
+
+// seek fills finger with the predecessors of key on every level and returns the node following the one on level 0.
+// The search starts from the finger of the previous operation if its key is less than key, climbing only as high as
+// the distance requires, or descends from head otherwise.
+// finger must hold the exact predecessors of some key, which every structural change other than seek breaks,
+// so they call resetFinger.
+func (sl *SkipList[O, T]) seek(key O) *node[O, T] {
+	for int32(len(sl.finger)) < sl.level {
+		// new levels are empty
+		sl.finger = append(sl.finger, sl.head)
+	}
+	f := sl.finger
+
+	var l int32
+	if f[0] != sl.head && f[0].key < key {
+		// climb while the finger falls short of key
+		for l < sl.level-1 && f[l].nextNodes[l] != nil && f[l].nextNodes[l].key < key {
+			l++
+		}
+	} else {
+		// descend from head
+		l = sl.level - 1
+		f[l] = sl.head
+	}
+
+	move := f[l]
+	for ; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+		f[l] = move
+
+		// search down
+	}
+	return move.nextNodes[0]
+}
+
+// resetFinger points finger to head, which precedes every key.
+func (sl *SkipList[O, T]) resetFinger() {
+	for l := range sl.finger {
+		sl.finger[l] = sl.head
+	}
+}
+
+// deleteFinger is delete searching by seek, which leaves finger as the predecessors of the unlinked node.
+func (sl *SkipList[O, T]) deleteFinger(key O, cond func(n *node[O, T]) bool) bool {
+	deleteNode := sl.seek(key)
+	if deleteNode == nil || deleteNode.key != key || (cond != nil && !cond(deleteNode)) {
+		return false
+	}
+
+	for l := range deleteNode.nextNodes {
+		// delete
+		sl.finger[l].nextNodes[l] = deleteNode.nextNodes[l]
+	}
+	val := deleteNode.val
+	sl.freeNode(deleteNode)
+
+	// cut
+	sl.cut()
+
+	sl.cap--
+
+	// tune
+	sl.tune(sl.cap)
+
+	sl.deleted(key, val)
+	return true
+}
@@ -0,0 +1,144 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWithFinger(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+	}
+	tests := []testCase{
+		{
+			name:     "TestWithFinger 1",
+			maxLevel: 16,
+		},
+		{
+			name:     "TestWithFinger 2",
+			maxLevel: 0,
+		},
+		{
+			name:     "TestWithFinger 3",
+			maxLevel: 16,
+			opts:     []Option{WithNodePool()},
+		},
+		{
+			name:     "TestWithFinger 4",
+			maxLevel: 16,
+			opts:     []Option{WithTombstones()},
+		},
+		{
+			name:     "TestWithFinger 5",
+			maxLevel: 0,
+			opts:     []Option{WithArena(), WithTombstones()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				r   = rand.New(rand.NewSource(1))
+				sl  = NewSkipList[int, int](tt.maxLevel, false, append(tt.opts, WithFinger(), WithRandSource(rand.NewSource(2)))...)
+				ref = make(map[int]int)
+				cur int
+			)
+			for i := 0; i < 100000; i++ {
+				// mostly near the previous key, sometimes anywhere
+				if r.Intn(10) == 0 {
+					cur = r.Intn(5000)
+				} else {
+					cur += r.Intn(7) - 2
+				}
+
+				switch op := r.Intn(100); {
+				case op < 40:
+					sl.Put(cur, i)
+					ref[cur] = i
+				case op < 70:
+					sl.Delete(cur)
+					delete(ref, cur)
+				case op < 98:
+					got, gotOk := sl.Get(cur)
+					want, wantOk := ref[cur]
+					if got != want || gotOk != wantOk {
+						t.Fatalf("Get(%d) = %v, %v, want %v, %v", cur, got, gotOk, want, wantOk)
+					}
+				case op < 99:
+					sl.DeleteIf(func(key, _ int) bool { return key%97 == 0 })
+					for key := range ref {
+						if key%97 == 0 {
+							delete(ref, key)
+						}
+					}
+				default:
+					sl.Compact()
+				}
+
+				if i%10000 == 0 {
+					if err := sl.Validate(); err != nil {
+						t.Fatalf("Validate() error = %v after %d operations", err, i)
+					}
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			var keys = make([]int, 0, len(ref))
+			for key := range ref {
+				keys = append(keys, key)
+			}
+			sort.Ints(keys)
+			var want = make([]*KvPair[int, int], 0, len(keys))
+			for _, key := range keys {
+				want = append(want, NewKvPair(key, ref[key]))
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkWithFinger(b *testing.B) {
+	const n = 1 << 20
+
+	type benchCase struct {
+		name string
+		key  func(i int) int
+	}
+	cases := []benchCase{
+		{"Sequential", func(i int) int { return i % n }},
+		{"NearSequential", func(i int) int { return (i + i%5*3) % n }},
+		{"Random", func(i int) int { return i * 7919 % n }},
+	}
+	for _, bc := range cases {
+		for _, finger := range []bool{false, true} {
+			var opts []Option
+			name := bc.name + "/Head"
+			if finger {
+				opts = append(opts, WithFinger())
+				name = bc.name + "/Finger"
+			}
+
+			sl := NewSkipList[int, int](20, false, opts...)
+			for i := 0; i < n; i++ {
+				sl.Put(i, i)
+			}
+			b.Run(name+"/Get", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sl.Get(bc.key(i))
+				}
+			})
+			b.Run(name+"/Put", func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					sl.Put(bc.key(i), i)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package skip_list
+
+import (
+	"sort"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// Frozen is an immutable copy of SkipList laid out in a sorted key slice and a parallel value slice,
+	// or served from the bytes of Encode by OpenFrozen, which answers reads by binary search and index arithmetic.
+	// It has no method to mutate it.
+	Frozen[O constraints.Ordered, T any] struct {
+		keys []O
+		vals []T
+
+		// encoded form replacing keys and vals if not nil
+		enc *frozenEncoding[O, T]
+	}
+)
+
+// Freeze returns a Frozen copy of SkipList, walking level 0 once.
+func (sl *SkipList[O, T]) Freeze() *Frozen[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var f = &Frozen[O, T]{
+		keys: make([]O, 0, sl.cap),
+		vals: make([]T, 0, sl.cap),
+	}
+	for n := sl.head.next(); n != nil; n = n.next() {
+		f.keys = append(f.keys, n.key)
+		f.vals = append(f.vals, n.val)
+	}
+	return f
+}
+
+// Cap returns the number of keys.
+func (f *Frozen[O, T]) Cap() int {
+	if f == nil {
+		return 0
+	}
+	return f.len()
+}
+
+func (f *Frozen[O, T]) Get(key O) (val T, exist bool) {
+	if i, ok := f.Rank(key); ok {
+		return f.val(i)
+	}
+	return
+}
+
+// Rank returns the index of key in key order and whether it exists, or the index it would be inserted at if not exist.
+func (f *Frozen[O, T]) Rank(key O) (int, bool) {
+	if f == nil {
+		return 0, false
+	}
+
+	i := f.ceil(key)
+	return i, i < f.len() && f.key(i) == key
+}
+
+// At returns *KvPair of the i-th key in key order.
+func (f *Frozen[O, T]) At(i int) (*KvPair[O, T], bool) {
+	if f == nil || i < 0 || i >= f.len() {
+		return nil, false
+	}
+	return f.pair(i)
+}
+
+// Range searches the *KvPair of key in [start, end].
+func (f *Frozen[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if f == nil {
+		return nil
+	}
+
+	var (
+		lo  = f.ceil(start)
+		hi  = f.floor(end) + 1
+		res = make([]*KvPair[O, T], 0)
+	)
+	for i := lo; i < hi; i++ {
+		if kv, ok := f.pair(i); ok {
+			res = append(res, kv)
+		}
+	}
+	return res
+}
+
+// Items returns all *KvPair in key order.
+func (f *Frozen[O, T]) Items() []*KvPair[O, T] {
+	if f == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0, f.len())
+	for i := 0; i < f.len(); i++ {
+		if kv, ok := f.pair(i); ok {
+			res = append(res, kv)
+		}
+	}
+	return res
+}
+
+// Ceil returns *KvPair of the least key greater than or equal to target.
+func (f *Frozen[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
+	if f == nil {
+		return nil, false
+	}
+	return f.At(f.ceil(target))
+}
+
+// Floor returns *KvPair of the greatest key less than or equal to target.
+func (f *Frozen[O, T]) Floor(target O) (*KvPair[O, T], bool) {
+	if f == nil {
+		return nil, false
+	}
+	return f.At(f.floor(target))
+}
+
+// EstimatedBytes returns the approximate bytes occupied by Frozen as SkipList.EstimatedBytes counts,
+// which is the length of the encoded bytes if opened by OpenFrozen.
+func (f *Frozen[O, T]) EstimatedBytes() int64 {
+	if f == nil {
+		return 0
+	}
+	if f.enc != nil {
+		return int64(unsafe.Sizeof(*f)) + int64(unsafe.Sizeof(*f.enc)) + int64(len(f.enc.data))
+	}
+
+	var (
+		key   O
+		val   T
+		bytes = int64(unsafe.Sizeof(*f)) + int64(cap(f.keys))*int64(unsafe.Sizeof(key)) + int64(cap(f.vals))*int64(unsafe.Sizeof(val))
+	)
+	for i := range f.keys {
+		if s, ok := any(f.keys[i]).(string); ok {
+			bytes += int64(len(s))
+		}
+	}
+	return bytes
+}
+
+// ceil returns the index of the least key greater than or equal to target, or len() if not exist.
+func (f *Frozen[O, T]) ceil(target O) int {
+	return sort.Search(f.len(), func(i int) bool { return f.key(i) >= target })
+}
+
+// floor returns the index of the greatest key less than or equal to target, or -1 if not exist.
+func (f *Frozen[O, T]) floor(target O) int {
+	return sort.Search(f.len(), func(i int) bool { return f.key(i) > target }) - 1
+}
+
+func (f *Frozen[O, T]) len() int {
+	if f.enc != nil {
+		return f.enc.count
+	}
+	return len(f.keys)
+}
+
+func (f *Frozen[O, T]) key(i int) O {
+	if f.enc != nil {
+		return f.enc.key(i)
+	}
+	return f.keys[i]
+}
+
+// val returns the i-th value, which is not exist if it fails to be decoded.
+func (f *Frozen[O, T]) val(i int) (T, bool) {
+	if f.enc != nil {
+		return f.enc.val(i)
+	}
+	return f.vals[i], true
+}
+
+func (f *Frozen[O, T]) pair(i int) (*KvPair[O, T], bool) {
+	val, ok := f.val(i)
+	if !ok {
+		return nil, false
+	}
+	return newKvPair(f.key(i), val), true
+}
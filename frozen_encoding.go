@@ -0,0 +1,247 @@
+package skip_list
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// The encoded form of Frozen is a header followed by a key section and a value section, all integers little-endian.
+//
+//	header:  magic "SKFZ" | version 1 | key width, 0 for string keys | 2 reserved bytes | count u64 | key section length u64 | value section length u64
+//	keys:    count fixed-width keys, or count+1 offsets u64 into the bytes of string keys followed by the bytes
+//	values:  count+1 offsets u64 into the bytes of encoded values followed by the bytes
+const (
+	frozenMagic      = "SKFZ"
+	frozenVersion    = 1
+	frozenHeaderSize = 32
+)
+
+var ErrCorruptFrozen = errors.New("skip_list: corrupt frozen data")
+
+type (
+	// frozenEncoding serves Frozen from the encoded bytes in place.
+	frozenEncoding[O constraints.Ordered, T any] struct {
+		data  []byte
+		count int
+
+		// fixed width of keys, or 0 for string keys located by keyOffsets
+		keyWidth   int
+		keys       []byte
+		keyOffsets []byte
+
+		valOffsets []byte
+		vals       []byte
+		decodeVal  func([]byte) (T, error)
+
+		// the first error of decodeVal
+		mu  sync.Mutex
+		err error
+	}
+)
+
+// Encode encodes Frozen in the form OpenFrozen serves from, encoding values by encodeVal.
+func (f *Frozen[O, T]) Encode(encodeVal func(T) ([]byte, error)) ([]byte, error) {
+	if f == nil {
+		return nil, ErrCorruptFrozen
+	}
+
+	var (
+		count    = f.len()
+		keyWidth = frozenKeyWidth[O]()
+		keys     []byte
+		vals     = make([]byte, (count+1)*8)
+	)
+	if keyWidth == 0 {
+		keys = make([]byte, (count+1)*8)
+	} else {
+		keys = make([]byte, count*keyWidth)
+	}
+	for i := 0; i < count; i++ {
+		key := f.key(i)
+		if keyWidth == 0 {
+			keys = append(keys, *(*string)(unsafe.Pointer(&key))...)
+			binary.LittleEndian.PutUint64(keys[(i+1)*8:], uint64(len(keys)-(count+1)*8))
+		} else {
+			putFrozenKey(keys[i*keyWidth:], key, keyWidth)
+		}
+
+		val, ok := f.val(i)
+		if !ok {
+			return nil, f.Err()
+		}
+		b, err := encodeVal(val)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", key, err)
+		}
+		vals = append(vals, b...)
+		binary.LittleEndian.PutUint64(vals[(i+1)*8:], uint64(len(vals)-(count+1)*8))
+	}
+
+	var data = make([]byte, frozenHeaderSize, frozenHeaderSize+len(keys)+len(vals))
+	copy(data, frozenMagic)
+	data[4] = frozenVersion
+	data[5] = byte(keyWidth)
+	binary.LittleEndian.PutUint64(data[8:], uint64(count))
+	binary.LittleEndian.PutUint64(data[16:], uint64(len(keys)))
+	binary.LittleEndian.PutUint64(data[24:], uint64(len(vals)))
+	data = append(data, keys...)
+	data = append(data, vals...)
+	return data, nil
+}
+
+// OpenFrozen returns a Frozen served from data encoded by Encode without copying, decoding values by decodeVal
+// lazily on access. The structure of data is checked in one pass without allocation, and ErrCorruptFrozen is
+// returned if it is broken. data must not be modified while Frozen is used, as string keys refer to it.
+// A value failing to be decoded is not exist, and its error is returned by Err.
+func OpenFrozen[O constraints.Ordered, T any](data []byte, decodeVal func([]byte) (T, error)) (*Frozen[O, T], error) {
+	if len(data) < frozenHeaderSize || string(data[:4]) != frozenMagic || data[4] != frozenVersion {
+		return nil, ErrCorruptFrozen
+	}
+
+	var (
+		keyWidth = frozenKeyWidth[O]()
+		count    = binary.LittleEndian.Uint64(data[8:])
+		keysLen  = binary.LittleEndian.Uint64(data[16:])
+		valsLen  = binary.LittleEndian.Uint64(data[24:])
+		size     = uint64(len(data) - frozenHeaderSize)
+	)
+	if int(data[5]) != keyWidth || keysLen > size || valsLen != size-keysLen || count > size {
+		return nil, ErrCorruptFrozen
+	}
+
+	var (
+		enc = &frozenEncoding[O, T]{
+			data:      data,
+			count:     int(count),
+			keyWidth:  keyWidth,
+			decodeVal: decodeVal,
+		}
+		keys = data[frozenHeaderSize : frozenHeaderSize+keysLen]
+		vals = data[frozenHeaderSize+keysLen:]
+		ok   bool
+	)
+	if keyWidth == 0 {
+		if enc.keyOffsets, enc.keys, ok = splitOffsets(keys, enc.count); !ok {
+			return nil, ErrCorruptFrozen
+		}
+	} else {
+		if keysLen != count*uint64(keyWidth) {
+			return nil, ErrCorruptFrozen
+		}
+		enc.keys = keys
+	}
+	if enc.valOffsets, enc.vals, ok = splitOffsets(vals, enc.count); !ok {
+		return nil, ErrCorruptFrozen
+	}
+
+	// keys must be strictly ascending for binary search
+	for i := 1; i < enc.count; i++ {
+		if enc.key(i-1) >= enc.key(i) {
+			return nil, ErrCorruptFrozen
+		}
+	}
+	return &Frozen[O, T]{enc: enc}, nil
+}
+
+// Err returns the first error of decoding values, nil if not opened by OpenFrozen.
+func (f *Frozen[O, T]) Err() error {
+	if f == nil || f.enc == nil {
+		return nil
+	}
+
+	f.enc.mu.Lock()
+	defer f.enc.mu.Unlock()
+	return f.enc.err
+}
+
+func (enc *frozenEncoding[O, T]) key(i int) (key O) {
+	if enc.keyWidth == 0 {
+		b := offsetBytes(enc.keyOffsets, enc.keys, i)
+		*(*string)(unsafe.Pointer(&key)) = unsafe.String(unsafe.SliceData(b), len(b))
+		return key
+	}
+	return frozenKey[O](enc.keys[i*enc.keyWidth:], enc.keyWidth)
+}
+
+func (enc *frozenEncoding[O, T]) val(i int) (T, bool) {
+	val, err := enc.decodeVal(offsetBytes(enc.valOffsets, enc.vals, i))
+	if err != nil {
+		enc.mu.Lock()
+		if enc.err == nil {
+			enc.err = fmt.Errorf("value %d: %w", i, err)
+		}
+		enc.mu.Unlock()
+		return val, false
+	}
+	return val, true
+}
+
+// splitOffsets splits a section into count+1 offsets and the bytes they locate,
+// and returns whether the offsets start from 0, never decrease and end at the length of the bytes.
+func splitOffsets(section []byte, count int) (offsets, b []byte, ok bool) {
+	if uint64(len(section))/8 < uint64(count)+1 {
+		return nil, nil, false
+	}
+	offsets, b = section[:(count+1)*8], section[(count+1)*8:]
+
+	var prev uint64
+	for i := 0; i <= count; i++ {
+		offset := binary.LittleEndian.Uint64(offsets[i*8:])
+		if (i == 0 && offset != 0) || offset < prev {
+			return nil, nil, false
+		}
+		prev = offset
+	}
+	return offsets, b, prev == uint64(len(b))
+}
+
+// offsetBytes returns the i-th bytes located by offsets checked by splitOffsets.
+func offsetBytes(offsets, b []byte, i int) []byte {
+	return b[binary.LittleEndian.Uint64(offsets[i*8:]):binary.LittleEndian.Uint64(offsets[(i+1)*8:])]
+}
+
+// frozenKeyWidth returns the fixed width of O, or 0 if O is a string.
+func frozenKeyWidth[O constraints.Ordered]() int {
+	var key O
+	if reflect.TypeOf(key).Kind() == reflect.String {
+		return 0
+	}
+	return int(unsafe.Sizeof(key))
+}
+
+// putFrozenKey puts the bits of a fixed-width key in little-endian.
+func putFrozenKey[O constraints.Ordered](b []byte, key O, width int) {
+	p := unsafe.Pointer(&key)
+	switch width {
+	case 1:
+		b[0] = *(*uint8)(p)
+	case 2:
+		binary.LittleEndian.PutUint16(b, *(*uint16)(p))
+	case 4:
+		binary.LittleEndian.PutUint32(b, *(*uint32)(p))
+	case 8:
+		binary.LittleEndian.PutUint64(b, *(*uint64)(p))
+	}
+}
+
+// frozenKey returns the fixed-width key put by putFrozenKey.
+func frozenKey[O constraints.Ordered](b []byte, width int) (key O) {
+	p := unsafe.Pointer(&key)
+	switch width {
+	case 1:
+		*(*uint8)(p) = b[0]
+	case 2:
+		*(*uint16)(p) = binary.LittleEndian.Uint16(b)
+	case 4:
+		*(*uint32)(p) = binary.LittleEndian.Uint32(b)
+	case 8:
+		*(*uint64)(p) = binary.LittleEndian.Uint64(b)
+	}
+	return key
+}
@@ -0,0 +1,155 @@
+package skip_list
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var (
+	encodeInt = func(val int) ([]byte, error) { return []byte(strconv.Itoa(val)), nil }
+	decodeInt = func(b []byte) (int, error) { return strconv.Atoi(string(b)) }
+)
+
+func TestOpenFrozen(t *testing.T) {
+	var (
+		r    = rand.New(rand.NewSource(1))
+		ints = NewSkipList[int64, int](0, false)
+		strs = NewSkipList[string, int](0, false)
+	)
+	for i := 0; i < 1000; i++ {
+		key := r.Int63n(1<<40) - 1<<39
+		ints.Put(key, i)
+		strs.Put(strconv.FormatInt(key, 36), i)
+	}
+
+	t.Run("TestOpenFrozen 1", func(t *testing.T) {
+		data, err := ints.Freeze().Encode(encodeInt)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		f, err := OpenFrozen[int64](data, decodeInt)
+		if err != nil {
+			t.Fatalf("OpenFrozen() error = %v", err)
+		}
+		checkFrozen(t, f, ints)
+	})
+
+	t.Run("TestOpenFrozen 2", func(t *testing.T) {
+		data, err := strs.Freeze().Encode(encodeInt)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		f, err := OpenFrozen[string](data, decodeInt)
+		if err != nil {
+			t.Fatalf("OpenFrozen() error = %v", err)
+		}
+		checkFrozen(t, f, strs)
+
+		// round trip again
+		again, err := f.Encode(encodeInt)
+		if err != nil || !reflect.DeepEqual(again, data) {
+			t.Errorf("Encode() of OpenFrozen() = %v, want the same bytes", err)
+		}
+	})
+
+	t.Run("TestOpenFrozen 3", func(t *testing.T) {
+		data, _ := NewSkipList[float64, int](10, false).Freeze().Encode(encodeInt)
+		f, err := OpenFrozen[float64](data, decodeInt)
+		if err != nil || f.Cap() != 0 || len(f.Items()) != 0 {
+			t.Errorf("OpenFrozen() = %v, %v, want empty", f, err)
+		}
+		if _, err := OpenFrozen[int32](data, decodeInt); !errors.Is(err, ErrCorruptFrozen) {
+			t.Errorf("OpenFrozen() of another key width error = %v, want ErrCorruptFrozen", err)
+		}
+	})
+
+	t.Run("TestOpenFrozen 4", func(t *testing.T) {
+		data, _ := strs.Freeze().Encode(encodeInt)
+		for i := 0; i < len(data); i++ {
+			if _, err := OpenFrozen[string](data[:i], decodeInt); !errors.Is(err, ErrCorruptFrozen) {
+				t.Fatalf("OpenFrozen() of %d bytes error = %v, want ErrCorruptFrozen", i, err)
+			}
+		}
+	})
+
+	t.Run("TestOpenFrozen 5", func(t *testing.T) {
+		data, _ := ints.Freeze().Encode(func(val int) ([]byte, error) { return []byte{byte(val)}, nil })
+		f, err := OpenFrozen[int64](data, decodeInt)
+		if err != nil {
+			t.Fatalf("OpenFrozen() error = %v", err)
+		}
+		if got := f.Items(); len(got) == ints.Freeze().Cap() {
+			t.Errorf("Items() decodes every value")
+		}
+		if f.Err() == nil {
+			t.Errorf("Err() = nil, want the error of decodeVal")
+		}
+	})
+}
+
+// checkFrozen checks every read of f against sl.
+func checkFrozen[O int64 | string](t *testing.T, f *Frozen[O, int], sl *SkipList[O, int]) {
+	t.Helper()
+
+	items := sl.Items()
+	if got := f.Items(); !reflect.DeepEqual(got, items) {
+		t.Fatalf("Items() = %v, want %v", got, items)
+	}
+	for i, kv := range items {
+		if got, ok := f.Get(kv.Key()); !ok || got != kv.Val() {
+			t.Fatalf("Get(%v) = %v, %v, want %v", kv.Key(), got, ok, kv.Val())
+		}
+		if got, ok := f.Rank(kv.Key()); !ok || got != i {
+			t.Fatalf("Rank(%v) = %v, %v, want %v", kv.Key(), got, ok, i)
+		}
+		if got, want := f.Range(kv.Key(), items[min(i+5, len(items)-1)].Key()), sl.Range(kv.Key(), items[min(i+5, len(items)-1)].Key()); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Range() = %v, want %v", got, want)
+		}
+		gotCeil, _ := f.Ceil(kv.Key())
+		wantCeil, _ := sl.Ceil(kv.Key())
+		gotFloor, _ := f.Floor(kv.Key())
+		wantFloor, _ := sl.Floor(kv.Key())
+		if !reflect.DeepEqual(gotCeil, wantCeil) || !reflect.DeepEqual(gotFloor, wantFloor) {
+			t.Fatalf("Ceil(), Floor() = %v, %v, want %v, %v", gotCeil, gotFloor, wantCeil, wantFloor)
+		}
+	}
+	if err := f.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func FuzzOpenFrozen(f *testing.F) {
+	var sl = NewSkipList[string, int](10, false)
+	for i := 0; i < 20; i++ {
+		sl.Put(strconv.Itoa(i*i), i)
+	}
+	data, _ := sl.Freeze().Encode(encodeInt)
+	f.Add(data)
+	f.Add(data[:len(data)/2])
+	f.Add([]byte(frozenMagic))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fz, err := OpenFrozen[string](data, decodeInt)
+		if err != nil {
+			return
+		}
+		// reads never panic on data accepted by OpenFrozen
+		for _, kv := range fz.Items() {
+			fz.Get(kv.Key())
+			fz.Ceil(kv.Key())
+			fz.Floor(kv.Key() + "\x00")
+		}
+		fz.Range("", "\xff")
+		fz.At(fz.Cap() - 1)
+	})
+}
@@ -0,0 +1,122 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSkipList_Freeze(t *testing.T) {
+	var (
+		r  = rand.New(rand.NewSource(1))
+		sl = NewSkipList[int, int](0, false, WithTombstones())
+	)
+	for i := 0; i < 1000; i++ {
+		sl.Put(r.Intn(3000), i)
+	}
+	for i := 0; i < 300; i++ {
+		sl.Delete(r.Intn(3000))
+	}
+	f := sl.Freeze()
+
+	t.Run("TestSkipList_Freeze 1", func(t *testing.T) {
+		items := sl.Items()
+		if got := f.Items(); !reflect.DeepEqual(got, items) {
+			t.Errorf("Items() = %v, want %v", got, items)
+		}
+		if got := f.Cap(); got != int(sl.Cap()) {
+			t.Errorf("Cap() = %v, want %v", got, sl.Cap())
+		}
+		for i, kv := range items {
+			if got, ok := f.At(i); !ok || !reflect.DeepEqual(got, kv) {
+				t.Fatalf("At(%v) = %v, %v, want %v", i, got, ok, kv)
+			}
+			if got, ok := f.Rank(kv.Key()); !ok || got != i {
+				t.Fatalf("Rank(%v) = %v, %v, want %v", kv.Key(), got, ok, i)
+			}
+		}
+		for _, i := range []int{-1, len(items)} {
+			if got, ok := f.At(i); ok {
+				t.Errorf("At(%v) = %v, want not exist", i, got)
+			}
+		}
+	})
+
+	t.Run("TestSkipList_Freeze 2", func(t *testing.T) {
+		for key := -5; key <= 3005; key++ {
+			wantVal, wantOk := sl.Get(key)
+			if got, ok := f.Get(key); got != wantVal || ok != wantOk {
+				t.Fatalf("Get(%v) = %v, %v, want %v, %v", key, got, ok, wantVal, wantOk)
+			}
+			wantCeil, wantOk := sl.Ceil(key)
+			if got, ok := f.Ceil(key); !reflect.DeepEqual(got, wantCeil) || ok != wantOk {
+				t.Fatalf("Ceil(%v) = %v, %v, want %v, %v", key, got, ok, wantCeil, wantOk)
+			}
+			wantFloor, wantOk := sl.Floor(key)
+			if got, ok := f.Floor(key); !reflect.DeepEqual(got, wantFloor) || ok != wantOk {
+				t.Fatalf("Floor(%v) = %v, %v, want %v, %v", key, got, ok, wantFloor, wantOk)
+			}
+			if rank, ok := f.Rank(key); !ok && rank != len(sl.Range(-5, key)) {
+				t.Fatalf("Rank(%v) = %v, want %v", key, rank, len(sl.Range(-5, key)))
+			}
+		}
+	})
+
+	t.Run("TestSkipList_Freeze 3", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			start := r.Intn(3100) - 50
+			end := start + r.Intn(200) - 20
+			if got, want := f.Range(start, end), sl.Range(start, end); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Range(%v, %v) = %v, want %v", start, end, got, want)
+			}
+		}
+	})
+
+	t.Run("TestSkipList_Freeze 4", func(t *testing.T) {
+		var nilSl *SkipList[int, int]
+		nilF := nilSl.Freeze()
+		if nilF != nil || nilF.Cap() != 0 || nilF.Items() != nil {
+			t.Errorf("Freeze() = %v, want nil", nilF)
+		}
+		if got := NewSkipList[int, int](10, false).Freeze().Range(0, 10); len(got) != 0 {
+			t.Errorf("Range() = %v, want empty", got)
+		}
+	})
+}
+
+func BenchmarkSkipList_Freeze(b *testing.B) {
+	const n = 1 << 20
+
+	var sl = NewSkipList[int, int](20, false)
+	for _, i := range rand.Perm(n) {
+		sl.Put(i, i)
+	}
+	f := sl.Freeze()
+	b.Logf("EstimatedBytes() of %d int keys: SkipList %d, Frozen %d", n, sl.EstimatedBytes(), f.EstimatedBytes())
+
+	b.Run("SkipList/Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl.Get(i * 7919 % n)
+		}
+	})
+	b.Run("Frozen/Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.Get(i * 7919 % n)
+		}
+	})
+	for _, size := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("SkipList/Range%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				start := i * 7919 % n
+				sl.Range(start, start+size-1)
+			}
+		})
+		b.Run(fmt.Sprintf("Frozen/Range%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				start := i * 7919 % n
+				f.Range(start, start+size-1)
+			}
+		})
+	}
+}
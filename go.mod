@@ -1,5 +1,5 @@
 module github.com/ALong1997/skip-list
 
-go 1.20
+go 1.23
 
 require golang.org/x/exp v0.0.0-20240112132812-db7319d0e0e3
@@ -0,0 +1,106 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// gobSkipList is the gob form of SkipList, holding the configuration to reconstruct it and kv-pairs in key order.
+type gobSkipList[O, T any] struct {
+	// 0 if maxLevel is tuned
+	MaxLevel    int32
+	Probability float64
+
+	Keys []O
+	Vals []T
+}
+
+// GobEncode implements gob.GobEncoder, encoding maxLevel, the probability and kv-pairs in key order.
+// Other options are not encoded.
+func (sl *SkipList[O, T]) GobEncode() ([]byte, error) {
+	if sl == nil {
+		return nil, nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var w = gobSkipList[O, T]{
+		MaxLevel:    sl.maxLevel,
+		Probability: sl.p,
+		Keys:        make([]O, 0, sl.cap),
+		Vals:        make([]T, 0, sl.cap),
+	}
+	if sl.autoMaxLevel {
+		w.MaxLevel = 0
+	}
+	for n := sl.head.next(); n != nil; n = n.next() {
+		w.Keys = append(w.Keys, n.key)
+		w.Vals = append(w.Vals, n.val)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(w); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, replacing all nodes by the kv-pairs GobEncode encodes in one pass as BulkLoad does.
+// A zero SkipList, which gob allocates for a nil pointer, is reconstructed with the encoded maxLevel and probability,
+// while one returned by NewSkipList keeps its own configuration. Malformed data leaves SkipList untouched.
+func (sl *SkipList[O, T]) GobDecode(data []byte) error {
+	if sl == nil {
+		return nil
+	}
+
+	var w gobSkipList[O, T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&w); err != nil {
+		return err
+	}
+	if len(w.Keys) != len(w.Vals) {
+		return fmt.Errorf("skip_list: %d keys with %d values", len(w.Keys), len(w.Vals))
+	}
+
+	if sl.head == nil {
+		if w.MaxLevel < 0 || !(w.Probability > 0 && w.Probability < 1) {
+			return fmt.Errorf("skip_list: invalid maxLevel %d or probability %v", w.MaxLevel, w.Probability)
+		}
+		sl.init(w.MaxLevel, w.Probability)
+	}
+
+	var pairs = make([]*KvPair[O, T], len(w.Keys))
+	for i := range pairs {
+		pairs[i] = newKvPair(w.Keys[i], w.Vals[i])
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.replaceAll(pairs)
+	return nil
+}
+
+// init sets up a zero SkipList as NewSkipList(maxLevel, false, WithProbability(p)) returns it,
+// setting every field NewSkipList does not leave zero.
+func (sl *SkipList[O, T]) init(maxLevel int32, p float64) {
+	sl.autoMaxLevel = maxLevel == 0
+	if sl.autoMaxLevel {
+		maxLevel = autoMaxLevelOf(0)
+	}
+	sl.level = 1
+	sl.maxLevel = maxLevel
+	sl.p = p
+	sl.head = newHead[O, T](false, sl.autoMaxLevel, maxLevel)
+	sl.r = rand.New(rand.NewSource(time.Now().Unix()))
+	sl.nodeCache = sync.Pool{New: func() any { return &node[O, T]{} }}
+	sl.codec = defaultCodec[T]()
+}
@@ -0,0 +1,162 @@
+package skip_list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type gobValue struct {
+	Name  string
+	Score float64
+}
+
+func TestSkipList_GobEncode(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+	}
+	testCases := []testCase{
+		{name: "TestSkipList_GobEncode 1", maxLevel: 10},
+		{name: "TestSkipList_GobEncode 2", maxLevel: 0, opts: []Option{WithProbability(0.25)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := NewSkipList[string, gobValue](tc.maxLevel, false, tc.opts...)
+			for i := 0; i < 500; i++ {
+				key := strings.Repeat("k", i%7) + string(rune('a'+i%26))
+				src.Put(key, gobValue{Name: key, Score: float64(i) / 2})
+			}
+
+			// embedded in a larger struct, decoded into a zero SkipList
+			type snapshot struct {
+				Version int
+				Index   *SkipList[string, gobValue]
+				Note    string
+			}
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(snapshot{Version: 3, Index: src, Note: "n"}); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			var dst snapshot
+			if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			if dst.Version != 3 || dst.Note != "n" {
+				t.Errorf("Decode() = %v, %v, want 3, n", dst.Version, dst.Note)
+			}
+			if got, want := dst.Index.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+			if dst.Index.maxLevel != src.maxLevel || dst.Index.autoMaxLevel != src.autoMaxLevel || dst.Index.p != src.p {
+				t.Errorf("maxLevel, autoMaxLevel, p = %v, %v, %v, want %v, %v, %v",
+					dst.Index.maxLevel, dst.Index.autoMaxLevel, dst.Index.p, src.maxLevel, src.autoMaxLevel, src.p)
+			}
+			if err := dst.Index.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			// still usable
+			dst.Index.Put("zzz", gobValue{})
+			dst.Index.Delete("a")
+			if err := dst.Index.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSkipList_GobDecode(t *testing.T) {
+	src := NewSkipList[int, int](5, false)
+	for i := 0; i < 100; i++ {
+		src.Put(i, -i)
+	}
+	data, err := src.GobEncode()
+	if err != nil {
+		t.Fatalf("GobEncode() error = %v", err)
+	}
+
+	t.Run("TestSkipList_GobDecode 1", func(t *testing.T) {
+		// an initialized SkipList keeps its configuration
+		dst := NewSkipList[int, int](12, true, WithTombstones())
+		dst.Put(-1, 1)
+		if err := dst.GobDecode(data); err != nil {
+			t.Fatalf("GobDecode() error = %v", err)
+		}
+		if got, want := dst.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		if dst.maxLevel != 12 || !dst.tombstones {
+			t.Errorf("maxLevel, tombstones = %v, %v, want 12, true", dst.maxLevel, dst.tombstones)
+		}
+	})
+
+	t.Run("TestSkipList_GobDecode 2", func(t *testing.T) {
+		dst := NewSkipList[int, int](5, false)
+		dst.Put(7, 7)
+		if err := dst.GobDecode(data[:len(data)/2]); err == nil {
+			t.Errorf("GobDecode() of truncated data error = nil")
+		}
+		if got, want := dst.Items(), []*KvPair[int, int]{{7, 7}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestSkipList_GobDecode 3", func(t *testing.T) {
+		var (
+			buf bytes.Buffer
+			dst SkipList[int, int]
+		)
+		gob.NewEncoder(&buf).Encode(gobSkipList[int, int]{MaxLevel: 5, Probability: 2})
+		if err := dst.GobDecode(buf.Bytes()); err == nil {
+			t.Errorf("GobDecode() of probability 2 error = nil")
+		}
+
+		buf.Reset()
+		gob.NewEncoder(&buf).Encode(gobSkipList[int, int]{MaxLevel: 5, Probability: 0.5, Keys: []int{1}})
+		if err := dst.GobDecode(buf.Bytes()); err == nil {
+			t.Errorf("GobDecode() of 1 key with 0 values error = nil")
+		}
+	})
+
+	t.Run("TestSkipList_GobDecode 4", func(t *testing.T) {
+		// a SkipList gob allocates encodes to binary by the default Codec
+		var (
+			buf bytes.Buffer
+			dst *SkipList[int, string]
+		)
+		src := NewSkipList[int, string](5, false)
+		for i := 0; i < 100; i++ {
+			src.Put(i, string(rune('a'+i%26)))
+		}
+		if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+
+		data, err := dst.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+		got := NewSkipList[int, string](5, false)
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Items(), src.Items()) {
+			t.Errorf("Items() = %v, want %v", got.Items(), src.Items())
+		}
+
+		dst.Put(100, "z")
+		dst.Delete(0)
+		if err := dst.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+}
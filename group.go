@@ -0,0 +1,60 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// GroupBy returns a SkipList per group derived by keyOf, each built in one pass with the configuration of sl.
+func GroupBy[O constraints.Ordered, T any, G comparable](sl *SkipList[O, T], keyOf func(key O, val T) G) map[G]*SkipList[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		groups = make(map[G]*SkipList[O, T])
+		tails  = make(map[G]*node[O, T])
+	)
+	for n := sl.head.next(); n != nil; n = n.next() {
+		g := keyOf(n.key, n.val)
+		group, ok := groups[g]
+		if !ok {
+			group = sl.emptyCopy()
+			groups[g] = group
+			tails[g] = group.head
+		}
+		tails[g] = group.pushBack(tails[g], n.key, n.val)
+	}
+	for _, group := range groups {
+		group.build()
+	}
+	return groups
+}
+
+// Partition returns a SkipList of the nodes for which pred returns true and one of the rest,
+// both built in one pass with the configuration of sl.
+func (sl *SkipList[O, T]) Partition(pred func(key O, val T) bool) (matching, rest *SkipList[O, T]) {
+	if sl == nil {
+		return nil, nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	matching, rest = sl.emptyCopy(), sl.emptyCopy()
+	var matchingTail, restTail = matching.head, rest.head
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if pred(n.key, n.val) {
+			matchingTail = matching.pushBack(matchingTail, n.key, n.val)
+		} else {
+			restTail = rest.pushBack(restTail, n.key, n.val)
+		}
+	}
+	matching.build()
+	rest.build()
+	return matching, rest
+}
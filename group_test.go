@@ -0,0 +1,141 @@
+package skip_list
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	type testCase struct {
+		name string
+		sl   *SkipList[int, int]
+		mod  int
+		want map[int][]*KvPair[int, int]
+	}
+
+	var sl = NewSkipList[int, int](0, false, WithTombstones())
+	for i := 0; i < 10; i++ {
+		sl.Put(i, -i)
+	}
+	sl.Delete(4)
+
+	tests := []testCase{
+		{
+			name: "TestGroupBy 1",
+			sl:   sl,
+			mod:  3,
+			want: map[int][]*KvPair[int, int]{
+				0: {{0, 0}, {3, -3}, {6, -6}, {9, -9}},
+				1: {{1, -1}, {7, -7}},
+				2: {{2, -2}, {5, -5}, {8, -8}},
+			},
+		},
+		{
+			name: "TestGroupBy 2",
+			sl:   sl,
+			mod:  1,
+			want: map[int][]*KvPair[int, int]{
+				0: {{0, 0}, {1, -1}, {2, -2}, {3, -3}, {5, -5}, {6, -6}, {7, -7}, {8, -8}, {9, -9}},
+			},
+		},
+		{
+			name: "TestGroupBy 3",
+			sl:   NewSkipList[int, int](10, false),
+			mod:  3,
+			want: map[int][]*KvPair[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupBy(tt.sl, func(key, _ int) int { return key % tt.mod })
+
+			var (
+				got   = make(map[int][]*KvPair[int, int])
+				union []*KvPair[int, int]
+			)
+			for g, group := range groups {
+				if err := group.Validate(); err != nil {
+					t.Errorf("Validate() error = %v for group %v", err, g)
+				}
+				got[g] = group.Items()
+				union = append(union, got[g]...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy() = %v, want %v", got, tt.want)
+			}
+
+			sort.Slice(union, func(i, j int) bool { return union[i].Key() < union[j].Key() })
+			if want := tt.sl.Items(); len(want) > 0 && !reflect.DeepEqual(union, want) {
+				t.Errorf("union of GroupBy() = %v, want %v", union, want)
+			}
+		})
+	}
+
+	if got := GroupBy[int, int, int](nil, nil); got != nil {
+		t.Errorf("GroupBy() = %v, want nil", got)
+	}
+}
+
+func TestSkipList_Partition(t *testing.T) {
+	type testCase struct {
+		name         string
+		sl           *SkipList[int, int]
+		pred         func(key, val int) bool
+		wantMatching []*KvPair[int, int]
+		wantRest     []*KvPair[int, int]
+	}
+
+	var sl = NewSkipList[int, int](10, true)
+	for i := 0; i < 8; i++ {
+		sl.Put(i, i*i)
+	}
+
+	tests := []testCase{
+		{
+			name:         "TestSkipList_Partition 1",
+			sl:           sl,
+			pred:         func(key, _ int) bool { return key%3 == 0 },
+			wantMatching: []*KvPair[int, int]{{0, 0}, {3, 9}, {6, 36}},
+			wantRest:     []*KvPair[int, int]{{1, 1}, {2, 4}, {4, 16}, {5, 25}, {7, 49}},
+		},
+		{
+			name:         "TestSkipList_Partition 2",
+			sl:           sl,
+			pred:         func(_, val int) bool { return val > 100 },
+			wantMatching: []*KvPair[int, int]{},
+			wantRest:     sl.Items(),
+		},
+		{
+			name:         "TestSkipList_Partition 3",
+			sl:           NewSkipList[int, int](10, false),
+			pred:         func(int, int) bool { return true },
+			wantMatching: []*KvPair[int, int]{},
+			wantRest:     []*KvPair[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matching, rest := tt.sl.Partition(tt.pred)
+			if got := matching.Items(); !reflect.DeepEqual(got, tt.wantMatching) {
+				t.Errorf("Partition() matching = %v, want %v", got, tt.wantMatching)
+			}
+			if got := rest.Items(); !reflect.DeepEqual(got, tt.wantRest) {
+				t.Errorf("Partition() rest = %v, want %v", got, tt.wantRest)
+			}
+			if err := matching.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if err := rest.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			// interleave back
+			union := append(matching.Items(), rest.Items()...)
+			sort.Slice(union, func(i, j int) bool { return union[i].Key() < union[j].Key() })
+			if want := tt.sl.Items(); !reflect.DeepEqual(union, want) {
+				t.Errorf("union of Partition() = %v, want %v", union, want)
+			}
+		})
+	}
+}
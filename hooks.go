@@ -0,0 +1,59 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// Hooks are invoked synchronously after the corresponding mutation, and nil ones are skipped.
+// BulkLoad and CopyRange build a new SkipList without invoking them.
+// A concurrent SkipList invokes them while holding its lock, so they must not call the methods of the SkipList.
+type Hooks[O constraints.Ordered, T any] struct {
+	// OnInsert is invoked when Put inserts key, including reviving a tombstone.
+	OnInsert func(key O, val T)
+
+	// OnUpdate is invoked when Put or CompareAndSwap replaces the value of key.
+	OnUpdate func(key O, old, new T)
+
+	// OnDelete is invoked for each node deleted by Delete, CompareAndDelete, DeleteIf, RetainIf and Clear.
+	OnDelete func(key O, val T)
+}
+
+func (h *Hooks[O, T]) insert(key O, val T) {
+	if h.OnInsert != nil {
+		h.OnInsert(key, val)
+	}
+}
+
+func (h *Hooks[O, T]) update(key O, old, new T) {
+	if h.OnUpdate != nil {
+		h.OnUpdate(key, old, new)
+	}
+}
+
+func (h *Hooks[O, T]) delete(key O, val T) {
+	if h.OnDelete != nil {
+		h.OnDelete(key, val)
+	}
+}
+
+// inserted counts the modification, indexes val, invokes OnInsert and appends the record of putting key to the log.
+func (sl *SkipList[O, T]) inserted(key O, val T) {
+	sl.mods++
+	sl.values.add(key, val)
+	sl.hooks.insert(key, val)
+	sl.log.put(key, val)
+}
+
+// updated moves key from the index of old to that of new, invokes OnUpdate and appends the record of putting key
+// to the log.
+func (sl *SkipList[O, T]) updated(key O, old, new T) {
+	sl.values.update(key, old, new)
+	sl.hooks.update(key, old, new)
+	sl.log.put(key, new)
+}
+
+// deleted counts the modification, unindexes val, invokes OnDelete and appends the record of deleting key to the log.
+func (sl *SkipList[O, T]) deleted(key O, val T) {
+	sl.mods++
+	sl.values.remove(key, val)
+	sl.hooks.delete(key, val)
+	sl.log.delete(key)
+}
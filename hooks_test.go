@@ -0,0 +1,139 @@
+package skip_list
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+		op   func(sl *SkipList[int, string])
+		want []string
+	}
+
+	var events []string
+	hooks := Hooks[int, string]{
+		OnInsert: func(key int, val string) { events = append(events, fmt.Sprintf("insert %d %s", key, val)) },
+		OnUpdate: func(key int, old, new string) { events = append(events, fmt.Sprintf("update %d %s %s", key, old, new)) },
+		OnDelete: func(key int, val string) { events = append(events, fmt.Sprintf("delete %d %s", key, val)) },
+	}
+	eq := func(a, b string) bool { return a == b }
+
+	tests := []testCase{
+		{
+			name: "TestHooks 1",
+			op:   func(sl *SkipList[int, string]) { sl.Put(4, "d") },
+			want: []string{"insert 4 d"},
+		},
+		{
+			name: "TestHooks 2",
+			op:   func(sl *SkipList[int, string]) { sl.Put(2, "x") },
+			want: []string{"update 2 b x"},
+		},
+		{
+			name: "TestHooks 3",
+			op:   func(sl *SkipList[int, string]) { sl.Delete(2); sl.Delete(2); sl.Delete(4) },
+			want: []string{"delete 2 b"},
+		},
+		{
+			name: "TestHooks 4",
+			op: func(sl *SkipList[int, string]) {
+				sl.CompareAndSwap(1, "x", "y", eq)
+				sl.CompareAndSwap(1, "a", "y", eq)
+			},
+			want: []string{"update 1 a y"},
+		},
+		{
+			name: "TestHooks 5",
+			op: func(sl *SkipList[int, string]) {
+				sl.CompareAndDelete(3, "x", eq)
+				sl.CompareAndDelete(3, "c", eq)
+			},
+			want: []string{"delete 3 c"},
+		},
+		{
+			name: "TestHooks 6",
+			op:   func(sl *SkipList[int, string]) { sl.DeleteIf(func(key int, _ string) bool { return key != 2 }) },
+			want: []string{"delete 1 a", "delete 3 c"},
+		},
+		{
+			name: "TestHooks 7",
+			op:   func(sl *SkipList[int, string]) { sl.RetainIf(func(key int, _ string) bool { return key == 2 }) },
+			want: []string{"delete 1 a", "delete 3 c"},
+		},
+		{
+			name: "TestHooks 8",
+			op:   func(sl *SkipList[int, string]) { sl.Clear() },
+			want: []string{"delete 1 a", "delete 2 b", "delete 3 c"},
+		},
+		{
+			name: "TestHooks 9",
+			opts: []Option{WithTombstones()},
+			op:   func(sl *SkipList[int, string]) { sl.Delete(2); sl.Delete(2); sl.Put(2, "x"); sl.Put(2, "y") },
+			want: []string{"delete 2 b", "insert 2 x", "update 2 x y"},
+		},
+		{
+			name: "TestHooks 10",
+			opts: []Option{WithTombstones()},
+			op: func(sl *SkipList[int, string]) {
+				sl.Delete(1)
+				sl.DeleteIf(func(int, string) bool { return true })
+				sl.Clear()
+			},
+			want: []string{"delete 1 a", "delete 2 b", "delete 3 c"},
+		},
+		{
+			name: "TestHooks 11",
+			opts: []Option{WithNodePool()},
+			op:   func(sl *SkipList[int, string]) { sl.Delete(1); sl.DeleteIf(func(int, string) bool { return true }) },
+			want: []string{"delete 1 a", "delete 2 b", "delete 3 c"},
+		},
+		{
+			// the nodes are marked for the open Iterator after the hooks
+			name: "TestHooks 12",
+			opts: []Option{WithSafeIterators()},
+			op: func(sl *SkipList[int, string]) {
+				it := sl.Iterator()
+				defer it.Close()
+				sl.Clear()
+			},
+			want: []string{"delete 1 a", "delete 2 b", "delete 3 c"},
+		},
+		{
+			name: "TestHooks 13",
+			opts: []Option{WithSafeIterators()},
+			op: func(sl *SkipList[int, string]) {
+				it := sl.Iterator()
+				defer it.Close()
+				sl.ReplaceAll([]*KvPair[int, string]{newKvPair(4, "d")})
+			},
+			want: []string{"delete 1 a", "delete 2 b", "delete 3 c", "insert 4 d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, string](10, false, append(tt.opts, WithHooks(hooks))...)
+			sl.Put(1, "a")
+			sl.Put(2, "b")
+			sl.Put(3, "c")
+
+			events = nil
+			tt.op(sl)
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events = %v, want %v", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	if sl := NewSkipList[int, string](10, false, WithHooks(Hooks[int, string]{})); sl == nil {
+		t.Errorf("NewSkipList() = nil, want non-nil")
+	}
+	if sl := NewSkipList[int, int](10, false, WithHooks(Hooks[int, string]{})); sl != nil {
+		t.Errorf("NewSkipList() = %v, want nil for mismatched Hooks", sl)
+	}
+}
@@ -0,0 +1,308 @@
+package skip_list
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrInvalidInterval = errors.New("skip_list: interval end is less than start")
+
+type (
+	// IntervalList is a skip list of closed intervals ordered by start and then by end, which finds the intervals
+	// containing a point or overlapping a range without scanning the intervals ending before them.
+	// Each node keeps, on every level, the greatest end of the nodes from it up to its successor on that level,
+	// so that a query skips every region ending before the point.
+	IntervalList[O constraints.Ordered, T any] struct {
+		level, maxLevel, cap int32
+
+		// probability of promoting a node to the next level
+		p float64
+
+		// head node of IntervalList
+		head *intervalNode[O, T]
+
+		// randomly generate level when inserting a node
+		r *rand.Rand
+
+		// concurrent
+		isConcurrent bool
+		sync.RWMutex
+	}
+
+	intervalNode[O constraints.Ordered, T any] struct {
+		start, end O
+		val        T
+		nextNodes  []*intervalNode[O, T]
+
+		// maxEnds[l] is the greatest end of the nodes from this node up to nextNodes[l] exclusive on level 0,
+		// unused by head
+		maxEnds []O
+	}
+
+	// Match is an interval of IntervalList with its value.
+	Match[O constraints.Ordered, T any] struct {
+		start, end O
+		val        T
+	}
+)
+
+// Start returns the start of the interval.
+func (m Match[O, T]) Start() O {
+	return m.start
+}
+
+// End returns the end of the interval.
+func (m Match[O, T]) End() O {
+	return m.end
+}
+
+// Val returns the value of the interval.
+func (m Match[O, T]) Val() T {
+	return m.val
+}
+
+// NewIntervalList returns an IntervalList whose nodes have at most maxLevel+1 levels, maxLevel must be positive.
+// WithProbability and WithRandSource are honored, other options are ignored.
+func NewIntervalList[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *IntervalList[O, T] {
+	if maxLevel <= 0 {
+		return nil
+	}
+
+	o := newOptions(opts...)
+	if !o.valid() {
+		return nil
+	}
+
+	src := o.randSource
+	if src == nil {
+		src = rand.NewSource(time.Now().Unix())
+	}
+
+	return &IntervalList[O, T]{
+		level:        1,
+		maxLevel:     maxLevel,
+		cap:          0,
+		p:            o.p,
+		head:         &intervalNode[O, T]{nextNodes: make([]*intervalNode[O, T], 1)},
+		r:            rand.New(src),
+		isConcurrent: isConcurrent,
+	}
+}
+
+// Cap returns the number of intervals.
+func (il *IntervalList[O, T]) Cap() int32 {
+	if il == nil {
+		return 0
+	}
+
+	if il.isConcurrent {
+		il.RLock()
+		defer il.RUnlock()
+	}
+
+	return il.cap
+}
+
+// Insert inserts the interval [start, end] with val, or replaces the value of an equal interval.
+// It returns ErrInvalidInterval if end is less than start.
+func (il *IntervalList[O, T]) Insert(start, end O, val T) error {
+	if il == nil {
+		return nil
+	}
+	if end < start {
+		return ErrInvalidInterval
+	}
+
+	if il.isConcurrent {
+		il.Lock()
+		defer il.Unlock()
+	}
+
+	var update = make([]*intervalNode[O, T], il.maxLevel+1)
+	if n := il.ceil(start, end, update); n != nil && n.start == start && n.end == end {
+		// update
+		n.val = val
+		return nil
+	}
+
+	var randL int32
+	for il.r.Float64() < il.p && randL < il.maxLevel {
+		randL++
+	}
+
+	// grow
+	for il.level < randL+1 {
+		il.head.nextNodes = append(il.head.nextNodes, nil)
+		update[il.level] = il.head
+		il.level++
+	}
+
+	// insert
+	newNode := &intervalNode[O, T]{
+		start:     start,
+		end:       end,
+		val:       val,
+		nextNodes: make([]*intervalNode[O, T], randL+1),
+		maxEnds:   make([]O, randL+1),
+	}
+	for l := int32(0); l <= randL; l++ {
+		newNode.nextNodes[l] = update[l].nextNodes[l]
+		update[l].nextNodes[l] = newNode
+	}
+	newNode.maxEnds[0] = end
+	il.cap++
+
+	// the regions of the new node and of its predecessors, from the bottom as each level folds the one below
+	for l := int32(1); l < il.level; l++ {
+		if l <= randL {
+			newNode.fold(l)
+		}
+		if update[l] != il.head {
+			update[l].fold(l)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the interval [start, end], and returns whether it exists.
+func (il *IntervalList[O, T]) Delete(start, end O) bool {
+	if il == nil {
+		return false
+	}
+
+	if il.isConcurrent {
+		il.Lock()
+		defer il.Unlock()
+	}
+
+	var update = make([]*intervalNode[O, T], il.maxLevel+1)
+	n := il.ceil(start, end, update)
+	if n == nil || n.start != start || n.end != end {
+		// not exist
+		return false
+	}
+
+	// delete
+	for l := range n.nextNodes {
+		update[l].nextNodes[l] = n.nextNodes[l]
+	}
+	il.cap--
+
+	// the regions of the predecessors, which absorb or lose the region of the node
+	for l := int32(1); l < il.level; l++ {
+		if update[l] != il.head {
+			update[l].fold(l)
+		}
+	}
+
+	// cut
+	for il.level > 1 && il.head.nextNodes[il.level-1] == nil {
+		il.level--
+	}
+	il.head.nextNodes = il.head.nextNodes[:il.level]
+	return true
+}
+
+// Stab returns the intervals containing point in ascending order of start and end.
+func (il *IntervalList[O, T]) Stab(point O) []Match[O, T] {
+	return il.Overlap(point, point)
+}
+
+// Overlap returns the intervals overlapping [a, b] in ascending order of start and end, or none if b is less than a.
+func (il *IntervalList[O, T]) Overlap(a, b O) []Match[O, T] {
+	if il == nil {
+		return nil
+	}
+
+	var res = make([]Match[O, T], 0)
+	if b < a {
+		return res
+	}
+
+	if il.isConcurrent {
+		il.RLock()
+		defer il.RUnlock()
+	}
+
+	il.overlap(il.head, il.level-1, nil, a, b, &res)
+	return res
+}
+
+// overlap appends the intervals overlapping [a, b] among the nodes from n up to stop exclusive on level l,
+// descending only into the regions ending at or after a. It returns false once a node starts after b.
+func (il *IntervalList[O, T]) overlap(n *intervalNode[O, T], l int32, stop *intervalNode[O, T], a, b O, res *[]Match[O, T]) bool {
+	for ; n != stop; n = n.nextNodes[l] {
+		if n != il.head {
+			if b < n.start {
+				return false
+			}
+			if n.maxEnds[l] < a {
+				// skip the region
+				continue
+			}
+		}
+
+		if l == 0 {
+			if n != il.head {
+				*res = append(*res, Match[O, T]{start: n.start, end: n.end, val: n.val})
+			}
+			continue
+		}
+		if !il.overlap(n, l-1, n.nextNodes[l], a, b, res) {
+			return false
+		}
+	}
+	return true
+}
+
+// ForEach calls fn for each interval in ascending order of start and end until fn returns false.
+// fn must not modify il.
+func (il *IntervalList[O, T]) ForEach(fn func(start, end O, val T) bool) {
+	if il == nil {
+		return
+	}
+
+	if il.isConcurrent {
+		il.RLock()
+		defer il.RUnlock()
+	}
+
+	for n := il.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if !fn(n.start, n.end, n.val) {
+			return
+		}
+	}
+}
+
+// ceil returns the least node of an interval not less than [start, end], and records the predecessors of it on
+// every level in update if not nil.
+func (il *IntervalList[O, T]) ceil(start, end O, update []*intervalNode[O, T]) *intervalNode[O, T] {
+	move := il.head
+	for l := il.level - 1; l >= 0; l-- {
+		for next := move.nextNodes[l]; next != nil && (next.start < start || (next.start == start && next.end < end)); next = move.nextNodes[l] {
+			// search to the right
+			move = next
+		}
+
+		// search down
+		if update != nil {
+			update[l] = move
+		}
+	}
+	return move.nextNodes[0]
+}
+
+// fold sets maxEnds[l] of n to the greatest maxEnds[l-1] of the nodes from n up to nextNodes[l] on level l-1.
+func (n *intervalNode[O, T]) fold(l int32) {
+	maxEnd := n.maxEnds[l-1]
+	for m := n.nextNodes[l-1]; m != n.nextNodes[l]; m = m.nextNodes[l-1] {
+		if m.maxEnds[l-1] > maxEnd {
+			maxEnd = m.maxEnds[l-1]
+		}
+	}
+	n.maxEnds[l] = maxEnd
+}
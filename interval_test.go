@@ -0,0 +1,207 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestIntervalList(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		maxLevel     int32
+		p            float64
+		span         int
+	}
+	tests := []testCase{
+		{name: "TestIntervalList 1", isConcurrent: false, maxLevel: 16, p: 0.5, span: 20},
+		{name: "TestIntervalList 2", isConcurrent: true, maxLevel: 16, p: 0.25, span: 20},
+		{name: "TestIntervalList 3", isConcurrent: false, maxLevel: 3, p: 0.5, span: 200},
+		{name: "TestIntervalList 4", isConcurrent: false, maxLevel: 16, p: 0.5, span: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				il  = NewIntervalList[int, int](tt.maxLevel, tt.isConcurrent, WithProbability(tt.p), WithRandSource(rand.NewSource(2)))
+				ref = make(map[[2]int]int)
+			)
+			for i := 0; i < 5000; i++ {
+				start := rnd.Intn(1000)
+				end := start + rnd.Intn(tt.span)
+				if rnd.Intn(3) == 0 && len(ref) > 0 {
+					// delete an existing interval mostly
+					for k := range ref {
+						if rnd.Intn(2) == 0 {
+							start, end = k[0], k[1]
+						}
+						break
+					}
+					_, exist := ref[[2]int{start, end}]
+					if got := il.Delete(start, end); got != exist {
+						t.Fatalf("Delete(%v, %v) = %v, want %v", start, end, got, exist)
+					}
+					delete(ref, [2]int{start, end})
+				} else {
+					if err := il.Insert(start, end, i); err != nil {
+						t.Fatalf("Insert(%v, %v) error = %v", start, end, err)
+					}
+					ref[[2]int{start, end}] = i
+				}
+
+				if i%50 == 0 {
+					testIntervalListMaxEnds(t, il)
+					for q := 0; q < 20; q++ {
+						a := rnd.Intn(1100) - 50
+						b := a + rnd.Intn(30)
+						if got, want := il.Stab(a), overlapRef(ref, a, a); !reflect.DeepEqual(got, want) {
+							t.Fatalf("Stab(%v) = %v, want %v", a, got, want)
+						}
+						if got, want := il.Overlap(a, b), overlapRef(ref, a, b); !reflect.DeepEqual(got, want) {
+							t.Fatalf("Overlap(%v, %v) = %v, want %v", a, b, got, want)
+						}
+					}
+				}
+			}
+			testIntervalListMaxEnds(t, il)
+
+			if il.Cap() != int32(len(ref)) {
+				t.Errorf("Cap() = %v, want %v", il.Cap(), len(ref))
+			}
+			// deleting every interval leaves an empty list
+			for k := range ref {
+				if !il.Delete(k[0], k[1]) {
+					t.Fatalf("Delete(%v, %v) = false", k[0], k[1])
+				}
+				delete(ref, k)
+				if len(ref)%100 == 0 {
+					testIntervalListMaxEnds(t, il)
+				}
+			}
+			if il.Cap() != 0 || len(il.Overlap(-1000, 2000)) != 0 {
+				t.Errorf("IntervalList is not empty after deleting every interval")
+			}
+		})
+	}
+}
+
+func TestIntervalList_Match(t *testing.T) {
+	il := NewIntervalList[string, string](8, false)
+	for _, r := range []struct{ start, end, name string }{
+		{"10.0.0.0", "10.0.0.255", "a"},
+		{"10.0.0.0", "10.0.255.255", "b"},
+		{"10.0.1.0", "10.0.1.255", "c"},
+		{"192.168.0.0", "192.168.0.255", "d"},
+	} {
+		il.Insert(r.start, r.end, r.name)
+	}
+
+	var got []string
+	for _, m := range il.Stab("10.0.1.100") {
+		got = append(got, m.Start()+"-"+m.End()+":"+m.Val())
+	}
+	if want := []string{"10.0.0.0-10.0.255.255:b", "10.0.1.0-10.0.1.255:c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stab() = %v, want %v", got, want)
+	}
+
+	// the endpoints are included
+	if got := il.Stab("192.168.0.255"); len(got) != 1 || got[0].Val() != "d" {
+		t.Errorf("Stab() of an end = %v, want d", got)
+	}
+	if got := il.Overlap("10.0.0.255", "10.0.1.0"); len(got) != 3 {
+		t.Errorf("Overlap() = %v, want a, b and c", got)
+	}
+	if got := il.Overlap("2", "1"); len(got) != 0 {
+		t.Errorf("Overlap() of a reversed range = %v, want none", got)
+	}
+
+	// an equal interval replaces the value
+	il.Insert("10.0.1.0", "10.0.1.255", "e")
+	if got := il.Stab("10.0.1.0"); il.Cap() != 4 || got[len(got)-1].Val() != "e" {
+		t.Errorf("Insert() of an equal interval = %v, Cap() = %v", got, il.Cap())
+	}
+	if err := il.Insert("b", "a", ""); err != ErrInvalidInterval {
+		t.Errorf("Insert() of a reversed interval error = %v, want %v", err, ErrInvalidInterval)
+	}
+	if il.Delete("10.0.1.0", "10.0.1.254") {
+		t.Errorf("Delete() of an absent interval = true")
+	}
+
+	var n int
+	il.ForEach(func(start, end, val string) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("ForEach() visited %d intervals, want 2", n)
+	}
+
+	var nilList *IntervalList[int, int]
+	if nilList.Insert(1, 2, 3) != nil || nilList.Delete(1, 2) || nilList.Stab(1) != nil || nilList.Cap() != 0 {
+		t.Errorf("nil IntervalList is not empty")
+	}
+	if il := NewIntervalList[int, int](0, false); il != nil {
+		t.Errorf("NewIntervalList() = %v, want nil", il)
+	}
+}
+
+func BenchmarkIntervalList_Stab(b *testing.B) {
+	var (
+		rnd = rand.New(rand.NewSource(1))
+		il  = NewIntervalList[int, int](20, false)
+	)
+	for i := 0; i < 100000; i++ {
+		start := rnd.Intn(10000000)
+		il.Insert(start, start+rnd.Intn(1000), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		il.Stab(rnd.Intn(10000000))
+	}
+}
+
+// overlapRef returns the intervals of ref overlapping [a, b] in ascending order of start and end.
+func overlapRef(ref map[[2]int]int, a, b int) []Match[int, int] {
+	var res = make([]Match[int, int], 0)
+	for k, val := range ref {
+		if k[0] <= b && a <= k[1] {
+			res = append(res, Match[int, int]{start: k[0], end: k[1], val: val})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].start != res[j].start {
+			return res[i].start < res[j].start
+		}
+		return res[i].end < res[j].end
+	})
+	return res
+}
+
+// testIntervalListMaxEnds checks the order of il and that maxEnds of every node on every level is the greatest end
+// of its region.
+func testIntervalListMaxEnds(t *testing.T, il *IntervalList[int, int]) {
+	t.Helper()
+
+	var prev *intervalNode[int, int]
+	for n := il.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if prev != nil && (n.start < prev.start || (n.start == prev.start && n.end <= prev.end)) {
+			t.Fatalf("[%v, %v] follows [%v, %v]", n.start, n.end, prev.start, prev.end)
+		}
+		prev = n
+
+		for l := range n.nextNodes {
+			want := n.end
+			for m := n.nextNodes[0]; m != n.nextNodes[l]; m = m.nextNodes[0] {
+				if m.end > want {
+					want = m.end
+				}
+			}
+			if n.maxEnds[l] != want {
+				t.Fatalf("maxEnds[%d] of [%v, %v] = %v, want %v", l, n.start, n.end, n.maxEnds[l], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package skip_list
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrConcurrentModification = errors.New("skip_list: modified during iteration")
+
+type (
+	// Iterator walks the nodes of SkipList in key order.
+	// Unless WithTombstones or WithSafeIterators, it fails fast once SkipList is modified other than by DeleteAt of it:
+	// Next stops the iteration and Err returns ErrConcurrentModification instead of walking nodes which may be
+	// deleted or recycled.
+	Iterator[O constraints.Ordered, T any] struct {
+		sl *SkipList[O, T]
+
+		// nil if exhausted
+		n *node[O, T]
+
+		// mods of sl the iteration is consistent with, and err is set once they differ
+		mods uint64
+		err  error
+
+		// counted by sl.iterators until closed
+		open bool
+
+		// Next deletes the node it moves past
+		drain bool
+	}
+)
+
+// Iterator returns an Iterator positioned at the least key.
+func (sl *SkipList[O, T]) Iterator() *Iterator[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.newIterator(sl.head.next())
+}
+
+// Seek returns an Iterator positioned at the least key greater than or equal to target.
+func (sl *SkipList[O, T]) Seek(target O) *Iterator[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.newIterator(sl.ceil(target))
+}
+
+// DrainIterator returns an Iterator positioned at the least key, whose Next deletes the node it moves past,
+// so that consuming all of it empties SkipList as a one-shot queue.
+func (sl *SkipList[O, T]) DrainIterator() *Iterator[O, T] {
+	it := sl.Iterator()
+	if it != nil {
+		it.drain = true
+	}
+	return it
+}
+
+// newIterator returns an Iterator positioned at n, which is open if safeIterators.
+func (sl *SkipList[O, T]) newIterator(n *node[O, T]) *Iterator[O, T] {
+	if sl.safeIterators {
+		atomic.AddInt32(&sl.iterators, 1)
+	}
+	return &Iterator[O, T]{sl: sl, n: n, open: sl.safeIterators, mods: sl.mods}
+}
+
+// Valid returns whether it is positioned at a node.
+func (it *Iterator[O, T]) Valid() bool {
+	return it != nil && it.n != nil
+}
+
+// Key returns the key of the node it is positioned at, it must be Valid.
+func (it *Iterator[O, T]) Key() O {
+	return it.n.key
+}
+
+// Val returns the value of the node it is positioned at, it must be Valid.
+func (it *Iterator[O, T]) Val() T {
+	if it.sl.isConcurrent {
+		it.sl.RLock()
+		defer it.sl.RUnlock()
+	}
+
+	return it.n.val
+}
+
+// Next moves it to the next key, and returns whether it is Valid.
+func (it *Iterator[O, T]) Next() bool {
+	if !it.Valid() {
+		return false
+	}
+
+	if it.drain && it.sl.DeleteAt(it) {
+		// moved by DeleteAt
+		return it.n != nil
+	}
+
+	if it.sl.isConcurrent {
+		it.sl.RLock()
+		defer it.sl.RUnlock()
+	}
+
+	if !it.consistent() {
+		return false
+	}
+
+	it.n = it.n.next()
+	return it.n != nil
+}
+
+// Err returns ErrConcurrentModification if Next stopped it because SkipList was modified during the iteration.
+func (it *Iterator[O, T]) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
+
+// consistent returns whether SkipList is not modified since it was positioned, or stops it with
+// ErrConcurrentModification. Modifications are harmless WithTombstones or WithSafeIterators.
+func (it *Iterator[O, T]) consistent() bool {
+	if it.sl.mods == it.mods || it.sl.tombstones || it.sl.safeIterators {
+		return true
+	}
+	it.n, it.err = nil, ErrConcurrentModification
+	return false
+}
+
+// Close releases it, and reclaims the nodes retired for the open Iterators if it is the last one.
+// It is required for the Iterators returned by Iterator and Seek of a SkipList WithSafeIterators, and a no-op otherwise.
+func (it *Iterator[O, T]) Close() {
+	if it == nil || !it.open {
+		return
+	}
+	it.open = false
+	it.n = nil
+
+	if atomic.AddInt32(&it.sl.iterators, -1) > 0 {
+		return
+	}
+
+	sl := it.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if atomic.LoadInt32(&sl.iterators) > 0 {
+		// opened again
+		return
+	}
+	retired := sl.retired
+	sl.retired = nil
+	for _, n := range retired {
+		sl.freeNode(n)
+	}
+}
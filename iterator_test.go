@@ -0,0 +1,296 @@
+package skip_list
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipList_Iterator(t *testing.T) {
+	type testCase struct {
+		name string
+		sl   *SkipList[int, int]
+		want []*KvPair[int, int]
+	}
+
+	var (
+		sl = NewSkipList[int, int](10, false)
+		tl = NewSkipList[int, int](10, true, WithTombstones())
+	)
+	for i := 9; i >= 0; i-- {
+		sl.Put(i, i*i)
+		tl.Put(i, i*i)
+	}
+	tl.Delete(0)
+	tl.Delete(5)
+
+	tests := []testCase{
+		{
+			name: "TestSkipList_Iterator 1",
+			sl:   sl,
+			want: []*KvPair[int, int]{{0, 0}, {1, 1}, {2, 4}, {3, 9}, {4, 16}, {5, 25}, {6, 36}, {7, 49}, {8, 64}, {9, 81}},
+		},
+		{
+			name: "TestSkipList_Iterator 2",
+			sl:   tl,
+			want: []*KvPair[int, int]{{1, 1}, {2, 4}, {3, 9}, {4, 16}, {6, 36}, {7, 49}, {8, 64}, {9, 81}},
+		},
+		{
+			name: "TestSkipList_Iterator 3",
+			sl:   NewSkipList[int, int](10, false),
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestSkipList_Iterator 4",
+			sl:   nil,
+			want: []*KvPair[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got = make([]*KvPair[int, int], 0)
+			for it := tt.sl.Iterator(); it.Valid(); it.Next() {
+				got = append(got, NewKvPair(it.Key(), it.Val()))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Iterator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Seek(t *testing.T) {
+	type testCase struct {
+		name      string
+		target    int
+		wantValid bool
+		wantKey   int
+	}
+
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 0; i < 100; i += 10 {
+		sl.Put(i, i)
+	}
+	sl.Delete(50)
+
+	tests := []testCase{
+		{"TestSkipList_Seek 1", -1, true, 0},
+		{"TestSkipList_Seek 2", 10, true, 10},
+		{"TestSkipList_Seek 3", 11, true, 20},
+		{"TestSkipList_Seek 4", 45, true, 60},
+		{"TestSkipList_Seek 5", 90, true, 90},
+		{"TestSkipList_Seek 6", 91, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := sl.Seek(tt.target)
+			if it.Valid() != tt.wantValid {
+				t.Fatalf("Seek(%v).Valid() = %v, want %v", tt.target, it.Valid(), tt.wantValid)
+			}
+			if tt.wantValid && it.Key() != tt.wantKey {
+				t.Errorf("Seek(%v).Key() = %v, want %v", tt.target, it.Key(), tt.wantKey)
+			}
+			if !tt.wantValid && it.Next() {
+				t.Errorf("Next() = true for an exhausted Iterator")
+			}
+		})
+	}
+}
+
+func TestSkipList_DrainIterator(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestSkipList_DrainIterator 1",
+		},
+		{
+			name: "TestSkipList_DrainIterator 2",
+			opts: []Option{WithTombstones()},
+		},
+		{
+			name: "TestSkipList_DrainIterator 3",
+			opts: []Option{WithNodePool(), WithFinger()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, false, tt.opts...)
+			for i := 999; i >= 0; i-- {
+				sl.Put(i, i)
+			}
+
+			// consume half
+			var (
+				it   = sl.DrainIterator()
+				want int
+			)
+			for ; it.Valid() && it.Key() < 500; it.Next() {
+				if it.Key() != want {
+					t.Fatalf("Key() = %v, want %v", it.Key(), want)
+				}
+				want++
+			}
+			if got := sl.Cap(); got != 500 {
+				t.Errorf("Cap() = %v, want 500 after consuming half", got)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			// consume all
+			for ; it.Valid(); it.Next() {
+				if it.Key() != want {
+					t.Fatalf("Key() = %v, want %v", it.Key(), want)
+				}
+				want++
+			}
+			if want != 1000 {
+				t.Errorf("consumed %v keys, want 1000", want)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if sl.Cap() != 0 || len(sl.Items()) != 0 {
+				t.Errorf("Cap() = %v, Items() = %v after consuming all", sl.Cap(), sl.Items())
+			}
+			if sl.Tombstones() == 0 && sl.Level() != 1 {
+				t.Errorf("Level() = %v, want 1 after consuming all", sl.Level())
+			}
+		})
+	}
+
+	var sl *SkipList[int, int]
+	if it := sl.DrainIterator(); it.Valid() {
+		t.Errorf("DrainIterator() is Valid for nil")
+	}
+}
+
+func TestIterator_Err(t *testing.T) {
+	type testCase struct {
+		name    string
+		opts    []Option
+		modify  func(sl *SkipList[int, int], it *Iterator[int, int])
+		wantErr error
+	}
+	tests := []testCase{
+		{
+			name:    "TestIterator_Err 1",
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Put(1000, 0) },
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			// the node it is positioned at is recycled
+			name:    "TestIterator_Err 2",
+			opts:    []Option{WithNodePool()},
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Delete(it.Key()) },
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name:    "TestIterator_Err 3",
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.DeleteRange(0, 99) },
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name:    "TestIterator_Err 4",
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Clear() },
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			// another Iterator
+			name: "TestIterator_Err 5",
+			modify: func(sl *SkipList[int, int], it *Iterator[int, int]) {
+				sl.DeleteAt(sl.Seek(it.Key() + 1))
+			},
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			// updates are not structural
+			name:    "TestIterator_Err 6",
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Put(it.Key(), -1) },
+			wantErr: nil,
+		},
+		{
+			name: "TestIterator_Err 7",
+			modify: func(sl *SkipList[int, int], it *Iterator[int, int]) {
+				sl.Delete(1000)
+				sl.Put(it.Key(), -1)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "TestIterator_Err 8",
+			opts:    []Option{WithTombstones()},
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Delete(it.Key()) },
+			wantErr: nil,
+		},
+		{
+			name:    "TestIterator_Err 9",
+			opts:    []Option{WithSafeIterators()},
+			modify:  func(sl *SkipList[int, int], it *Iterator[int, int]) { sl.Delete(it.Key()) },
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		for _, isConcurrent := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				sl := NewSkipList[int, int](10, isConcurrent, tt.opts...)
+				for i := 0; i < 100; i++ {
+					sl.Put(i, i)
+				}
+
+				var (
+					it   = sl.Iterator()
+					keys int
+				)
+				for ; it.Valid(); it.Next() {
+					if it.Key() != keys {
+						t.Fatalf("Key() = %v, want %v", it.Key(), keys)
+					}
+					if keys == 50 {
+						tt.modify(sl, it)
+					}
+					keys++
+				}
+				it.Close()
+
+				if err := it.Err(); err != tt.wantErr {
+					t.Fatalf("Err() = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil && keys != 51 {
+					t.Errorf("%d keys before the error, want 51", keys)
+				}
+			})
+		}
+	}
+
+	// DeleteAt of the Iterator itself
+	sl := NewSkipList[int, int](10, false)
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+	it := sl.Iterator()
+	for it.Valid() {
+		if it.Key()%2 == 0 {
+			sl.DeleteAt(it)
+			continue
+		}
+		it.Next()
+	}
+	if it.Err() != nil || sl.Cap() != 50 {
+		t.Errorf("Err() = %v, Cap() = %v after DeleteAt of every even key, want nil, 50", it.Err(), sl.Cap())
+	}
+
+	// DeleteAt of a stale Iterator
+	it = sl.Iterator()
+	sl.Put(-1, -1)
+	if sl.DeleteAt(it) || it.Err() != ErrConcurrentModification || it.Valid() {
+		t.Errorf("DeleteAt() of a stale Iterator = true or Err() = %v", it.Err())
+	}
+
+	var nilIt *Iterator[int, int]
+	if nilIt.Err() != nil {
+		t.Errorf("Err() of nil = %v", nilIt.Err())
+	}
+}
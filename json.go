@@ -0,0 +1,97 @@
+package skip_list
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jsonPair is the JSON form of a kv-pair.
+type jsonPair[O, T any] struct {
+	Key   O `json:"key"`
+	Value T `json:"value"`
+}
+
+var ErrNotInitialized = errors.New("skip_list: SkipList is not initialized by NewSkipList")
+
+// MarshalJSON implements json.Marshaler, encoding SkipList as an array of {"key": key, "value": val} in key order,
+// which keeps the order of keys a JSON object would not.
+func (sl *SkipList[O, T]) MarshalJSON() ([]byte, error) {
+	if sl == nil {
+		return []byte("null"), nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var pairs = make([]jsonPair[O, T], 0, sl.cap)
+	for n := sl.head.next(); n != nil; n = n.next() {
+		pairs = append(pairs, jsonPair[O, T]{Key: n.key, Value: n.val})
+	}
+	return json.Marshal(pairs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing all nodes by the array MarshalJSON encodes.
+// Ascending keys are linked in one pass as BulkLoad does, others are put one by one, and the last one of duplicate keys wins.
+// Malformed data leaves SkipList untouched, and the error reports the index of the malformed element.
+// SkipList must be returned by NewSkipList, whose maxLevel and options are kept.
+func (sl *SkipList[O, T]) UnmarshalJSON(data []byte) error {
+	if sl == nil {
+		return nil
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if elems == nil {
+		// null
+		return nil
+	}
+	if sl.head == nil {
+		return ErrNotInitialized
+	}
+
+	var (
+		pairs     = make([]*KvPair[O, T], len(elems))
+		ascending = true
+	)
+	for i, elem := range elems {
+		var raw jsonPair[json.RawMessage, json.RawMessage]
+		if err := json.Unmarshal(elem, &raw); err != nil {
+			return fmt.Errorf("skip_list: element %d: %w", i, err)
+		}
+		if raw.Key == nil {
+			return fmt.Errorf("skip_list: element %d: missing key", i)
+		}
+
+		var kv = &KvPair[O, T]{}
+		if err := json.Unmarshal(raw.Key, &kv.key); err != nil {
+			return fmt.Errorf("skip_list: element %d: key: %w", i, err)
+		}
+		if raw.Value != nil {
+			if err := json.Unmarshal(raw.Value, &kv.val); err != nil {
+				return fmt.Errorf("skip_list: element %d: value: %w", i, err)
+			}
+		}
+		pairs[i] = kv
+		ascending = ascending && (i == 0 || pairs[i-1].key < kv.key)
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if ascending {
+		sl.replaceAll(pairs)
+		return nil
+	}
+	sl.replaceAll(nil)
+	for _, kv := range pairs {
+		sl.put(nil, kv.key, kv.val)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package skip_list
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type jsonValue struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+func TestSkipList_MarshalJSON(t *testing.T) {
+	t.Run("TestSkipList_MarshalJSON 1", func(t *testing.T) {
+		sl := NewSkipList[int, jsonValue](10, false)
+		for _, i := range []int{3, -1, 20, 2} {
+			sl.Put(i, jsonValue{Name: strings.Repeat("x", i&3)})
+		}
+		sl.Delete(20)
+
+		data, err := json.Marshal(sl)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		want := `[{"key":-1,"value":{"name":"xxx"}},{"key":2,"value":{"name":"xx"}},{"key":3,"value":{"name":"xxx"}}]`
+		if string(data) != want {
+			t.Errorf("Marshal() = %s, want %s", data, want)
+		}
+	})
+
+	t.Run("TestSkipList_MarshalJSON 2", func(t *testing.T) {
+		var sl *SkipList[int, int]
+		if data, err := json.Marshal(sl); err != nil || string(data) != "null" {
+			t.Errorf("Marshal(nil) = %s, %v, want null", data, err)
+		}
+		if data, err := json.Marshal(NewSkipList[int, int](10, false)); err != nil || string(data) != "[]" {
+			t.Errorf("Marshal(empty) = %s, %v, want []", data, err)
+		}
+	})
+}
+
+func TestSkipList_UnmarshalJSON(t *testing.T) {
+	t.Run("TestSkipList_UnmarshalJSON 1", func(t *testing.T) {
+		// int keys and struct values
+		src := NewSkipList[int, jsonValue](0, false)
+		for i := 1000; i > 0; i-- {
+			src.Put(i, jsonValue{Name: "v", Tags: []string{strings.Repeat("t", i%4)}})
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+
+		dst := NewSkipList[int, jsonValue](0, true)
+		dst.Put(-1, jsonValue{})
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got, want := dst.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		if err := dst.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("TestSkipList_UnmarshalJSON 2", func(t *testing.T) {
+		// float keys
+		src := NewSkipList[float64, int](10, false)
+		for i, k := range []float64{0.5, -2.25, 1e300, 3} {
+			src.Put(k, i)
+		}
+		data, _ := json.Marshal(src)
+
+		dst := NewSkipList[float64, int](10, false)
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got, want := dst.Items(), src.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestSkipList_UnmarshalJSON 3", func(t *testing.T) {
+		// unsorted string keys, the last one of duplicates wins
+		dst := NewSkipList[string, int](10, false)
+		data := `[{"key":"b","value":1},{"key":"a","value":2},{"key":"c"},{"key":"b","value":3}]`
+		if err := json.Unmarshal([]byte(data), dst); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got, want := dst.Items(), []*KvPair[string, int]{{"a", 2}, {"b", 3}, {"c", 0}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		if err := dst.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+
+		// a struct field
+		var s struct {
+			Index *SkipList[string, int] `json:"index"`
+		}
+		s.Index = NewSkipList[string, int](10, false)
+		if err := json.Unmarshal([]byte(`{"index":`+data+`}`), &s); err != nil || s.Index.Cap() != 3 {
+			t.Errorf("Unmarshal() = %v, %v, want 3 keys", s.Index.Items(), err)
+		}
+	})
+}
+
+func TestSkipList_UnmarshalJSON_Malformed(t *testing.T) {
+	type testCase struct {
+		name    string
+		data    string
+		wantErr string
+	}
+	testCases := []testCase{
+		{name: "TestSkipList_UnmarshalJSON_Malformed 1", data: `{"key":1}`, wantErr: "cannot unmarshal object"},
+		{name: "TestSkipList_UnmarshalJSON_Malformed 2", data: `[{"key":1,"value":1},{"key":"2","value":2}]`, wantErr: "element 1: key"},
+		{name: "TestSkipList_UnmarshalJSON_Malformed 3", data: `[{"key":1,"value":1},{"key":2,"value":1},{"key":3,"value":"x"}]`, wantErr: "element 2: value"},
+		{name: "TestSkipList_UnmarshalJSON_Malformed 4", data: `[{"value":1}]`, wantErr: "element 0: missing key"},
+		{name: "TestSkipList_UnmarshalJSON_Malformed 5", data: `[1]`, wantErr: "element 0"},
+		{name: "TestSkipList_UnmarshalJSON_Malformed 6", data: `[{"key":1}`, wantErr: "unexpected end"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, false)
+			sl.Put(7, 7)
+			err := json.Unmarshal([]byte(tc.data), sl)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Unmarshal() error = %v, want %q", err, tc.wantErr)
+			}
+			// untouched
+			if got, want := sl.Items(), []*KvPair[int, int]{{7, 7}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("TestSkipList_UnmarshalJSON_Malformed 7", func(t *testing.T) {
+		var sl SkipList[int, int]
+		if err := json.Unmarshal([]byte(`[{"key":1}]`), &sl); !errors.Is(err, ErrNotInitialized) {
+			t.Errorf("Unmarshal() error = %v, want %v", err, ErrNotInitialized)
+		}
+	})
+}
@@ -7,6 +7,12 @@ type (
 		key O
 		val T
 	}
+
+	// RankedPair is a kv-pair with the index of its key in key order.
+	RankedPair[O constraints.Ordered, T any] struct {
+		KvPair[O, T]
+		rank int
+	}
 )
 
 func (kv *KvPair[O, T]) Key() (key O) {
@@ -17,9 +23,18 @@ func (kv *KvPair[O, T]) Val() (val T) {
 	return kv.val
 }
 
+func (rp *RankedPair[O, T]) Rank() int {
+	return rp.rank
+}
+
 func newKvPair[O constraints.Ordered, T any](key O, val T) *KvPair[O, T] {
 	return &KvPair[O, T]{
 		key: key,
 		val: val,
 	}
 }
+
+// NewKvPair returns a *KvPair of key and val.
+func NewKvPair[O constraints.Ordered, T any](key O, val T) *KvPair[O, T] {
+	return newKvPair(key, val)
+}
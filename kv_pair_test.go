@@ -195,3 +195,9 @@ func Test_newKvPair(t *testing.T) {
 		})
 	}
 }
+
+func TestNewKvPair(t *testing.T) {
+	if got, want := NewKvPair("key", 1), (&KvPair[string, int]{"key", 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewKvPair() = %v, want %v", got, want)
+	}
+}
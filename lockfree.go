@@ -0,0 +1,326 @@
+package skip_list
+
+import (
+	"math/bits"
+	"math/rand"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// LockFreeSkipList is a concurrent SkipList after Herlihy and Shavit, whose reads are wait-free and writes lock-free.
+	// Links are replaced by compare-and-swap, and a node is deleted by clearing its value, which is the linearization point,
+	// then marking its links from the top level down, after which any search unlinks it.
+	LockFreeSkipList[O constraints.Ordered, T any] struct {
+		// the highest level in use, which never decreases
+		level, maxLevel int32
+
+		// number of nodes whose value is set
+		cap int32
+
+		// head node of LockFreeSkipList, with maxLevel+1 levels
+		head *lfNode[O, T]
+	}
+
+	lfNode[O constraints.Ordered, T any] struct {
+		key O
+
+		// nil once deleted
+		val atomic.Pointer[T]
+
+		nextNodes []atomic.Pointer[lfLink[O, T]]
+	}
+
+	// lfLink is an immutable link to the next node, which is marked if the node holding it is being deleted.
+	lfLink[O constraints.Ordered, T any] struct {
+		next   *lfNode[O, T]
+		marked bool
+	}
+)
+
+// NewLockFree returns a LockFreeSkipList whose nodes have at most maxLevel+1 levels, which is promoted with probability 0.5.
+// Unlike NewSkipList, maxLevel is not tuned if 0.
+func NewLockFree[O constraints.Ordered, T any](maxLevel int32) *LockFreeSkipList[O, T] {
+	if maxLevel < 0 {
+		return nil
+	}
+
+	head := &lfNode[O, T]{nextNodes: make([]atomic.Pointer[lfLink[O, T]], maxLevel+1)}
+	for l := range head.nextNodes {
+		head.nextNodes[l].Store(&lfLink[O, T]{})
+	}
+	return &LockFreeSkipList[O, T]{
+		level:    1,
+		maxLevel: maxLevel,
+		head:     head,
+	}
+}
+
+func (sl *LockFreeSkipList[O, T]) Level() int32 {
+	if sl == nil {
+		return 0
+	}
+	return atomic.LoadInt32(&sl.level)
+}
+
+// Cap returns the number of nodes, which is exact only while no write is in progress.
+func (sl *LockFreeSkipList[O, T]) Cap() int32 {
+	if sl == nil {
+		return 0
+	}
+	return atomic.LoadInt32(&sl.cap)
+}
+
+func (sl *LockFreeSkipList[O, T]) Get(key O) (val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if n := sl.ceil(key); n != nil && n.key == key {
+		if v := n.val.Load(); v != nil {
+			return *v, true
+		}
+	}
+	return
+}
+
+func (sl *LockFreeSkipList[O, T]) Put(key O, val T) {
+	if sl == nil {
+		return
+	}
+
+	var (
+		h      = sl.randHeight()
+		preds  = make([]*lfNode[O, T], sl.maxLevel+1)
+		links  = make([]*lfLink[O, T], sl.maxLevel+1)
+		newVal = &val
+	)
+	for level := atomic.LoadInt32(&sl.level); level < h; level = atomic.LoadInt32(&sl.level) {
+		if atomic.CompareAndSwapInt32(&sl.level, level, h) {
+			break
+		}
+	}
+
+	for {
+		if n := sl.find(key, preds, links); n != nil {
+			if old := n.val.Load(); old != nil {
+				if n.val.CompareAndSwap(old, newVal) {
+					return
+				}
+				continue
+			}
+			// help the deletion in progress, then search again
+			sl.mark(n)
+			continue
+		}
+
+		newNode := &lfNode[O, T]{key: key, nextNodes: make([]atomic.Pointer[lfLink[O, T]], h)}
+		newNode.val.Store(newVal)
+		for l := range newNode.nextNodes {
+			newNode.nextNodes[l].Store(&lfLink[O, T]{next: links[l].next})
+		}
+		// linearization point
+		if !preds[0].nextNodes[0].CompareAndSwap(links[0], &lfLink[O, T]{next: newNode}) {
+			continue
+		}
+		atomic.AddInt32(&sl.cap, 1)
+
+		sl.linkUpper(newNode, preds, links)
+		return
+	}
+}
+
+// linkUpper links the levels of newNode above 0 bottom up, giving up once it is being deleted.
+func (sl *LockFreeSkipList[O, T]) linkUpper(newNode *lfNode[O, T], preds []*lfNode[O, T], links []*lfLink[O, T]) {
+	for l := 1; l < len(newNode.nextNodes); l++ {
+		for {
+			link := newNode.nextNodes[l].Load()
+			if link.marked {
+				return
+			}
+			if succ := links[l].next; link.next != succ {
+				if !newNode.nextNodes[l].CompareAndSwap(link, &lfLink[O, T]{next: succ}) {
+					continue
+				}
+			}
+			if preds[l].nextNodes[l].CompareAndSwap(links[l], &lfLink[O, T]{next: newNode}) {
+				break
+			}
+			if sl.find(newNode.key, preds, links) != newNode {
+				// deleted
+				return
+			}
+		}
+	}
+}
+
+func (sl *LockFreeSkipList[O, T]) Delete(key O) {
+	if sl == nil {
+		return
+	}
+
+	var (
+		preds = make([]*lfNode[O, T], sl.maxLevel+1)
+		links = make([]*lfLink[O, T], sl.maxLevel+1)
+	)
+	n := sl.find(key, preds, links)
+	if n == nil {
+		return
+	}
+	for {
+		old := n.val.Load()
+		if old == nil {
+			// deleted by another
+			return
+		}
+		// linearization point
+		if n.val.CompareAndSwap(old, nil) {
+			break
+		}
+	}
+	atomic.AddInt32(&sl.cap, -1)
+
+	sl.mark(n)
+	// unlink
+	sl.find(key, preds, links)
+}
+
+// mark marks the links of n from the top level down.
+func (sl *LockFreeSkipList[O, T]) mark(n *lfNode[O, T]) {
+	for l := len(n.nextNodes) - 1; l >= 0; l-- {
+		for {
+			link := n.nextNodes[l].Load()
+			if link.marked || n.nextNodes[l].CompareAndSwap(link, &lfLink[O, T]{next: link.next, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// Range searches the *KvPair of key in [start, end], which is weakly consistent under concurrent writes:
+// every key present throughout the call is returned, and keys written during it may or may not be.
+func (sl *LockFreeSkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	sl.forEach(sl.ceil(start), func(key O, val T) bool {
+		if key > end {
+			return false
+		}
+		res = append(res, newKvPair(key, val))
+		return true
+	})
+	return res
+}
+
+// Items returns all *KvPair in key order, weakly consistent as Range.
+func (sl *LockFreeSkipList[O, T]) Items() []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var n = atomic.LoadInt32(&sl.cap)
+	if n < 0 {
+		// a node deleted before its insertion is counted
+		n = 0
+	}
+	var res = make([]*KvPair[O, T], 0, n)
+	sl.forEach(sl.head.nextNodes[0].Load().next, func(key O, val T) bool {
+		res = append(res, newKvPair(key, val))
+		return true
+	})
+	return res
+}
+
+// ForEach calls fn for each key in ascending order until fn returns false, weakly consistent as Range.
+func (sl *LockFreeSkipList[O, T]) ForEach(fn func(key O, val T) bool) {
+	if sl == nil {
+		return
+	}
+
+	sl.forEach(sl.head.nextNodes[0].Load().next, fn)
+}
+
+func (sl *LockFreeSkipList[O, T]) forEach(n *lfNode[O, T], fn func(key O, val T) bool) {
+	for n != nil {
+		link := n.nextNodes[0].Load()
+		if v := n.val.Load(); v != nil && !link.marked && !fn(n.key, *v) {
+			return
+		}
+		n = link.next
+	}
+}
+
+// ceil returns the first node of key greater than or equal to target not marked on level 0, without unlinking marked nodes.
+func (sl *LockFreeSkipList[O, T]) ceil(target O) *lfNode[O, T] {
+	var (
+		pred = sl.head
+		curr *lfNode[O, T]
+	)
+	for l := atomic.LoadInt32(&sl.level) - 1; l >= 0; l-- {
+		curr = pred.nextNodes[l].Load().next
+		for curr != nil {
+			link := curr.nextNodes[l].Load()
+			if link.marked {
+				// skip curr being deleted
+				curr = link.next
+				continue
+			}
+			if curr.key >= target {
+				break
+			}
+			pred, curr = curr, link.next
+		}
+	}
+	return curr
+}
+
+// find fills preds and links with the predecessors of key on every level in use and their unmarked links,
+// unlinking the marked nodes on the way. It returns the node of key if exists.
+func (sl *LockFreeSkipList[O, T]) find(key O, preds []*lfNode[O, T], links []*lfLink[O, T]) *lfNode[O, T] {
+retry:
+	for {
+		pred := sl.head
+		for l := atomic.LoadInt32(&sl.level) - 1; l >= 0; l-- {
+			link := pred.nextNodes[l].Load()
+			if link.marked {
+				// pred is being deleted
+				continue retry
+			}
+			for curr := link.next; curr != nil; curr = link.next {
+				currLink := curr.nextNodes[l].Load()
+				if currLink.marked {
+					// unlink curr
+					unlinked := &lfLink[O, T]{next: currLink.next}
+					if !pred.nextNodes[l].CompareAndSwap(link, unlinked) {
+						continue retry
+					}
+					link = unlinked
+					continue
+				}
+				if curr.key >= key {
+					break
+				}
+				pred, link = curr, currLink
+			}
+			preds[l], links[l] = pred, link
+		}
+
+		if n := links[0].next; n != nil && n.key == key {
+			return n
+		}
+		return nil
+	}
+}
+
+// randHeight returns the number of levels of a new node by coin flips of the global rand source, which is lock-free.
+func (sl *LockFreeSkipList[O, T]) randHeight() int32 {
+	randL := int32(bits.TrailingZeros64(rand.Uint64()))
+	if randL > sl.maxLevel {
+		randL = sl.maxLevel
+	}
+	return randL + 1
+}
@@ -0,0 +1,407 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewLockFree(t *testing.T) {
+	if sl := NewLockFree[int, int](-1); sl != nil {
+		t.Errorf("NewLockFree(-1) = %v, want nil", sl)
+	}
+
+	var sl *LockFreeSkipList[int, int]
+	sl.Put(1, 1)
+	sl.Delete(1)
+	if _, ok := sl.Get(1); ok || sl.Cap() != 0 || sl.Level() != 0 || sl.Range(0, 1) != nil || sl.Items() != nil {
+		t.Errorf("nil LockFreeSkipList is not empty")
+	}
+}
+
+func TestLockFreeSkipList(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+	}
+	tests := []testCase{
+		{
+			name:     "TestLockFreeSkipList 1",
+			maxLevel: 0,
+		},
+		{
+			name:     "TestLockFreeSkipList 2",
+			maxLevel: 3,
+		},
+		{
+			name:     "TestLockFreeSkipList 3",
+			maxLevel: 16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the same operations on SkipList and LockFreeSkipList give the same results
+			var (
+				r    = rand.New(rand.NewSource(1))
+				sl   = NewLockFree[int, int](tt.maxLevel)
+				want = NewSkipList[int, int](0, false)
+			)
+			for i := 0; i < 20000; i++ {
+				key := r.Intn(500)
+				switch r.Intn(4) {
+				case 0, 1:
+					sl.Put(key, i)
+					want.Put(key, i)
+				case 2:
+					sl.Delete(key)
+					want.Delete(key)
+				case 3:
+					got, gotOk := sl.Get(key)
+					val, ok := want.Get(key)
+					if got != val || gotOk != ok {
+						t.Fatalf("Get(%v) = %v, %v, want %v, %v", key, got, gotOk, val, ok)
+					}
+					if got, want := sl.Range(key, key+20), want.Range(key, key+20); !reflect.DeepEqual(got, want) {
+						t.Fatalf("Range(%v, %v) = %v, want %v", key, key+20, got, want)
+					}
+				}
+			}
+			if got := sl.Cap(); got != want.Cap() {
+				t.Errorf("Cap() = %v, want %v", got, want.Cap())
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, want.Items()) {
+				t.Errorf("Items() = %v, want %v", got, want.Items())
+			}
+			var keys []int
+			sl.ForEach(func(key, _ int) bool {
+				keys = append(keys, key)
+				return len(keys) < 3
+			})
+			if len(keys) != 3 {
+				t.Errorf("ForEach() visited %v, want 3 keys", keys)
+			}
+			if err := sl.validate(); err != nil {
+				t.Errorf("validate() error = %v", err)
+			}
+		})
+	}
+}
+
+// validate checks that every level is ascending and a subsequence of level 0,
+// and that no marked node is linked once writes are quiescent.
+func (sl *LockFreeSkipList[O, T]) validate() error {
+	for l := sl.level - 1; l >= 0; l-- {
+		var lower = sl.head
+		for n := sl.head.nextNodes[l].Load().next; n != nil; {
+			link := n.nextNodes[l].Load()
+			if link.marked || n.val.Load() == nil {
+				return fmt.Errorf("node %v on level %d is deleted", n.key, l)
+			}
+			if link.next != nil && link.next.key <= n.key {
+				return fmt.Errorf("level %d is not ascending at %v", l, n.key)
+			}
+			for lower != nil && lower != n {
+				lower = lower.nextNodes[0].Load().next
+			}
+			if lower == nil {
+				return fmt.Errorf("node %v on level %d is not on level 0", n.key, l)
+			}
+			n = link.next
+		}
+	}
+	return nil
+}
+
+func TestLockFreeSkipList_Stress(t *testing.T) {
+	const (
+		keys    = 256
+		writers = 8
+		readers = 8
+		ops     = 5000
+	)
+
+	var (
+		sl   = NewLockFree[int, int](8)
+		ref  = NewConcurrent[int, int](8)
+		wg   sync.WaitGroup
+		done int32
+	)
+	// keys are owned by writers, so the writes of every key reach sl and ref in the same order
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < ops; i++ {
+				key := r.Intn(keys/writers)*writers + w
+				if r.Intn(3) == 0 {
+					sl.Delete(key)
+					ref.Delete(key)
+				} else {
+					// the value encodes its key
+					sl.Put(key, key+keys*i)
+					ref.Put(key, key+keys*i)
+				}
+			}
+		}(w)
+	}
+	var readersWg sync.WaitGroup
+	for rd := 0; rd < readers; rd++ {
+		readersWg.Add(1)
+		go func(seed int64) {
+			defer readersWg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for atomic.LoadInt32(&done) == 0 {
+				key := r.Intn(keys)
+				if val, ok := sl.Get(key); ok && val%keys != key {
+					t.Errorf("Get(%v) = %v", key, val)
+				}
+				kvs := sl.Range(key, key+16)
+				for j, kv := range kvs {
+					if kv.val%keys != kv.key || j > 0 && kvs[j-1].key >= kv.key {
+						t.Errorf("Range(%v) = %v", key, kvs)
+						break
+					}
+				}
+			}
+		}(int64(rd + writers))
+	}
+	wg.Wait()
+	atomic.StoreInt32(&done, 1)
+	readersWg.Wait()
+
+	if got, want := sl.Items(), ref.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if got, want := sl.Cap(), ref.Cap(); got != want {
+		t.Errorf("Cap() = %v, want %v", got, want)
+	}
+	// unlink nodes marked after the last search
+	for key := 0; key < keys; key++ {
+		sl.find(key, make([]*lfNode[int, int], sl.maxLevel+1), make([]*lfLink[int, int], sl.maxLevel+1))
+	}
+	if err := sl.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
+
+type lfOp struct {
+	// logical times of invocation and response
+	call, ret int64
+
+	// 0: Put(val), 1: Delete, 2: Get returning val and ok
+	kind int
+	val  int
+	ok   bool
+}
+
+// linearizable reports whether ops on a single key, at most 64, are equivalent to some sequential order
+// respecting their real-time order, starting from an absent key.
+func linearizable(ops []lfOp) bool {
+	type state struct {
+		done    uint64
+		val     int
+		present bool
+	}
+
+	var (
+		all     = uint64(1)<<len(ops) - 1
+		visited = make(map[state]bool)
+		dfs     func(s state) bool
+	)
+	dfs = func(s state) bool {
+		if s.done == all {
+			return true
+		}
+		if visited[s] {
+			return false
+		}
+		visited[s] = true
+
+		// an op can be linearized next only if invoked before every pending op responded
+		var minRet int64 = 1<<63 - 1
+		for i, op := range ops {
+			if s.done&(1<<i) == 0 && op.ret < minRet {
+				minRet = op.ret
+			}
+		}
+		for i, op := range ops {
+			if s.done&(1<<i) != 0 || op.call > minRet {
+				continue
+			}
+			next := state{done: s.done | 1<<i, val: s.val, present: s.present}
+			switch op.kind {
+			case 0:
+				next.val, next.present = op.val, true
+			case 1:
+				next.val, next.present = 0, false
+			case 2:
+				if op.ok != s.present || op.ok && op.val != s.val {
+					continue
+				}
+			}
+			if dfs(next) {
+				return true
+			}
+		}
+		return false
+	}
+	return dfs(state{})
+}
+
+func TestLockFreeSkipList_Linearizable(t *testing.T) {
+	const (
+		rounds     = 200
+		goroutines = 4
+		ops        = 16
+		keys       = 2
+	)
+
+	for round := 0; round < rounds; round++ {
+		var (
+			sl      = NewLockFree[int, int](4)
+			clock   int64
+			history = make([][]lfOp, goroutines*keys)
+			wg      sync.WaitGroup
+		)
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(round*goroutines + g)))
+				for i := 0; i < ops; i++ {
+					var (
+						key = r.Intn(keys)
+						op  = lfOp{kind: r.Intn(3)}
+					)
+					op.call = atomic.AddInt64(&clock, 1)
+					switch op.kind {
+					case 0:
+						op.val = g*ops + i + 1
+						sl.Put(key, op.val)
+					case 1:
+						sl.Delete(key)
+					case 2:
+						op.val, op.ok = sl.Get(key)
+					}
+					op.ret = atomic.AddInt64(&clock, 1)
+					history[g*keys+key] = append(history[g*keys+key], op)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		for key := 0; key < keys; key++ {
+			var ops []lfOp
+			for g := 0; g < goroutines; g++ {
+				ops = append(ops, history[g*keys+key]...)
+			}
+			if !linearizable(ops) {
+				t.Fatalf("round %d: history of key %d is not linearizable: %v", round, key, ops)
+			}
+		}
+	}
+}
+
+func TestLinearizable(t *testing.T) {
+	type testCase struct {
+		name string
+		ops  []lfOp
+		want bool
+	}
+	tests := []testCase{
+		{
+			name: "TestLinearizable 1",
+			// Put(1) then Get = 1
+			ops:  []lfOp{{call: 1, ret: 2, kind: 0, val: 1}, {call: 3, ret: 4, kind: 2, val: 1, ok: true}},
+			want: true,
+		},
+		{
+			name: "TestLinearizable 2",
+			// Get = 1 returns before Put(1) is invoked
+			ops:  []lfOp{{call: 3, ret: 4, kind: 0, val: 1}, {call: 1, ret: 2, kind: 2, val: 1, ok: true}},
+			want: false,
+		},
+		{
+			name: "TestLinearizable 3",
+			// Get = 1 overlaps Put(1) and Delete
+			ops: []lfOp{
+				{call: 1, ret: 5, kind: 0, val: 1},
+				{call: 2, ret: 6, kind: 1},
+				{call: 3, ret: 4, kind: 2, val: 1, ok: true},
+			},
+			want: true,
+		},
+		{
+			name: "TestLinearizable 4",
+			// Put(1) and Delete are done before Get = 1
+			ops: []lfOp{
+				{call: 1, ret: 2, kind: 0, val: 1},
+				{call: 3, ret: 4, kind: 1},
+				{call: 5, ret: 6, kind: 2, val: 1, ok: true},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := linearizable(tt.ops); got != tt.want {
+				t.Errorf("linearizable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkLockFreeSkipList(b *testing.B) {
+	const keys = 1 << 16
+
+	type list interface {
+		Get(key int) (int, bool)
+		Put(key, val int)
+		Delete(key int)
+	}
+	lists := []struct {
+		name string
+		new  func() list
+	}{
+		{"SkipList", func() list { return NewSkipList[int, int](0, true) }},
+		{"ConcurrentSkipList", func() list { return NewConcurrent[int, int](0) }},
+		{"LockFreeSkipList", func() list { return NewLockFree[int, int](20) }},
+	}
+	for _, l := range lists {
+		for _, goroutines := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("%s/goroutines=%d", l.name, goroutines), func(b *testing.B) {
+				sl := l.new()
+				for i := 0; i < keys; i += 2 {
+					sl.Put(i, i)
+				}
+
+				var wg sync.WaitGroup
+				b.ResetTimer()
+				for g := 0; g < goroutines; g++ {
+					wg.Add(1)
+					go func(seed int64) {
+						defer wg.Done()
+						r := rand.New(rand.NewSource(seed))
+						// 80% Get, 10% Put and 10% Delete
+						for i := 0; i < b.N/goroutines; i++ {
+							key := r.Intn(keys)
+							switch op := r.Intn(10); {
+							case op == 0:
+								sl.Put(key, i)
+							case op == 1:
+								sl.Delete(key)
+							default:
+								sl.Get(key)
+							}
+						}
+					}(int64(g))
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
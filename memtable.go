@@ -0,0 +1,211 @@
+package skip_list
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrStaleSequence = errors.New("skip_list: sequence number not greater than the last one")
+
+// RecordKind is the kind of a record of Memtable.
+type RecordKind uint8
+
+const (
+	// RecordValue is a record written by Put.
+	RecordValue RecordKind = iota
+
+	// RecordTombstone is a record written by Delete, which shadows the older records of its key.
+	RecordTombstone
+)
+
+type (
+	// Memtable is the memtable of an LSM tree, recording every write of a key with its sequence number rather than
+	// overwriting or removing the older ones, so that Get reads the key as of any snapshot sequence number.
+	// It is a SkipList of the records keyed by the Encode of CompositeKey of key and the complement of sequence number,
+	// by which the records of a key are ordered from the newest and the newest visible one is found by one Ceil.
+	Memtable[K constraints.Ordered, V any] struct {
+		sl *SkipList[string, memRecord[K, V]]
+
+		// the sequence number of the last write, and the approximate bytes, guarded by the lock of sl
+		lastSeq uint64
+		written bool
+		size    int64
+	}
+
+	memRecord[K constraints.Ordered, V any] struct {
+		key  K
+		seq  uint64
+		kind RecordKind
+		val  V
+	}
+
+	// FlushIter walks the records of Memtable in ascending order of keys and descending order of sequence numbers
+	// for each key, including tombstones. Memtable must not be written during the iteration, as it is frozen once
+	// it is flushed, otherwise Next stops it like Iterator.
+	FlushIter[K constraints.Ordered, V any] struct {
+		it *Iterator[string, memRecord[K, V]]
+	}
+)
+
+// NewMemtable returns a Memtable of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewMemtable[K constraints.Ordered, V any](maxLevel int32, isConcurrent bool, opts ...Option) *Memtable[K, V] {
+	sl := NewSkipList[string, memRecord[K, V]](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &Memtable[K, V]{sl: sl}
+}
+
+// Put records val of key at seq, which must be greater than the sequence number of the last write,
+// or it returns ErrStaleSequence.
+func (m *Memtable[K, V]) Put(seq uint64, key K, val V) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.write(memRecord[K, V]{key: key, seq: seq, kind: RecordValue, val: val})
+}
+
+// Delete records a tombstone of key at seq, which must be greater than the sequence number of the last write,
+// or it returns ErrStaleSequence.
+func (m *Memtable[K, V]) Delete(seq uint64, key K) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.write(memRecord[K, V]{key: key, seq: seq, kind: RecordTombstone})
+}
+
+func (m *Memtable[K, V]) write(r memRecord[K, V]) error {
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if m.written && r.seq <= m.lastSeq {
+		return ErrStaleSequence
+	}
+	sl.count(&sl.puts)
+	m.lastSeq, m.written = r.seq, true
+
+	key := memtableKey(r.key, r.seq)
+	sl.put(nil, key, r)
+	m.size += recordBytes(key, r)
+	return nil
+}
+
+// Get returns the value of the newest record of key whose sequence number is less than or equal to readSeq,
+// with its kind. The value of a RecordTombstone is zero, and exist is false if no record of key is visible at readSeq,
+// in which case the older tables of the LSM tree are searched.
+func (m *Memtable[K, V]) Get(key K, readSeq uint64) (val V, kind RecordKind, exist bool) {
+	if m == nil {
+		return
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	n := sl.ceil(memtableKey(key, readSeq))
+	if n == nil || n.val.key != key {
+		return
+	}
+	return n.val.val, n.val.kind, true
+}
+
+// ApproximateSize returns the approximate bytes of the records of Memtable, including their nodes, their keys,
+// and the bytes of string and []byte values, by which it is flushed once it is large enough.
+func (m *Memtable[K, V]) ApproximateSize() int64 {
+	if m == nil {
+		return 0
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return m.size
+}
+
+// NewFlushIter returns a FlushIter positioned at the newest record of the least key.
+func (m *Memtable[K, V]) NewFlushIter() *FlushIter[K, V] {
+	if m == nil {
+		return nil
+	}
+
+	return &FlushIter[K, V]{it: m.sl.Iterator()}
+}
+
+// Valid returns whether it is positioned at a record.
+func (it *FlushIter[K, V]) Valid() bool {
+	return it != nil && it.it.Valid()
+}
+
+// Next moves it to the next record, and returns whether it is Valid.
+func (it *FlushIter[K, V]) Next() bool {
+	return it.Valid() && it.it.Next()
+}
+
+// Key returns the key of the record it is positioned at, it must be Valid.
+func (it *FlushIter[K, V]) Key() K {
+	return it.it.Val().key
+}
+
+// Seq returns the sequence number of the record it is positioned at, it must be Valid.
+func (it *FlushIter[K, V]) Seq() uint64 {
+	return it.it.Val().seq
+}
+
+// Kind returns the kind of the record it is positioned at, it must be Valid.
+func (it *FlushIter[K, V]) Kind() RecordKind {
+	return it.it.Val().kind
+}
+
+// Val returns the value of the record it is positioned at, which is zero for a RecordTombstone. It must be Valid.
+func (it *FlushIter[K, V]) Val() V {
+	return it.it.Val().val
+}
+
+// Err returns ErrConcurrentModification if Next stopped it because Memtable was written during the iteration.
+func (it *FlushIter[K, V]) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.it.Err()
+}
+
+// Close releases it, which is required WithSafeIterators like Iterator.
+func (it *FlushIter[K, V]) Close() {
+	if it == nil {
+		return
+	}
+	it.it.Close()
+}
+
+// memtableKey returns the key of the record of key at seq in Memtable, which sorts the newer records of key first.
+func memtableKey[K constraints.Ordered](key K, seq uint64) string {
+	return NewCompositeKey(key, ^seq).Encode()
+}
+
+// recordBytes returns the approximate bytes of r keyed by key, like EstimatedBytes.
+func recordBytes[K constraints.Ordered, V any](key string, r memRecord[K, V]) int64 {
+	var bytes = int64(unsafe.Sizeof(node[string, memRecord[K, V]]{})) + int64(len(key))
+	if s, ok := any(r.key).(string); ok {
+		bytes += int64(len(s))
+	}
+	switch v := any(r.val).(type) {
+	case string:
+		bytes += int64(len(v))
+	case []byte:
+		bytes += int64(len(v))
+	}
+	return bytes
+}
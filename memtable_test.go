@@ -0,0 +1,189 @@
+package skip_list
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// memtableOp is a write of memtable tests, a tombstone if del.
+type memtableOp struct {
+	seq uint64
+	key string
+	val string
+	del bool
+}
+
+func TestMemtable(t *testing.T) {
+	var ops = []memtableOp{
+		{seq: 1, key: "b", val: "b1"},
+		{seq: 2, key: "a", val: "a2"},
+		{seq: 3, key: "b", del: true},
+		{seq: 4, key: "c", val: "c4"},
+		{seq: 5, key: "b", val: "b5"},
+		{seq: 6, key: "a", del: true},
+		{seq: 7, key: "a", val: "a7"},
+		{seq: 8, key: "c", del: true},
+	}
+
+	type want struct {
+		val   string
+		kind  RecordKind
+		exist bool
+	}
+	type testCase struct {
+		name    string
+		key     string
+		readSeq uint64
+		want    want
+	}
+	tests := []testCase{
+		{name: "TestMemtable 1", key: "b", readSeq: 0, want: want{}},
+		{name: "TestMemtable 2", key: "b", readSeq: 1, want: want{val: "b1", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 3", key: "b", readSeq: 2, want: want{val: "b1", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 4", key: "b", readSeq: 3, want: want{kind: RecordTombstone, exist: true}},
+		{name: "TestMemtable 5", key: "b", readSeq: 4, want: want{kind: RecordTombstone, exist: true}},
+		{name: "TestMemtable 6", key: "b", readSeq: 5, want: want{val: "b5", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 7", key: "a", readSeq: 1, want: want{}},
+		{name: "TestMemtable 8", key: "a", readSeq: 5, want: want{val: "a2", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 9", key: "a", readSeq: 6, want: want{kind: RecordTombstone, exist: true}},
+		{name: "TestMemtable 10", key: "a", readSeq: 1 << 63, want: want{val: "a7", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 11", key: "c", readSeq: 7, want: want{val: "c4", kind: RecordValue, exist: true}},
+		{name: "TestMemtable 12", key: "c", readSeq: ^uint64(0), want: want{kind: RecordTombstone, exist: true}},
+		{name: "TestMemtable 13", key: "", readSeq: ^uint64(0), want: want{}},
+		{name: "TestMemtable 14", key: "d", readSeq: ^uint64(0), want: want{}},
+		// a key prefixed by another is not read as it
+		{name: "TestMemtable 15", key: "a\x00", readSeq: ^uint64(0), want: want{}},
+	}
+	for _, isConcurrent := range []bool{false, true} {
+		m := NewMemtable[string, string](0, isConcurrent)
+		for _, op := range ops {
+			var err error
+			if op.del {
+				err = m.Delete(op.seq, op.key)
+			} else {
+				err = m.Put(op.seq, op.key, op.val)
+			}
+			if err != nil {
+				t.Fatalf("write %+v error = %v", op, err)
+			}
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				val, kind, exist := m.Get(tt.key, tt.readSeq)
+				if got := (want{val: val, kind: kind, exist: exist}); got != tt.want {
+					t.Errorf("Get(%q, %v) = %+v, want %+v", tt.key, tt.readSeq, got, tt.want)
+				}
+			})
+		}
+
+		// the records are flushed by key, from the newest, including tombstones
+		var got []memtableOp
+		it := m.NewFlushIter()
+		for ; it.Valid(); it.Next() {
+			got = append(got, memtableOp{seq: it.Seq(), key: it.Key(), val: it.Val(), del: it.Kind() == RecordTombstone})
+		}
+		it.Close()
+		wantFlush := []memtableOp{
+			{seq: 7, key: "a", val: "a7"},
+			{seq: 6, key: "a", del: true},
+			{seq: 2, key: "a", val: "a2"},
+			{seq: 5, key: "b", val: "b5"},
+			{seq: 3, key: "b", del: true},
+			{seq: 1, key: "b", val: "b1"},
+			{seq: 8, key: "c", del: true},
+			{seq: 4, key: "c", val: "c4"},
+		}
+		if !reflect.DeepEqual(got, wantFlush) || it.Err() != nil {
+			t.Errorf("flushed %+v, %v, want %+v", got, it.Err(), wantFlush)
+		}
+	}
+}
+
+func TestMemtable_Sequence(t *testing.T) {
+	m := NewMemtable[int, []byte](0, false)
+	if err := m.Put(0, 1, []byte("x")); err != nil {
+		t.Errorf("Put(0) error = %v", err)
+	}
+	if err := m.Put(0, 2, nil); !errors.Is(err, ErrStaleSequence) {
+		t.Errorf("Put(0) again error = %v, want ErrStaleSequence", err)
+	}
+	if err := m.Delete(0, 1); !errors.Is(err, ErrStaleSequence) {
+		t.Errorf("Delete(0) error = %v, want ErrStaleSequence", err)
+	}
+	if _, _, exist := m.Get(2, 0); exist {
+		t.Errorf("a rejected write is recorded")
+	}
+
+	// the size grows by every record, and by the bytes of its value
+	size := m.ApproximateSize()
+	if size <= 0 {
+		t.Errorf("ApproximateSize() = %v, want positive", size)
+	}
+	_ = m.Put(1, 1, make([]byte, 1000))
+	_ = m.Delete(2, 1)
+	if grown := m.ApproximateSize() - size; grown != 2*(size-1)+1000 {
+		t.Errorf("ApproximateSize() grew %v by two records of 1000 bytes and 0 bytes, from %v", grown, size)
+	}
+
+	// a write during a flush stops it
+	it := m.NewFlushIter()
+	_ = m.Put(3, 0, nil)
+	if it.Next() || !errors.Is(it.Err(), ErrConcurrentModification) {
+		t.Errorf("Next() after a write = true, Err() = %v", it.Err())
+	}
+
+	var nilMemtable *Memtable[int, int]
+	if err := nilMemtable.Put(1, 1, 1); err != nil || nilMemtable.ApproximateSize() != 0 || nilMemtable.NewFlushIter().Valid() {
+		t.Errorf("nil Memtable is not empty")
+	}
+}
+
+func TestMemtable_Random(t *testing.T) {
+	type version struct {
+		seq uint64
+		val int
+		del bool
+	}
+	var (
+		rnd     = rand.New(rand.NewSource(1))
+		m       = NewMemtable[int, int](0, true)
+		history = make(map[int][]version)
+		seq     uint64
+	)
+	for i := 0; i < 5000; i++ {
+		seq += uint64(rnd.Intn(3)) + 1
+		key := rnd.Intn(50) - 25
+		if rnd.Intn(4) == 0 {
+			_ = m.Delete(seq, key)
+			history[key] = append(history[key], version{seq: seq, del: true})
+		} else {
+			_ = m.Put(seq, key, i)
+			history[key] = append(history[key], version{seq: seq, val: i})
+		}
+	}
+
+	for i := 0; i < 5000; i++ {
+		var (
+			key     = rnd.Intn(60) - 30
+			readSeq = uint64(rnd.Int63n(int64(seq) + 2))
+			want    *version
+		)
+		for j := range history[key] {
+			if history[key][j].seq <= readSeq {
+				want = &history[key][j]
+			}
+		}
+		val, kind, exist := m.Get(key, readSeq)
+		switch {
+		case want == nil:
+			if exist {
+				t.Fatalf("Get(%v, %v) = %v, %v, want none", key, readSeq, val, kind)
+			}
+		case !exist || (kind == RecordTombstone) != want.del || val != want.val:
+			t.Fatalf("Get(%v, %v) = %v, %v, %v, want %+v", key, readSeq, val, kind, exist, *want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package skip_list
+
+import "sync/atomic"
+
+type (
+	Metrics struct {
+		// number of calls to Get, and of the calls which put or delete nodes, such as Put and CompareAndSwap or
+		// Delete and DeleteRange. Calls changing nothing, as Delete of an absent key, are not counted.
+		Gets, Puts, Deletes uint64
+
+		Level, Cap int32
+	}
+)
+
+// Metrics returns the operation counters along with the current Level and Cap.
+func (sl *SkipList[O, T]) Metrics() Metrics {
+	if sl == nil {
+		return Metrics{}
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return Metrics{
+		Gets:    atomic.LoadUint64(&sl.gets),
+		Puts:    atomic.LoadUint64(&sl.puts),
+		Deletes: atomic.LoadUint64(&sl.deletes),
+		Level:   sl.level,
+		Cap:     sl.cap,
+	}
+}
+
+// count increments c, atomically if concurrent as Get only holds the read lock.
+func (sl *SkipList[O, T]) count(c *uint64) {
+	if sl.isConcurrent {
+		atomic.AddUint64(c, 1)
+		return
+	}
+	*c++
+}
+
+// countDeleted counts a deletion of n nodes if n is positive, and returns n.
+func (sl *SkipList[O, T]) countDeleted(n int) int {
+	if n > 0 {
+		sl.count(&sl.deletes)
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+package skip_list
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSkipList_Metrics(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		want         Metrics
+	}
+	tests := []testCase{
+		{
+			name:         "TestSkipList_Metrics 1",
+			isConcurrent: false,
+			want:         Metrics{Gets: 400, Puts: 200, Deletes: 50, Level: 1, Cap: 50},
+		},
+		{
+			name:         "TestSkipList_Metrics 2",
+			isConcurrent: true,
+			want:         Metrics{Gets: 400, Puts: 200, Deletes: 50, Level: 1, Cap: 50},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, tt.isConcurrent, WithLevelFunc(func(int) int { return 0 }))
+			for i := 0; i < 100; i++ {
+				sl.Put(i, i)
+				sl.Put(i, i+1)
+			}
+			// Deletes of the 100 absent keys are not counted
+			for i := 50; i < 200; i++ {
+				sl.Delete(i)
+			}
+
+			// Get holds the read lock only, so concurrent readers share the counters
+			var wg sync.WaitGroup
+			for g := 0; g < 4; g++ {
+				wg.Add(1)
+				get := func() {
+					defer wg.Done()
+					for i := 0; i < 100; i++ {
+						sl.Get(i)
+					}
+				}
+				if tt.isConcurrent {
+					go get()
+				} else {
+					get()
+				}
+			}
+			wg.Wait()
+
+			if got := sl.Metrics(); got != tt.want {
+				t.Errorf("Metrics() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Metrics_Conditional(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipList_Metrics_Conditional 1"},
+		{name: "TestSkipList_Metrics_Conditional 2", opts: []Option{WithTombstones()}},
+	}
+	eq := func(a, b int) bool { return a == b }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, true, tt.opts...)
+			for i := 0; i < 10; i++ {
+				sl.Put(i, i)
+			}
+			assert := func(op string, puts, deletes uint64) {
+				t.Helper()
+				if got := sl.Metrics(); got.Puts != puts || got.Deletes != deletes {
+					t.Errorf("after %v Puts, Deletes = %v, %v, want %v, %v", op, got.Puts, got.Deletes, puts, deletes)
+				}
+			}
+
+			sl.CompareAndSwap(0, 0, 100, eq)
+			assert("a successful CompareAndSwap", 11, 0)
+			sl.CompareAndSwap(0, 0, 200, eq)
+			sl.CompareAndSwap(-1, 0, 200, eq)
+			assert("failed CompareAndSwaps", 11, 0)
+
+			sl.CompareAndDelete(1, 1, eq)
+			assert("a successful CompareAndDelete", 11, 1)
+			sl.CompareAndDelete(2, 0, eq)
+			sl.CompareAndDelete(1, 1, eq)
+			assert("failed CompareAndDeletes", 11, 1)
+
+			it := sl.Seek(5)
+			sl.DeleteAt(it)
+			assert("a successful DeleteAt", 11, 2)
+			sl.DeleteAt(sl.Seek(100))
+			sl.DeleteAt(NewSkipList[int, int](0, false).Iterator())
+			stale := sl.Seek(6)
+			sl.Put(100, 100)
+			if !sl.tombstones {
+				// stopped by the Put
+				sl.DeleteAt(stale)
+			}
+			assert("failed DeleteAts", 12, 2)
+
+			// every delete path counts once per call which deletes nodes
+			sl.DeleteRange(50, 60)
+			sl.PopRange(50, 60)
+			sl.DeleteKeys([]int{-1})
+			sl.DeleteIf(func(key, _ int) bool { return key < 0 })
+			assert("deletes of nothing", 12, 2)
+			sl.DeleteRange(2, 3)
+			sl.DeleteIf(func(key, _ int) bool { return key == 4 })
+			sl.PopMin()
+			sl.PopMax()
+			assert("deletes", 12, 6)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// SkipMultiMap is an ordered map whose Put always inserts, keeping the values of equal keys in insertion order.
+// It is a SkipList of the values of each key, so the options typed by the value, such as WithHooks, are of []T.
+type SkipMultiMap[O constraints.Ordered, T any] struct {
+	sl *SkipList[O, []T]
+
+	// the number of entries, guarded by the lock of sl
+	len int
+}
+
+// NewSkipMultiMap returns a SkipMultiMap of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewSkipMultiMap[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *SkipMultiMap[O, T] {
+	sl := NewSkipList[O, []T](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &SkipMultiMap[O, T]{sl: sl}
+}
+
+// Put inserts an entry of key and val after the entries of equal keys.
+func (m *SkipMultiMap[O, T]) Put(key O, val T) {
+	if m == nil {
+		return
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	var vals []T
+	if n := sl.get(key); n != nil && !n.deleted {
+		vals = n.val
+	}
+	// appending never overwrites the values of a previous slice, which may be kept by ReadViews
+	sl.put(nil, key, append(vals, val))
+	m.len++
+}
+
+// Get returns the value of the first entry of key in insertion order.
+func (m *SkipMultiMap[O, T]) Get(key O) (val T, exist bool) {
+	if m == nil {
+		return
+	}
+
+	if vals, ok := m.sl.Get(key); ok {
+		return vals[0], true
+	}
+	return
+}
+
+// GetAll returns the values of all entries of key in insertion order.
+func (m *SkipMultiMap[O, T]) GetAll(key O) []T {
+	if m == nil {
+		return nil
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	if n := sl.get(key); n != nil && !n.deleted {
+		return append([]T(nil), n.val...)
+	}
+	return nil
+}
+
+// Delete deletes the first entry of key in insertion order, as a FIFO of equal keys, and returns whether it exists.
+func (m *SkipMultiMap[O, T]) Delete(key O) bool {
+	if m == nil {
+		return false
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	n := sl.get(key)
+	if n == nil || n.deleted {
+		return false
+	}
+	if len(n.val) == 1 {
+		sl.delete(key, nil)
+	} else {
+		sl.put(nil, key, n.val[1:])
+	}
+	sl.count(&sl.deletes)
+	m.len--
+	return true
+}
+
+// DeleteAll deletes all entries of key, and returns the number of them.
+func (m *SkipMultiMap[O, T]) DeleteAll(key O) int {
+	if m == nil {
+		return 0
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	n := sl.get(key)
+	if n == nil || n.deleted {
+		return 0
+	}
+	deleted := len(n.val)
+	sl.delete(key, nil)
+	sl.count(&sl.deletes)
+	m.len -= deleted
+	return deleted
+}
+
+// Range returns the *KvPair of every entry of key in [start, end] in key order, and in insertion order among equal keys.
+func (m *SkipMultiMap[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if m == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	m.sl.AscendRange(start, end, func(key O, vals []T) bool {
+		for _, val := range vals {
+			res = append(res, newKvPair(key, val))
+		}
+		return true
+	})
+	return res
+}
+
+// Len returns the number of entries, counting each of equal keys.
+func (m *SkipMultiMap[O, T]) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	sl := m.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return m.len
+}
+
+// DistinctKeys returns the number of distinct keys.
+func (m *SkipMultiMap[O, T]) DistinctKeys() int {
+	if m == nil {
+		return 0
+	}
+
+	return int(m.sl.Cap())
+}
@@ -0,0 +1,151 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSkipMultiMap(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipMultiMap 1", isConcurrent: false},
+		{name: "TestSkipMultiMap 2", isConcurrent: true},
+		{name: "TestSkipMultiMap 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd     = rand.New(rand.NewSource(1))
+				m       = NewSkipMultiMap[int, int](0, tt.isConcurrent, tt.opts...)
+				ref     = make(map[int][]int)
+				entries int
+			)
+			for i := 0; i < 20000; i++ {
+				key := rnd.Intn(100)
+				switch rnd.Intn(10) {
+				case 0:
+					if got := m.Delete(key); got != (len(ref[key]) > 0) {
+						t.Fatalf("Delete(%v) = %v, want %v", key, got, !got)
+					}
+					if vals := ref[key]; len(vals) > 0 {
+						ref[key] = vals[1:]
+						entries--
+					}
+				case 1:
+					if got := m.DeleteAll(key); got != len(ref[key]) {
+						t.Fatalf("DeleteAll(%v) = %v, want %v", key, got, len(ref[key]))
+					}
+					entries -= len(ref[key])
+					delete(ref, key)
+				default:
+					// i is the insertion order
+					m.Put(key, i)
+					ref[key] = append(ref[key], i)
+					entries++
+				}
+			}
+
+			if m.Len() != entries {
+				t.Errorf("Len() = %v, want %v", m.Len(), entries)
+			}
+			var (
+				keys []int
+				want []*KvPair[int, int]
+			)
+			for key, vals := range ref {
+				if len(vals) > 0 {
+					keys = append(keys, key)
+				}
+			}
+			sort.Ints(keys)
+			if m.DistinctKeys() != len(keys) {
+				t.Errorf("DistinctKeys() = %v, want %v", m.DistinctKeys(), len(keys))
+			}
+			for _, key := range keys {
+				for _, val := range ref[key] {
+					want = append(want, NewKvPair(key, val))
+				}
+			}
+			if got := m.Range(-1, 100); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Range() = %v, want %v", got, want)
+			}
+
+			for key := -1; key <= 100; key++ {
+				vals := ref[key]
+				if got := m.GetAll(key); len(got) != len(vals) || (len(vals) > 0 && !reflect.DeepEqual(got, vals)) {
+					t.Fatalf("GetAll(%v) = %v, want %v", key, got, vals)
+				}
+				if val, ok := m.Get(key); ok != (len(vals) > 0) || (ok && val != vals[0]) {
+					t.Fatalf("Get(%v) = %v, %v, want the first of %v", key, val, ok, vals)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipMultiMap_Duplicates(t *testing.T) {
+	m := NewSkipMultiMap[string, string](10, false)
+	for _, kv := range [][2]string{{"b", "b1"}, {"a", "a1"}, {"b", "b2"}, {"c", "c1"}, {"b", "b3"}, {"a", "a2"}} {
+		m.Put(kv[0], kv[1])
+	}
+
+	if got, want := m.Range("a", "c"), []*KvPair[string, string]{{"a", "a1"}, {"a", "a2"}, {"b", "b1"}, {"b", "b2"}, {"b", "b3"}, {"c", "c1"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+	if m.Len() != 6 || m.DistinctKeys() != 3 {
+		t.Errorf("Len() = %v, DistinctKeys() = %v, want 6, 3", m.Len(), m.DistinctKeys())
+	}
+
+	// GetAll returns a copy
+	vals := m.GetAll("b")
+	vals[0] = "x"
+	if val, _ := m.Get("b"); val != "b1" {
+		t.Errorf("Get() = %v after modifying GetAll(), want b1", val)
+	}
+
+	// Delete deletes the first inserted
+	if !m.Delete("b") {
+		t.Errorf("Delete() = false")
+	}
+	if got, want := m.GetAll("b"), []string{"b2", "b3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAll() = %v after Delete(), want %v", got, want)
+	}
+	m.Put("b", "b4")
+	if got, want := m.GetAll("b"), []string{"b2", "b3", "b4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAll() = %v after Put(), want %v", got, want)
+	}
+
+	// a ReadView keeps the values before Put
+	v := m.sl.Snapshot()
+	m.Put("b", "b5")
+	m.Delete("b")
+	if got, ok := v.Get("b"); !ok || !reflect.DeepEqual(got, []string{"b2", "b3", "b4"}) {
+		t.Errorf("ReadView.Get() = %v, %v, want [b2 b3 b4]", got, ok)
+	}
+	v.Close()
+
+	if got := m.DeleteAll("b"); got != 3 {
+		t.Errorf("DeleteAll() = %v, want 3", got)
+	}
+	if m.Delete("b") || m.DeleteAll("b") != 0 || m.GetAll("b") != nil {
+		t.Errorf("entries of b exist after DeleteAll()")
+	}
+	if m.Len() != 3 || m.DistinctKeys() != 2 {
+		t.Errorf("Len() = %v, DistinctKeys() = %v, want 3, 2", m.Len(), m.DistinctKeys())
+	}
+
+	var nilMap *SkipMultiMap[int, int]
+	nilMap.Put(1, 1)
+	if _, ok := nilMap.Get(1); ok || nilMap.Len() != 0 || nilMap.Delete(1) || nilMap.Range(0, 1) != nil {
+		t.Errorf("nil SkipMultiMap is not empty")
+	}
+	if m := NewSkipMultiMap[int, int](-1, false); m != nil {
+		t.Errorf("NewSkipMultiMap() = %v, want nil", m)
+	}
+}
@@ -0,0 +1,161 @@
+package skip_list
+
+import (
+	"errors"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrNegativeCount = errors.New("skip_list: count would be negative")
+
+// SkipMultiSet is an ordered multiset, which is a SkipList of the positive count of each key.
+// Counts saturate at math.MaxInt instead of overflowing, and so does Total.
+type SkipMultiSet[O constraints.Ordered] struct {
+	sl *SkipList[O, int]
+
+	// the saturating sum of counts, guarded by the lock of sl
+	total int
+}
+
+// NewSkipMultiSet returns a SkipMultiSet of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewSkipMultiSet[O constraints.Ordered](maxLevel int32, isConcurrent bool, opts ...Option) *SkipMultiSet[O] {
+	sl := NewSkipList[O, int](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &SkipMultiSet[O]{sl: sl}
+}
+
+// Add adds delta to the count of key, deleting key once the count is 0, and returns the new count.
+// It returns ErrNegativeCount with the count unchanged if the count would be negative.
+func (s *SkipMultiSet[O]) Add(key O, delta int) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	count, err := s.add(key, delta)
+	switch {
+	case err != nil || delta == 0:
+	case count == 0:
+		sl.count(&sl.deletes)
+	default:
+		sl.count(&sl.puts)
+	}
+	return count, err
+}
+
+func (s *SkipMultiSet[O]) add(key O, delta int) (int, error) {
+	var count int
+	if n := s.sl.get(key); n != nil && !n.deleted {
+		count = n.val
+	}
+	if delta < 0 && count+delta < 0 {
+		return count, ErrNegativeCount
+	}
+	if delta == 0 {
+		return count, nil
+	}
+
+	newCount := saturatingAdd(count, delta)
+	if newCount == 0 {
+		s.sl.delete(key, nil)
+	} else {
+		s.sl.put(nil, key, newCount)
+	}
+
+	if delta := newCount - count; delta < 0 && s.total == math.MaxInt {
+		// the saturated total is recounted
+		s.total = 0
+		for n := s.sl.head.next(); n != nil; n = n.next() {
+			s.total = saturatingAdd(s.total, n.val)
+		}
+	} else {
+		s.total = saturatingAdd(s.total, delta)
+	}
+	return newCount, nil
+}
+
+// Count returns the count of key, which is 0 if absent.
+func (s *SkipMultiSet[O]) Count(key O) int {
+	if s == nil {
+		return 0
+	}
+
+	count, _ := s.sl.Get(key)
+	return count
+}
+
+// Total returns the sum of the counts of all keys.
+func (s *SkipMultiSet[O]) Total() int {
+	if s == nil {
+		return 0
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return s.total
+}
+
+// Len returns the number of distinct keys.
+func (s *SkipMultiSet[O]) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return int(s.sl.Cap())
+}
+
+// Range returns the keys in [start, end] with their counts as the values of *KvPair in ascending order.
+func (s *SkipMultiSet[O]) Range(start, end O) []*KvPair[O, int] {
+	if s == nil {
+		return nil
+	}
+
+	return s.sl.Range(start, end)
+}
+
+// Merge adds the counts of other to the counts of s. The counts of other are copied first,
+// so that no two locks are held at once.
+func (s *SkipMultiSet[O]) Merge(other *SkipMultiSet[O]) {
+	if s == nil || other == nil {
+		return
+	}
+
+	pairs := other.sl.Items()
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	if len(pairs) > 0 {
+		sl.count(&sl.puts)
+	}
+
+	for _, kv := range pairs {
+		// counts are positive
+		_, _ = s.add(kv.key, kv.val)
+	}
+}
+
+// saturatingAdd returns a + b clamped to [math.MinInt, math.MaxInt].
+func saturatingAdd(a, b int) int {
+	switch {
+	case b > 0 && a > math.MaxInt-b:
+		return math.MaxInt
+	case b < 0 && a < math.MinInt-b:
+		return math.MinInt
+	}
+	return a + b
+}
@@ -0,0 +1,163 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSkipMultiSet(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipMultiSet 1", isConcurrent: false},
+		{name: "TestSkipMultiSet 2", isConcurrent: true},
+		{name: "TestSkipMultiSet 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				s   = NewSkipMultiSet[int](0, tt.isConcurrent, tt.opts...)
+				ref = make(map[int]int)
+			)
+			for i := 0; i < 20000; i++ {
+				key, delta := rnd.Intn(200), rnd.Intn(11)-5
+				got, err := s.Add(key, delta)
+				if ref[key]+delta < 0 {
+					if err != ErrNegativeCount || got != ref[key] {
+						t.Fatalf("Add(%v, %v) = %v, %v, want %v, %v", key, delta, got, err, ref[key], ErrNegativeCount)
+					}
+					continue
+				}
+				ref[key] += delta
+				if ref[key] == 0 {
+					delete(ref, key)
+				}
+				if err != nil || got != ref[key] {
+					t.Fatalf("Add(%v, %v) = %v, %v, want %v, nil", key, delta, got, err, ref[key])
+				}
+			}
+
+			testSkipMultiSetEqual(t, s, ref)
+			if err := s.sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	var nilSet *SkipMultiSet[int]
+	if got, err := nilSet.Add(1, 1); got != 0 || err != nil || nilSet.Count(1) != 0 || nilSet.Total() != 0 || nilSet.Len() != 0 {
+		t.Errorf("nil SkipMultiSet is not empty")
+	}
+	nilSet.Merge(NewSkipMultiSet[int](10, false))
+	if s := NewSkipMultiSet[int](-1, false); s != nil {
+		t.Errorf("NewSkipMultiSet() = %v, want nil", s)
+	}
+}
+
+func TestSkipMultiSet_Saturation(t *testing.T) {
+	s := NewSkipMultiSet[string](10, false)
+	if got, _ := s.Add("a", math.MaxInt-1); got != math.MaxInt-1 {
+		t.Errorf("Add() = %v, want %v", got, math.MaxInt-1)
+	}
+	if got, _ := s.Add("a", 10); got != math.MaxInt {
+		t.Errorf("Add() = %v, want saturated %v", got, math.MaxInt)
+	}
+	s.Add("b", 3)
+	if s.Total() != math.MaxInt {
+		t.Errorf("Total() = %v, want saturated %v", s.Total(), math.MaxInt)
+	}
+
+	// the total is recounted once it drops below saturation
+	if got, _ := s.Add("a", -math.MaxInt+5); got != 5 {
+		t.Errorf("Add() = %v, want 5", got)
+	}
+	if s.Total() != 8 {
+		t.Errorf("Total() = %v, want 8", s.Total())
+	}
+	if got, err := s.Add("a", math.MinInt); err != ErrNegativeCount || got != 5 {
+		t.Errorf("Add(MinInt) = %v, %v, want 5, %v", got, err, ErrNegativeCount)
+	}
+	if got, err := s.Add("c", -1); err != ErrNegativeCount || got != 0 || s.Len() != 2 {
+		t.Errorf("Add() of an absent key = %v, %v, want 0, %v", got, err, ErrNegativeCount)
+	}
+	if got, err := s.Add("c", 0); err != nil || got != 0 || s.Len() != 2 {
+		t.Errorf("Add(0) of an absent key = %v, %v, want 0 without inserting it", got, err)
+	}
+	if got, _ := s.Add("b", -3); got != 0 || s.Count("b") != 0 || s.Len() != 1 || s.Total() != 5 {
+		t.Errorf("Add() to 0 = %v, Len() = %v, Total() = %v, want the key deleted", got, s.Len(), s.Total())
+	}
+}
+
+func TestSkipMultiSet_Merge(t *testing.T) {
+	var (
+		rnd        = rand.New(rand.NewSource(2))
+		a, b       = NewSkipMultiSet[int](0, true), NewSkipMultiSet[int](0, false)
+		refA, refB = make(map[int]int), make(map[int]int)
+	)
+	for i := 0; i < 1000; i++ {
+		key, delta := rnd.Intn(300), rnd.Intn(5)+1
+		a.Add(key, delta)
+		refA[key] += delta
+		key, delta = rnd.Intn(300), rnd.Intn(5)+1
+		b.Add(key, delta)
+		refB[key] += delta
+	}
+
+	a.Merge(b)
+	for key, count := range refB {
+		refA[key] += count
+	}
+	testSkipMultiSetEqual(t, a, refA)
+	testSkipMultiSetEqual(t, b, refB)
+
+	// merging itself doubles the counts
+	b.Merge(b)
+	for key := range refB {
+		refB[key] *= 2
+	}
+	testSkipMultiSetEqual(t, b, refB)
+
+	b.Merge(nil)
+	testSkipMultiSetEqual(t, b, refB)
+}
+
+// testSkipMultiSetEqual checks Count, Total, Len and Range of s against ref.
+func testSkipMultiSetEqual(t *testing.T, s *SkipMultiSet[int], ref map[int]int) {
+	t.Helper()
+
+	var (
+		total int
+		keys  []int
+		want  []*KvPair[int, int]
+	)
+	for key, count := range ref {
+		total += count
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	for _, key := range keys {
+		want = append(want, NewKvPair(key, ref[key]))
+	}
+
+	for key := -1; key <= 300; key++ {
+		if s.Count(key) != ref[key] {
+			t.Fatalf("Count(%v) = %v, want %v", key, s.Count(key), ref[key])
+		}
+	}
+	if s.Total() != total {
+		t.Errorf("Total() = %v, want %v", s.Total(), total)
+	}
+	if s.Len() != len(keys) {
+		t.Errorf("Len() = %v, want %v", s.Len(), len(keys))
+	}
+	if got := s.Range(-1, 300); len(want) > 0 && !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package skip_list
+
+// Version returns the version of the last write WithMVCC.
+func (sl *SkipList[O, T]) Version() uint64 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.version
+}
+
+// PutVersioned is Put returning the version of the write WithMVCC.
+func (sl *SkipList[O, T]) PutVersioned(key O, val T) uint64 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	sl.put(nil, key, val)
+	return sl.version
+}
+
+// DeleteVersioned is Delete returning the version of the write WithMVCC, and whether key is deleted.
+func (sl *SkipList[O, T]) DeleteVersioned(key O) (uint64, bool) {
+	if sl == nil {
+		return 0, false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	ok := sl.delete(key, nil)
+	if ok {
+		sl.count(&sl.deletes)
+	}
+	return sl.version, ok
+}
+
+// GetAt returns the value of key as of version WithMVCC, which must not be before the watermark of GC.
+func (sl *SkipList[O, T]) GetAt(key O, version uint64) (val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.lower(key).nextNodes[0]; n != nil && n.key == key {
+		return n.at(version)
+	}
+	return
+}
+
+// RangeAt searches the *KvPair of key in [start, end] as of version WithMVCC, which must not be before the watermark of GC.
+func (sl *SkipList[O, T]) RangeAt(start, end O, version uint64) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.lower(start).nextNodes[0]; n != nil && n.key <= end; n = n.nextNodes[0] {
+		if val, ok := n.at(version); ok {
+			res = append(res, newKvPair(n.key, val))
+		}
+	}
+	return res
+}
+
+// GC discards the history needed only by reads of version before the watermark, which never moves backwards,
+// and unlinks the tombstones no read needs. The history needed by open ReadViews is kept.
+func (sl *SkipList[O, T]) GC(watermark uint64) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if watermark > sl.version {
+		watermark = sl.version
+	}
+	if watermark > sl.watermark {
+		sl.watermark = watermark
+	}
+	sl.reclaimViews()
+}
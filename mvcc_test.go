@@ -0,0 +1,130 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+// historyLen returns the number of records in the history of sl.
+func historyLen[O constraints.Ordered, T any](sl *SkipList[O, T]) int {
+	var res int
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		for r := n.history; r != nil; r = r.next {
+			res++
+		}
+	}
+	return res
+}
+
+func TestWithMVCC(t *testing.T) {
+	var (
+		r  = rand.New(rand.NewSource(1))
+		sl = NewSkipList[int, int](0, false, WithMVCC())
+
+		// states[v] is the content as of version v
+		states = []map[int]int{{}}
+		cur    = map[int]int{}
+	)
+	snapshot := func(version uint64) {
+		state := make(map[int]int, len(cur))
+		for k, v := range cur {
+			state[k] = v
+		}
+		for uint64(len(states)) <= version {
+			states = append(states, state)
+		}
+	}
+	for i := 0; i < 3000; i++ {
+		key := r.Intn(200)
+		switch op := r.Intn(100); {
+		case op < 60:
+			cur[key] = i
+			snapshot(sl.PutVersioned(key, i))
+		case op < 99:
+			version, ok := sl.DeleteVersioned(key)
+			if _, want := cur[key]; ok != want {
+				t.Fatalf("DeleteVersioned(%v) = %v, want %v", key, ok, want)
+			}
+			delete(cur, key)
+			snapshot(version)
+		default:
+			// Clear deletes each key in order with a version of its own
+			var keys []int
+			for k := range cur {
+				keys = append(keys, k)
+			}
+			sort.Ints(keys)
+			version := sl.Version()
+			sl.Clear()
+			for _, k := range keys {
+				delete(cur, k)
+				version++
+				snapshot(version)
+			}
+			if got := sl.Version(); got != version {
+				t.Fatalf("Version() = %v after Clear(), want %v", got, version)
+			}
+		}
+	}
+	if got, want := sl.Version(), uint64(len(states)-1); got != want {
+		t.Fatalf("Version() = %v, want %v", got, want)
+	}
+
+	check := func(from uint64) {
+		for version := from; version < uint64(len(states)); version++ {
+			var want = make([]*KvPair[int, int], 0)
+			for k, v := range states[version] {
+				want = append(want, NewKvPair(k, v))
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i].Key() < want[j].Key() })
+			if got := sl.RangeAt(math.MinInt, math.MaxInt, version); !reflect.DeepEqual(got, want) {
+				t.Fatalf("RangeAt(%v) = %v, want %v", version, got, want)
+			}
+			for key := 0; key < 200; key += 7 {
+				want, wantOk := states[version][key]
+				if got, ok := sl.GetAt(key, version); got != want || ok != wantOk {
+					t.Fatalf("GetAt(%v, %v) = %v, %v, want %v, %v", key, version, got, ok, want, wantOk)
+				}
+			}
+		}
+	}
+	check(0)
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// GC keeps the reads after the watermark
+	before := historyLen(sl)
+	sl.GC(2000)
+	if after := historyLen(sl); after >= before {
+		t.Errorf("history = %v after GC(2000), want less than %v", after, before)
+	}
+	check(2000)
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// the watermark never moves backwards
+	sl.GC(100)
+	check(2000)
+
+	// GC of all
+	v := sl.Snapshot()
+	sl.GC(sl.Version())
+	if got := historyLen(sl); got != 0 {
+		t.Errorf("history = %v after GC(Version()), want 0", got)
+	}
+	if got := sl.Tombstones(); got != 0 {
+		t.Errorf("Tombstones() = %v after GC(Version()), want 0", got)
+	}
+	check(sl.Version())
+	v.Close()
+	if got := sl.Items(); len(got) != len(cur) {
+		t.Errorf("Items() = %v, want %v", got, cur)
+	}
+}
@@ -0,0 +1,49 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// Nearest returns *KvPair of the key closest to target, which is the lower one if the floor and the ceil of target
+// are equally close. Both are found by one descent, and the distances of integers are computed without overflow.
+func Nearest[O constraints.Integer | constraints.Float, T any](sl *SkipList[O, T], target O) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < target {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		// search down
+	}
+
+	var (
+		floor = sl.liveFloor(move)
+		ceil  = move.nextNodes[0].live()
+	)
+	switch {
+	case ceil != nil && (floor == sl.head || ceil.key == target || ceilCloser(floor.key, target, ceil.key)):
+		return newKvPair(ceil.key, ceil.val), true
+	case floor != sl.head:
+		return newKvPair(floor.key, floor.val), true
+	}
+	return nil, false
+}
+
+// ceilCloser returns whether ceil is closer to target than floor, computing the distances of integers in uint64
+// modulo 2^64, which is exact and so does not overflow.
+func ceilCloser[O constraints.Integer | constraints.Float](floor, target, ceil O) bool {
+	if O(1)/2 != 0 {
+		// float
+		return ceil-target < target-floor
+	}
+	return uint64(ceil)-uint64(target) < uint64(target)-uint64(floor)
+}
@@ -0,0 +1,121 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNearest(t *testing.T) {
+	type testCase struct {
+		name    string
+		keys    []int
+		deleted []int
+		target  int
+		want    int
+		exist   bool
+	}
+	tests := []testCase{
+		{name: "TestNearest 1", keys: []int{10, 20, 30}, target: 5, want: 10, exist: true},
+		{name: "TestNearest 2", keys: []int{10, 20, 30}, target: 40, want: 30, exist: true},
+		{name: "TestNearest 3", keys: []int{10, 20, 30}, target: 20, want: 20, exist: true},
+		// equidistant prefers the lower key
+		{name: "TestNearest 4", keys: []int{10, 20, 30}, target: 15, want: 10, exist: true},
+		{name: "TestNearest 5", keys: []int{10, 20, 30}, target: 16, want: 20, exist: true},
+		{name: "TestNearest 6", keys: []int{10, 20, 30}, target: 14, want: 10, exist: true},
+		{name: "TestNearest 7", keys: []int{10, 20, 30}, deleted: []int{20}, target: 19, want: 10, exist: true},
+		{name: "TestNearest 8", keys: []int{10, 20, 30}, deleted: []int{10, 20}, target: 0, want: 30, exist: true},
+		{name: "TestNearest 9", keys: nil, target: 0, exist: false},
+		{name: "TestNearest 10", keys: []int{10}, deleted: []int{10}, target: 10, exist: false},
+		{name: "TestNearest 11", keys: []int{math.MinInt, math.MaxInt}, target: 0, want: math.MaxInt, exist: true},
+		{name: "TestNearest 12", keys: []int{math.MinInt, math.MaxInt}, target: -1, want: math.MinInt, exist: true},
+	}
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {WithTombstones()}} {
+			t.Run(tt.name, func(t *testing.T) {
+				sl := NewSkipList[int, int](0, true, opts...)
+				for _, key := range tt.keys {
+					sl.Put(key, -key)
+				}
+				for _, key := range tt.deleted {
+					sl.Delete(key)
+				}
+
+				kv, exist := Nearest(sl, tt.target)
+				if exist != tt.exist {
+					t.Fatalf("Nearest(%v) exist = %v, want %v", tt.target, exist, tt.exist)
+				}
+				if exist && (kv.Key() != tt.want || kv.Val() != -tt.want) {
+					t.Errorf("Nearest(%v) = %v, want %v", tt.target, kv, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestNearest_Unsigned(t *testing.T) {
+	sl := NewSkipList[uint64, int](10, false)
+	sl.Put(0, 0)
+	sl.Put(math.MaxUint64, 1)
+	if kv, _ := Nearest(sl, math.MaxUint64/2); kv.Key() != 0 {
+		t.Errorf("Nearest(MaxUint64/2) = %v, want 0", kv)
+	}
+	if kv, _ := Nearest(sl, math.MaxUint64/2+1); kv.Key() != math.MaxUint64 {
+		t.Errorf("Nearest(MaxUint64/2+1) = %v, want MaxUint64", kv)
+	}
+
+	small := NewSkipList[uint8, int](10, false)
+	small.Put(0, 0)
+	small.Put(255, 1)
+	for target, want := range map[uint8]uint8{0: 0, 127: 0, 128: 255, 255: 255} {
+		if kv, _ := Nearest(small, target); kv.Key() != want {
+			t.Errorf("Nearest(%v) = %v, want %v", target, kv, want)
+		}
+	}
+
+	floats := NewSkipList[float64, int](10, false)
+	floats.Put(-1.5, 0)
+	floats.Put(2.5, 1)
+	if kv, _ := Nearest(floats, 0.5); kv.Key() != -1.5 {
+		t.Errorf("Nearest(0.5) = %v, want -1.5", kv)
+	}
+	if kv, _ := Nearest(floats, math.Inf(1)); kv.Key() != 2.5 {
+		t.Errorf("Nearest(+Inf) = %v, want 2.5", kv)
+	}
+
+	var nilList *SkipList[int, int]
+	if _, exist := Nearest(nilList, 0); exist {
+		t.Errorf("Nearest() of a nil SkipList exists")
+	}
+}
+
+func TestNearest_Random(t *testing.T) {
+	var (
+		rnd = rand.New(rand.NewSource(1))
+		sl  = NewSkipList[int, int](0, false)
+	)
+	for i := 0; i < 1000; i++ {
+		sl.Put(rnd.Intn(100000), i)
+	}
+	items := sl.Items()
+
+	for i := 0; i < 1000; i++ {
+		target := rnd.Intn(110000) - 5000
+		var want = items[0]
+		for _, kv := range items {
+			if d, best := abs(kv.Key()-target), abs(want.Key()-target); d < best {
+				want = kv
+			}
+		}
+		if kv, _ := Nearest(sl, target); kv.Key() != want.Key() {
+			t.Fatalf("Nearest(%v) = %v, want %v", target, kv, want)
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
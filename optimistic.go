@@ -0,0 +1,321 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// optimisticList is the lazy SkipList of Herlihy, Lev, Luchangco and Shavit backing a ConcurrentSkipList WithOptimisticLocking.
+	// Readers traverse without locks. A writer locks the predecessors of its key on each level, validates that they
+	// are not marked and still link to the successors it found, and retries otherwise. A node is inserted once
+	// fullyLinked is set, and deleted once marked is set under its own lock.
+	optimisticList[O constraints.Ordered, T any] struct {
+		// the highest level in use, which never decreases
+		level, maxLevel int32
+
+		// number of nodes inserted and not deleted
+		cap int32
+
+		// probability of promoting a node to the next level
+		p float64
+
+		head *optNode[O, T]
+	}
+
+	optNode[O constraints.Ordered, T any] struct {
+		key       O
+		val       atomic.Pointer[T]
+		nextNodes []atomic.Pointer[optNode[O, T]]
+
+		// guards the links of the node and marked
+		sync.Mutex
+		marked, fullyLinked atomic.Bool
+	}
+)
+
+// newOptimisticList returns an optimisticList whose nodes have at most maxLevel+1 levels,
+// where maxLevel 0 is sized for the greatest Cap as it cannot be tuned without a global lock.
+func newOptimisticList[O constraints.Ordered, T any](maxLevel int32, p float64) *optimisticList[O, T] {
+	if maxLevel == 0 {
+		maxLevel = autoMaxLevelOf(math.MaxInt32)
+	}
+	return &optimisticList[O, T]{
+		level:    1,
+		maxLevel: maxLevel,
+		p:        p,
+		head:     &optNode[O, T]{nextNodes: make([]atomic.Pointer[optNode[O, T]], maxLevel+1)},
+	}
+}
+
+func (sl *optimisticList[O, T]) get(key O) (val T, exist bool) {
+	pred := sl.head
+	for l := atomic.LoadInt32(&sl.level) - 1; l >= 0; l-- {
+		curr := pred.nextNodes[l].Load()
+		for curr != nil && curr.key < key {
+			pred, curr = curr, curr.nextNodes[l].Load()
+		}
+		if curr != nil && curr.key == key {
+			if curr.live() {
+				return *curr.val.Load(), true
+			}
+			return
+		}
+	}
+	return
+}
+
+// put inserts key, or updates its value if overwrite, and returns the value of key after it and whether key existed.
+func (sl *optimisticList[O, T]) put(key O, val T, overwrite bool) (actual T, loaded bool) {
+	var (
+		h            = sl.randHeight()
+		preds, succs = sl.searchPath()
+	)
+	for level := atomic.LoadInt32(&sl.level); level < h; level = atomic.LoadInt32(&sl.level) {
+		if atomic.CompareAndSwapInt32(&sl.level, level, h) {
+			break
+		}
+	}
+
+	for {
+		if l := sl.find(key, preds, succs); l >= 0 {
+			n := succs[l]
+			if n.marked.Load() {
+				// wait for it to be unlinked
+				runtime.Gosched()
+				continue
+			}
+			for !n.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			if !overwrite {
+				return *n.val.Load(), true
+			}
+
+			// the lock orders the update with a Delete marking n
+			n.Lock()
+			if n.marked.Load() {
+				n.Unlock()
+				continue
+			}
+			old := *n.val.Load()
+			n.val.Store(&val)
+			n.Unlock()
+			return old, true
+		}
+
+		locked, valid := sl.lockPreds(preds, h, func(l int32, pred *optNode[O, T]) bool {
+			succ := succs[l]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.nextNodes[l].Load() == succ
+		})
+		if !valid {
+			sl.unlockPreds(preds, locked)
+			continue
+		}
+
+		newNode := &optNode[O, T]{key: key, nextNodes: make([]atomic.Pointer[optNode[O, T]], h)}
+		newNode.val.Store(&val)
+		for l := int32(0); l < h; l++ {
+			newNode.nextNodes[l].Store(succs[l])
+			preds[l].nextNodes[l].Store(newNode)
+		}
+		// linearization point
+		newNode.fullyLinked.Store(true)
+		atomic.AddInt32(&sl.cap, 1)
+		sl.unlockPreds(preds, locked)
+		return val, false
+	}
+}
+
+// delete deletes key if cond is nil or returns true for its value, and returns its value and whether it is deleted.
+func (sl *optimisticList[O, T]) delete(key O, cond func(val T) bool) (val T, exist bool) {
+	var (
+		preds, succs = sl.searchPath()
+		victim       *optNode[O, T]
+	)
+	for {
+		l := sl.find(key, preds, succs)
+		if victim == nil {
+			// only a node fully linked and found on its top level is complete
+			if l < 0 || !succs[l].live() || int(l) != len(succs[l].nextNodes)-1 {
+				return
+			}
+			victim = succs[l]
+
+			victim.Lock()
+			if victim.marked.Load() || (cond != nil && !cond(*victim.val.Load())) {
+				victim.Unlock()
+				return
+			}
+			// linearization point
+			victim.marked.Store(true)
+			atomic.AddInt32(&sl.cap, -1)
+			val = *victim.val.Load()
+		}
+
+		h := int32(len(victim.nextNodes))
+		locked, valid := sl.lockPreds(preds, h, func(l int32, pred *optNode[O, T]) bool {
+			return !pred.marked.Load() && pred.nextNodes[l].Load() == victim
+		})
+		if !valid {
+			sl.unlockPreds(preds, locked)
+			continue
+		}
+
+		for l := h - 1; l >= 0; l-- {
+			preds[l].nextNodes[l].Store(victim.nextNodes[l].Load())
+		}
+		victim.Unlock()
+		sl.unlockPreds(preds, locked)
+		return val, true
+	}
+}
+
+// compareAndSwap sets the value of key to new if it exists and cond returns true for its value, and returns whether it is set.
+func (sl *optimisticList[O, T]) compareAndSwap(key O, cond func(val T) bool, new T) bool {
+	preds, succs := sl.searchPath()
+	l := sl.find(key, preds, succs)
+	if l < 0 || !succs[l].live() {
+		return false
+	}
+
+	// the lock orders the swap with a Delete marking n
+	n := succs[l]
+	n.Lock()
+	defer n.Unlock()
+
+	if n.marked.Load() || !cond(*n.val.Load()) {
+		return false
+	}
+	n.val.Store(&new)
+	return true
+}
+
+// lockPreds locks the distinct preds of the lowest h levels bottom up while valid returns true for them,
+// and returns the highest level locked and whether all are valid.
+func (sl *optimisticList[O, T]) lockPreds(preds []*optNode[O, T], h int32, valid func(l int32, pred *optNode[O, T]) bool) (int32, bool) {
+	var (
+		locked int32 = -1
+		prev   *optNode[O, T]
+	)
+	for l := int32(0); l < h; l++ {
+		if pred := preds[l]; pred != prev {
+			pred.Lock()
+			locked, prev = l, pred
+		}
+		if !valid(l, preds[l]) {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+// unlockPreds unlocks the distinct preds of levels up to locked.
+func (sl *optimisticList[O, T]) unlockPreds(preds []*optNode[O, T], locked int32) {
+	var prev *optNode[O, T]
+	for l := int32(0); l <= locked; l++ {
+		if pred := preds[l]; pred != prev {
+			pred.Unlock()
+			prev = pred
+		}
+	}
+}
+
+// find fills preds and succs with the predecessors and successors of key on every level in use without locks,
+// and returns the highest level key is found on, or -1 if not found.
+func (sl *optimisticList[O, T]) find(key O, preds, succs []*optNode[O, T]) int32 {
+	var (
+		found int32 = -1
+		pred        = sl.head
+	)
+	for l := atomic.LoadInt32(&sl.level) - 1; l >= 0; l-- {
+		curr := pred.nextNodes[l].Load()
+		for curr != nil && curr.key < key {
+			pred, curr = curr, curr.nextNodes[l].Load()
+		}
+		if found < 0 && curr != nil && curr.key == key {
+			found = l
+		}
+		preds[l], succs[l] = pred, curr
+	}
+	return found
+}
+
+// ceil returns the first live node of key greater than or equal to target.
+func (sl *optimisticList[O, T]) ceil(target O) *optNode[O, T] {
+	preds, succs := sl.searchPath()
+	sl.find(target, preds, succs)
+	n := succs[0]
+	for n != nil && !n.live() {
+		n = n.nextNodes[0].Load()
+	}
+	return n
+}
+
+// floor returns the last live node of key less than or equal to target.
+func (sl *optimisticList[O, T]) floor(target O) *optNode[O, T] {
+	preds, succs := sl.searchPath()
+	if l := sl.find(target, preds, succs); l >= 0 && succs[l].live() {
+		return succs[l]
+	}
+	// search again below a node being inserted or deleted
+	for n := preds[0]; n != sl.head; n = preds[0] {
+		if n.live() {
+			return n
+		}
+		sl.find(n.key, preds, succs)
+	}
+	return nil
+}
+
+// last returns the last live node.
+func (sl *optimisticList[O, T]) last() *optNode[O, T] {
+	n := sl.head
+	for l := atomic.LoadInt32(&sl.level) - 1; l >= 0; l-- {
+		for next := n.nextNodes[l].Load(); next != nil; next = n.nextNodes[l].Load() {
+			// search to the right
+			n = next
+		}
+
+		// search down
+	}
+	if n == sl.head {
+		return nil
+	}
+	if n.live() {
+		return n
+	}
+	return sl.floor(n.key)
+}
+
+// forEach calls fn for each live node from n in key order until fn returns false.
+func (sl *optimisticList[O, T]) forEach(n *optNode[O, T], fn func(key O, val T) bool) {
+	for ; n != nil; n = n.nextNodes[0].Load() {
+		if n.live() && !fn(n.key, *n.val.Load()) {
+			return
+		}
+	}
+}
+
+// live returns whether n is inserted and not deleted.
+func (n *optNode[O, T]) live() bool {
+	return n.fullyLinked.Load() && !n.marked.Load()
+}
+
+func (sl *optimisticList[O, T]) searchPath() (preds, succs []*optNode[O, T]) {
+	return make([]*optNode[O, T], sl.maxLevel+1), make([]*optNode[O, T], sl.maxLevel+1)
+}
+
+// randHeight returns the number of levels of a new node by coin flips of the global rand source, which is lock-free.
+func (sl *optimisticList[O, T]) randHeight() int32 {
+	var h int32 = 1
+	for h <= sl.maxLevel && rand.Float64() < sl.p {
+		h++
+	}
+	return h
+}
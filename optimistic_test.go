@@ -0,0 +1,199 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// validate checks that no node is locked, marked or partially linked once writes are quiescent, that every level
+// is ascending and a subsequence of level 0, and that cap is the number of nodes.
+func (sl *optimisticList[O, T]) validate() error {
+	var cap int32
+	for n := sl.head.nextNodes[0].Load(); n != nil; n = n.nextNodes[0].Load() {
+		if !n.TryLock() {
+			return fmt.Errorf("node %v is locked", n.key)
+		}
+		n.Unlock()
+		if !n.live() {
+			return fmt.Errorf("node %v is not live", n.key)
+		}
+		cap++
+	}
+	if cap != sl.cap {
+		return fmt.Errorf("%d nodes, want cap %d", cap, sl.cap)
+	}
+
+	for l := sl.level - 1; l >= 0; l-- {
+		var lower = sl.head
+		for n := sl.head.nextNodes[l].Load(); n != nil; n = n.nextNodes[l].Load() {
+			if next := n.nextNodes[l].Load(); next != nil && next.key <= n.key {
+				return fmt.Errorf("level %d is not ascending at %v", l, n.key)
+			}
+			for lower != nil && lower != n {
+				lower = lower.nextNodes[0].Load()
+			}
+			if lower == nil {
+				return fmt.Errorf("node %v on level %d is not on level 0", n.key, l)
+			}
+		}
+	}
+	return nil
+}
+
+func TestWithOptimisticLocking_AdjacentKeys(t *testing.T) {
+	const (
+		writers = 16
+		readers = 4
+		ops     = 3000
+	)
+
+	var (
+		c    = NewConcurrent[int, int](0, WithOptimisticLocking())
+		wg   sync.WaitGroup
+		done int32
+		// final state of the key owned by every writer
+		want = make([]bool, writers)
+	)
+	// writers own adjacent keys, whose writes contend on the same predecessors
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < ops; i++ {
+				if r.Intn(2) == 0 {
+					c.Put(w, w)
+					want[w] = true
+				} else {
+					c.Delete(w)
+					want[w] = false
+				}
+			}
+		}(w)
+	}
+	var readersWg sync.WaitGroup
+	for rd := 0; rd < readers; rd++ {
+		readersWg.Add(1)
+		go func(seed int64) {
+			defer readersWg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for atomic.LoadInt32(&done) == 0 {
+				key := r.Intn(writers)
+				if val, ok := c.Get(key); ok && val != key {
+					t.Errorf("Get(%v) = %v", key, val)
+				}
+				if kv, ok := c.Floor(key); ok && (kv.key > key || kv.val != kv.key) {
+					t.Errorf("Floor(%v) = %v", key, kv)
+				}
+				if kv, ok := c.Ceil(key); ok && (kv.key < key || kv.val != kv.key) {
+					t.Errorf("Ceil(%v) = %v", key, kv)
+				}
+				kvs := c.Items()
+				for j := 1; j < len(kvs); j++ {
+					if kvs[j-1].key >= kvs[j].key {
+						t.Errorf("Items() = %v is not ascending", kvs)
+						break
+					}
+				}
+			}
+		}(int64(rd))
+	}
+	wg.Wait()
+	atomic.StoreInt32(&done, 1)
+	readersWg.Wait()
+
+	for key, exist := range want {
+		if got := c.Contains(key); got != exist {
+			t.Errorf("Contains(%v) = %v, want %v", key, got, exist)
+		}
+	}
+	if err := c.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
+
+func TestWithOptimisticLocking_NoLostUpdate(t *testing.T) {
+	const (
+		workers = 16
+		ops     = 1000
+	)
+
+	var (
+		c     = NewConcurrent[int, int](0, WithOptimisticLocking())
+		wg    sync.WaitGroup
+		takes int64
+	)
+	// a token passed by LoadAndDelete and GetOrPut counts the successful takes
+	c.Put(0, 0)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				// neighbors churn around the token
+				c.Put(-1, i)
+				c.Put(1, i)
+				c.Delete(-1)
+				if val, ok := c.LoadAndDelete(0); ok {
+					atomic.AddInt64(&takes, 1)
+					if _, loaded := c.GetOrPut(0, val+1); loaded {
+						t.Errorf("GetOrPut() loaded = true while holding the token")
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := c.Get(0); int64(got) != takes {
+		t.Errorf("Get(0) = %v, want %v", got, takes)
+	}
+	if got, want := c.Items(), []*KvPair[int, int]{{0, int(takes)}, {1, ops - 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if err := c.validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
+
+func BenchmarkWithOptimisticLocking(b *testing.B) {
+	const keys = 1 << 16
+
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		for _, goroutines := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("optimistic=%v/goroutines=%d", len(opts) > 0, goroutines), func(b *testing.B) {
+				c := NewConcurrent[int, int](0, opts...)
+				for i := 0; i < keys; i += 2 {
+					c.Put(i, i)
+				}
+
+				var wg sync.WaitGroup
+				b.ResetTimer()
+				for g := 0; g < goroutines; g++ {
+					wg.Add(1)
+					go func(seed int64) {
+						defer wg.Done()
+						r := rand.New(rand.NewSource(seed))
+						// 80% Get, 10% Put and 10% Delete
+						for i := 0; i < b.N/goroutines; i++ {
+							key := r.Intn(keys)
+							switch op := r.Intn(10); {
+							case op == 0:
+								c.Put(key, i)
+							case op == 1:
+								c.Delete(key)
+							default:
+								c.Get(key)
+							}
+						}
+					}(int64(g))
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
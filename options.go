@@ -0,0 +1,223 @@
+package skip_list
+
+import (
+	"math/rand"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// Option configures a SkipList at construction.
+	Option func(o *options)
+
+	options struct {
+		// probability of promoting a node to the next level
+		p float64
+
+		// source of the coin flips, time seeded if nil
+		randSource rand.Source
+
+		// replaces the coin flips if not nil
+		levelFunc func(n int) int
+
+		// recycle deleted nodes
+		nodePool bool
+
+		// carve nodes out of chunked backing slices
+		arena bool
+
+		// mark deleted nodes instead of unlinking them
+		tombstones bool
+
+		// Hooks[O, T] asserted at construction
+		hooks any
+
+		// start searches from the previous access path
+		finger bool
+
+		// keep the nodes under open Iterators
+		safeIterators bool
+
+		// keep the history of every write
+		mvcc bool
+
+		// lock the predecessors of a write instead of the whole list
+		optimisticLocking bool
+
+		// number of keys copied per read lock by the scans of ConcurrentSkipList
+		rangeChunk int
+
+		// allocate the tower of head for every level up front
+		pregrow bool
+
+		// Codec[T] asserted at construction
+		codec any
+
+		// valueIndexFuncs[T] asserted at construction
+		valueIndex any
+
+		// Allocator[O, T] asserted at construction
+		allocator any
+	}
+)
+
+// WithProbability sets the probability of promoting a node to the next level, p must be in (0, 1).
+func WithProbability(p float64) Option {
+	return func(o *options) {
+		o.p = p
+	}
+}
+
+// WithRandSource sets the source of the coin flips which randomly generate level.
+func WithRandSource(src rand.Source) Option {
+	return func(o *options) {
+		o.randSource = src
+	}
+}
+
+// WithLevelFunc replaces the coin flips, fn receives the number of nodes and returns the level of the next node.
+// The level is clamped to [0, maxLevel].
+func WithLevelFunc(fn func(n int) int) Option {
+	return func(o *options) {
+		o.levelFunc = fn
+	}
+}
+
+// WithNodePool recycles deleted nodes through a sync.Pool to reduce the pressure of GC under heavy churn.
+// Key and value of a deleted node are zeroed before it is recycled,
+// so a pointer returned by GetRef must not be used once its key is deleted.
+func WithNodePool() Option {
+	return func(o *options) {
+		o.nodePool = true
+	}
+}
+
+// WithArena carves nodes and their towers out of chunked backing slices owned by SkipList,
+// which improves locality and reduces the objects scanned by GC, especially for a SkipList built by BulkLoad.
+// The memory of deleted nodes is not released until Clear or the whole SkipList is dropped, and WithNodePool is ignored.
+func WithArena() Option {
+	return func(o *options) {
+		o.arena = true
+	}
+}
+
+// WithAllocator allocates and frees the nodes by a, whose type parameters must match the SkipList, so that they can
+// be carved out of memory GC does not scan node by node. WithArena and WithNodePool are ignored.
+// Clear and ReplaceAll drop the nodes without Free, as they may still be read by open ReadViews.
+func WithAllocator[O constraints.Ordered, T any](a Allocator[O, T]) Option {
+	return func(o *options) {
+		if a != nil {
+			o.allocator = a
+		}
+	}
+}
+
+// WithTombstones makes Delete mark nodes as tombstones instead of unlinking them, so that writers do not touch
+// the links read by concurrent readers. Reads skip tombstones, Put revives them, and Compact unlinks them.
+func WithTombstones() Option {
+	return func(o *options) {
+		o.tombstones = true
+	}
+}
+
+// WithHooks sets the callbacks invoked after mutations, whose type parameters must match the SkipList.
+func WithHooks[O constraints.Ordered, T any](hooks Hooks[O, T]) Option {
+	return func(o *options) {
+		o.hooks = hooks
+	}
+}
+
+// WithValueIndex maintains an index of the keys of each value on every mutation, by which FindByValue and
+// ContainsValue look up the keys of a value instead of scanning the SkipList. Values with the same hash are told apart
+// by eq, and the type parameter must match the SkipList.
+func WithValueIndex[T any](hash func(T) uint64, eq func(a, b T) bool) Option {
+	return func(o *options) {
+		if hash != nil && eq != nil {
+			o.valueIndex = valueIndexFuncs[T]{hash: hash, eq: eq}
+		}
+	}
+}
+
+// WithFinger makes Get, Put and Delete start their searches from the access path of the previous one if its key is
+// less than the new key, which saves most of the descent for sequential and near-sequential keys.
+// It is ignored by a concurrent SkipList, whose readers share the lock.
+func WithFinger() Option {
+	return func(o *options) {
+		o.finger = true
+	}
+}
+
+// WithSafeIterators keeps Iterators returned by Iterator and Seek valid across deletions, until they are closed.
+// A deleted node is marked as a tombstone keeping its links when it is unlinked, so that an Iterator positioned at it
+// advances to the successor it had, skipping the tombstones after it. Deleted nodes are retired instead of being
+// recycled or zeroed while any Iterator is open, and reclaimed once the last one is closed.
+func WithSafeIterators() Option {
+	return func(o *options) {
+		o.safeIterators = true
+	}
+}
+
+// WithMVCC gives every write a new version and keeps the history of writes, so that GetAt and RangeAt read
+// SkipList as of a version. Deleted nodes are kept as tombstones, and the history is discarded by GC.
+func WithMVCC() Option {
+	return func(o *options) {
+		o.mvcc = true
+	}
+}
+
+// WithOptimisticLocking makes NewConcurrent back its ConcurrentSkipList by a lazy SkipList, whose reads take no lock
+// and whose writes lock only the predecessors of their key on each level, so that writes to disjoint keys run concurrently.
+// Writes validate the locked links and retry if they changed, and a node is marked under its own lock before it is
+// unlinked, so an update never revives a deleted node. Only WithProbability applies along with it, and maxLevel 0
+// is sized for the greatest Cap instead of being tuned. It is ignored by NewSkipList.
+func WithOptimisticLocking() Option {
+	return func(o *options) {
+		o.optimisticLocking = true
+	}
+}
+
+// WithRangeChunk sets the number of keys Range and ForEach of a ConcurrentSkipList copy per read lock, 1024 by default.
+// n must be positive, and NewSkipList checks it but has no scans to chunk.
+func WithRangeChunk(n int) Option {
+	return func(o *options) {
+		o.rangeChunk = n
+	}
+}
+
+// WithPregrow allocates the tower of head for maxLevel+1 levels at construction, or for the greatest maxLevel if it is tuned,
+// trading memory for no reallocation as the levels grow under bursty inserts. Level still reports the levels in use.
+func WithPregrow() Option {
+	return func(o *options) {
+		o.pregrow = true
+	}
+}
+
+// WithCodec sets the Codec values are persisted by, such as by MarshalBinary, SaveFile and AttachLog, whose type
+// parameter must match the SkipList. Without it, values of the built-in types have the built-in codec and the others
+// JSONCodec, as with a nil c.
+func WithCodec[T any](c Codec[T]) Option {
+	return func(o *options) {
+		o.codec = c
+	}
+}
+
+// WithValueCodec is WithCodec of the Codec of encode and decode.
+// decode must not retain b, which is reused.
+func WithValueCodec[T any](encode func(val T) ([]byte, error), decode func(b []byte) (T, error)) Option {
+	return WithCodec[T](codecFuncs[T]{encode: encode, decode: decode})
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		p:          0.5,
+		rangeChunk: defaultRangeChunk,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) valid() bool {
+	return o.p > 0 && o.p < 1 && o.rangeChunk > 0
+}
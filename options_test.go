@@ -0,0 +1,442 @@
+package skip_list
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWithProbability(t *testing.T) {
+	type testCase struct {
+		name    string
+		p       float64
+		wantNil bool
+	}
+	tests := []testCase{
+		{
+			name:    "TestWithProbability 1",
+			p:       0.5,
+			wantNil: false,
+		},
+		{
+			name:    "TestWithProbability 2",
+			p:       0.25,
+			wantNil: false,
+		},
+		{
+			name:    "TestWithProbability 3",
+			p:       0,
+			wantNil: true,
+		},
+		{
+			name:    "TestWithProbability 4",
+			p:       1,
+			wantNil: true,
+		},
+		{
+			name:    "TestWithProbability 5",
+			p:       -0.5,
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, false, WithProbability(tt.p))
+			if gotNil := sl == nil; gotNil != tt.wantNil {
+				t.Errorf("NewSkipList() nil = %v, want %v", gotNil, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestWithRandSource(t *testing.T) {
+	build := func(seed int64) *SkipList[int, int] {
+		sl := NewSkipList[int, int](16, false, WithRandSource(rand.NewSource(seed)))
+		for i := 0; i < 1000; i++ {
+			sl.Put((i*7919)%1000, i)
+		}
+		for i := 0; i < 1000; i += 3 {
+			sl.Delete(i)
+		}
+		return sl
+	}
+	dump := func(sl *SkipList[int, int]) string {
+		var buf bytes.Buffer
+		if err := sl.DumpDOT(&buf); err != nil {
+			t.Fatalf("DumpDOT() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	sl1, sl2 := build(42), build(42)
+	if s1, s2 := sl1.Stats(), sl2.Stats(); !reflect.DeepEqual(s1, s2) {
+		t.Errorf("Stats() = %v, want %v", s1, s2)
+	}
+	if d1, d2 := dump(sl1), dump(sl2); d1 != d2 {
+		t.Errorf("DumpDOT() differs for the same seed")
+	}
+}
+
+func TestWithLevelFunc(t *testing.T) {
+	var gotN []int
+	sl := NewSkipList[int, int](2, false, WithLevelFunc(func(n int) int {
+		gotN = append(gotN, n)
+		return []int{-1, 0, 1, 2, 5}[n]
+	}))
+	for i := 0; i < 5; i++ {
+		sl.Put(i, i)
+	}
+	sl.Put(0, 0)
+
+	if wantN := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(gotN, wantN) {
+		t.Errorf("levelFunc n = %v, want %v", gotN, wantN)
+	}
+	// levels are clamped to [0, maxLevel]
+	if got, want := sl.Stats().LevelHistogram, []int32{2, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats().LevelHistogram = %v, want %v", got, want)
+	}
+}
+
+func TestWithLevelFunc_Structure(t *testing.T) {
+	var (
+		levels = []int{0, 2, 1, 0, 3, 0, 1, 2}
+		sl     = NewSkipList[int, int](3, false, WithLevelFunc(func(n int) int { return levels[n] }))
+	)
+	for i := range levels {
+		sl.Put(i+1, i+1)
+	}
+
+	if got := sl.Level(); got != 4 {
+		t.Errorf("Level() = %v, want 4", got)
+	}
+	if got, want := sl.Stats().LevelHistogram, []int32{3, 2, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats().LevelHistogram = %v, want %v", got, want)
+	}
+	// keys linked on every level
+	want := [][]int{{1, 2, 3, 4, 5, 6, 7, 8}, {2, 3, 5, 7, 8}, {2, 5, 8}, {5}}
+	for l := range want {
+		var got []int
+		for n := sl.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			got = append(got, n.key)
+		}
+		if !reflect.DeepEqual(got, want[l]) {
+			t.Errorf("level %v = %v, want %v", l, got, want[l])
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestWithNodePool(t *testing.T) {
+	type value struct {
+		key  int
+		data *[16]int
+	}
+
+	var (
+		r   = rand.New(rand.NewSource(1))
+		sl  = NewSkipList[int, value](16, false, WithNodePool())
+		ref = make(map[int]value)
+	)
+	for i := 0; i < 100000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(2) == 0 {
+			val := value{key: key, data: &[16]int{key, i}}
+			sl.Put(key, val)
+			ref[key] = val
+		} else {
+			sl.Delete(key)
+			delete(ref, key)
+		}
+	}
+
+	t.Run("TestWithNodePool 1", func(t *testing.T) {
+		if err := sl.Validate(); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if got := sl.Cap(); got != int32(len(ref)) {
+			t.Errorf("Cap() = %v, want %v", got, len(ref))
+		}
+		for _, kv := range sl.Items() {
+			want, ok := ref[kv.key]
+			if !ok || kv.val != want || kv.val.key != kv.key || kv.val.data[0] != kv.key {
+				t.Fatalf("Items() has %v: %v, want %v", kv.key, kv.val, want)
+			}
+		}
+	})
+
+	t.Run("TestWithNodePool 2", func(t *testing.T) {
+		// a recycled node keeps neither key nor value
+		n := sl.newNode(1, value{key: 1, data: &[16]int{}}, 2)
+		sl.freeNode(n)
+		if !reflect.DeepEqual(*n, node[int, value]{}) {
+			t.Errorf("freeNode() = %v, want zero node", *n)
+		}
+	})
+}
+
+func BenchmarkWithNodePool(b *testing.B) {
+	for _, opts := range [][]Option{nil, {WithNodePool()}} {
+		b.Run(fmt.Sprintf("pool=%v", len(opts) > 0), func(b *testing.B) {
+			var sl = NewSkipList[int, int](16, false, opts...)
+			for i := 0; i < 1024; i++ {
+				sl.Put(i, i)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// steady-state churn
+				sl.Delete(i % 1024)
+				sl.Put(i%1024, i)
+			}
+		})
+	}
+}
+
+func TestWithTombstones(t *testing.T) {
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 1; i <= 10; i++ {
+		sl.Put(i, i)
+	}
+	for i := 2; i <= 10; i += 2 {
+		sl.Delete(i)
+	}
+	sl.Delete(2)
+	sl.DeleteIf(func(key, val int) bool { return key == 9 })
+
+	t.Run("TestWithTombstones 1", func(t *testing.T) {
+		if got := sl.Tombstones(); got != 6 {
+			t.Errorf("Tombstones() = %v, want 6", got)
+		}
+		if got := sl.Cap(); got != 4 {
+			t.Errorf("Cap() = %v, want 4", got)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("TestWithTombstones 2", func(t *testing.T) {
+		if v, ok := sl.Get(4); ok {
+			t.Errorf("Get(4) = %v, true, want tombstone skipped", v)
+		}
+		if ref, ok := sl.GetRef(4); ok {
+			t.Errorf("GetRef(4) = %v, true, want tombstone skipped", ref)
+		}
+		if sl.CompareAndSwap(4, 4, 40, func(a, b int) bool { return a == b }) {
+			t.Errorf("CompareAndSwap(4) = true, want tombstone skipped")
+		}
+		if got, want := sl.Items(), []*KvPair[int, int]{{1, 1}, {3, 3}, {5, 5}, {7, 7}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		if got, want := sl.Range(2, 6), []*KvPair[int, int]{{3, 3}, {5, 5}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Range() = %v, want %v", got, want)
+		}
+		if got, _ := sl.Ceil(8); got != nil {
+			t.Errorf("Ceil(8) = %v, want nil", got)
+		}
+		if got, _ := sl.Ceil(4); !reflect.DeepEqual(got, &KvPair[int, int]{5, 5}) {
+			t.Errorf("Ceil(4) = %v, want 5", got)
+		}
+		if got, _ := sl.Floor(10); !reflect.DeepEqual(got, &KvPair[int, int]{7, 7}) {
+			t.Errorf("Floor(10) = %v, want 7", got)
+		}
+		if got, _ := sl.Floor(2); !reflect.DeepEqual(got, &KvPair[int, int]{1, 1}) {
+			t.Errorf("Floor(2) = %v, want 1", got)
+		}
+	})
+
+	t.Run("TestWithTombstones 3", func(t *testing.T) {
+		sl.Put(4, 40)
+		if v, ok := sl.Get(4); !ok || v != 40 {
+			t.Errorf("Get(4) = %v, %v, want 40, true", v, ok)
+		}
+		if got := sl.Tombstones(); got != 5 {
+			t.Errorf("Tombstones() = %v, want 5", got)
+		}
+		if got := sl.Cap(); got != 5 {
+			t.Errorf("Cap() = %v, want 5", got)
+		}
+	})
+
+	t.Run("TestWithTombstones 4", func(t *testing.T) {
+		want := sl.Items()
+		sl.Compact()
+		if got := sl.Tombstones(); got != 0 {
+			t.Errorf("Tombstones() = %v, want 0", got)
+		}
+		if got := sl.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		s := sl.Stats()
+		var linked int32
+		for _, c := range s.LevelHistogram {
+			linked += c
+		}
+		if linked != s.Cap {
+			t.Errorf("Stats() has %v linked nodes, want %v", linked, s.Cap)
+		}
+		if err := sl.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+}
+
+func TestWithSafeIterators(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestWithSafeIterators 1",
+		},
+		{
+			name: "TestWithSafeIterators 2",
+			opts: []Option{WithNodePool()},
+		},
+		{
+			name: "TestWithSafeIterators 3",
+			opts: []Option{WithArena(), WithFinger()},
+		},
+		{
+			name: "TestWithSafeIterators 4",
+			opts: []Option{WithTombstones(), WithNodePool()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, false, append(tt.opts, WithSafeIterators())...)
+			for i := 0; i < 20; i++ {
+				sl.Put(i, -i)
+			}
+
+			// park at 5, delete it and its neighbors
+			it := sl.Seek(5)
+			for _, key := range []int{4, 5, 6, 7} {
+				sl.Delete(key)
+			}
+			sl.DeleteIf(func(key, _ int) bool { return key == 9 })
+			sl.Compact()
+			// churn so that recycled nodes would be reused
+			for i := 100; i < 110; i++ {
+				sl.Put(i, i)
+				sl.Delete(i)
+			}
+
+			if it.Key() != 5 || it.Val() != -5 {
+				t.Errorf("Key(), Val() = %v, %v, want 5, -5", it.Key(), it.Val())
+			}
+			var got []int
+			for it.Next() {
+				got = append(got, it.Key())
+			}
+			if want := []int{8, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Next() = %v, want %v", got, want)
+			}
+
+			if len(sl.retired) == 0 {
+				t.Errorf("retired is empty while an Iterator is open")
+			}
+			it.Close()
+			it.Close()
+			if len(sl.retired) != 0 || sl.iterators != 0 {
+				t.Errorf("retired = %v, iterators = %v after Close()", len(sl.retired), sl.iterators)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	t.Run("TestWithSafeIterators 5", func(t *testing.T) {
+		sl := NewSkipList[int, int](10, true, WithSafeIterators())
+		for i := 0; i < 10; i++ {
+			sl.Put(i, i)
+		}
+
+		it := sl.Iterator()
+		sl.Clear()
+		sl.Put(1, 10)
+		// a hint at a cleared node is ignored
+		if hinted := sl.PutWithHint(it, 2, 20); hinted.Key() != 2 {
+			t.Errorf("PutWithHint() is positioned at %v, want 2", hinted.Key())
+		}
+		if it.Next() {
+			t.Errorf("Next() = true, %v after Clear()", it.Key())
+		}
+		if got, want := sl.Items(), []*KvPair[int, int]{{1, 10}, {2, 20}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+		it.Close()
+	})
+}
+
+func TestWithPregrow(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		want     int
+	}
+	testCases := []testCase{
+		{name: "TestWithPregrow 1", maxLevel: 10, want: 11},
+		{name: "TestWithPregrow 2", maxLevel: 0, want: int(autoMaxLevelOf(math.MaxInt32)) + 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](tc.maxLevel, false, WithPregrow())
+			if got := sl.Level(); got != 1 {
+				t.Errorf("Level() = %v, want 1", got)
+			}
+			if got := cap(sl.head.nextNodes); got != tc.want {
+				t.Fatalf("cap(head.nextNodes) = %v, want %v", got, tc.want)
+			}
+
+			tower := &sl.head.nextNodes[:1][0]
+			for i := 0; i < 1000; i++ {
+				sl.Put(i, i)
+			}
+			if got := &sl.head.nextNodes[0]; got != tower {
+				t.Errorf("head.nextNodes is reallocated by Put()")
+			}
+			if got := sl.Level(); got != int32(len(sl.head.nextNodes)) {
+				t.Errorf("Level() = %v, want %v", got, len(sl.head.nextNodes))
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if got := len(sl.Items()); got != 1000 {
+				t.Errorf("len(Items()) = %v, want 1000", got)
+			}
+
+			sl.Clear()
+			if got := cap(sl.head.nextNodes); got != tc.want {
+				t.Errorf("cap(head.nextNodes) = %v after Clear(), want %v", got, tc.want)
+			}
+			if got := sl.Level(); got != 1 {
+				t.Errorf("Level() = %v after Clear(), want 1", got)
+			}
+		})
+	}
+}
+
+func BenchmarkWithPregrow(b *testing.B) {
+	for _, opts := range [][]Option{nil, {WithPregrow()}} {
+		b.Run(fmt.Sprintf("pregrow=%v", len(opts) > 0), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// bulk insert into a fresh list, with the same levels either way
+				sl := NewSkipList[int, int](16, false, append(opts, WithRandSource(rand.NewSource(1)))...)
+				for k := 0; k < 64; k++ {
+					sl.Put(k, k)
+				}
+			}
+		})
+	}
+}
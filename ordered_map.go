@@ -0,0 +1,22 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// OrderedMap is the method set of an ordered map, which SkipList and ConcurrentSkipList implement,
+// so that either can be swapped for another ordered map such as a B-tree behind an adapter.
+type OrderedMap[O constraints.Ordered, T any] interface {
+	Get(key O) (val T, exist bool)
+	Put(key O, val T)
+	Delete(key O)
+	Cap() int32
+	Min() (*KvPair[O, T], bool)
+	Max() (*KvPair[O, T], bool)
+	Ceil(target O) (*KvPair[O, T], bool)
+	Floor(target O) (*KvPair[O, T], bool)
+	AscendRange(start, end O, fn func(key O, val T) bool)
+}
+
+var (
+	_ OrderedMap[int, int] = (*SkipList[int, int])(nil)
+	_ OrderedMap[int, int] = (*ConcurrentSkipList[int, int])(nil)
+)
@@ -0,0 +1,83 @@
+package skip_list
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	type testCase struct {
+		name string
+		m    OrderedMap[int, int]
+	}
+	testCases := []testCase{
+		{name: "TestOrderedMap 1", m: NewSkipList[int, int](10, false)},
+		{name: "TestOrderedMap 2", m: NewSkipList[int, int](0, true, WithTombstones())},
+		{name: "TestOrderedMap 3", m: NewConcurrent[int, int](10)},
+		{name: "TestOrderedMap 4", m: NewConcurrent[int, int](10, WithOptimisticLocking())},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.m
+			if kv, ok := m.Min(); ok {
+				t.Errorf("Min() = %v, true on an empty map", kv)
+			}
+			if kv, ok := m.Max(); ok {
+				t.Errorf("Max() = %v, true on an empty map", kv)
+			}
+
+			for i := 0; i <= 100; i += 10 {
+				m.Put(i, -i)
+			}
+			m.Delete(0)
+			m.Delete(100)
+			m.Delete(55)
+
+			if got := m.Cap(); got != 9 {
+				t.Errorf("Cap() = %v, want 9", got)
+			}
+			if v, ok := m.Get(50); !ok || v != -50 {
+				t.Errorf("Get(50) = %v, %v, want -50, true", v, ok)
+			}
+			if _, ok := m.Get(100); ok {
+				t.Errorf("Get(100) = _, true after Delete(100)")
+			}
+			if kv, ok := m.Min(); !ok || kv.Key() != 10 {
+				t.Errorf("Min() = %v, %v, want 10", kv, ok)
+			}
+			if kv, ok := m.Max(); !ok || kv.Key() != 90 {
+				t.Errorf("Max() = %v, %v, want 90", kv, ok)
+			}
+			if kv, ok := m.Ceil(41); !ok || kv.Key() != 50 {
+				t.Errorf("Ceil(41) = %v, %v, want 50", kv, ok)
+			}
+			if kv, ok := m.Floor(41); !ok || kv.Key() != 40 {
+				t.Errorf("Floor(41) = %v, %v, want 40", kv, ok)
+			}
+			if kv, ok := m.Ceil(91); ok {
+				t.Errorf("Ceil(91) = %v, true, want false", kv)
+			}
+			if kv, ok := m.Floor(9); ok {
+				t.Errorf("Floor(9) = %v, true, want false", kv)
+			}
+
+			var got []int
+			m.AscendRange(25, 75, func(key, val int) bool {
+				got = append(got, key)
+				return true
+			})
+			if want := []int{30, 40, 50, 60, 70}; !reflect.DeepEqual(got, want) {
+				t.Errorf("AscendRange(25, 75) = %v, want %v", got, want)
+			}
+			got = got[:0]
+			m.AscendRange(0, 100, func(key, val int) bool {
+				got = append(got, key)
+				return len(got) < 2
+			})
+			if want := []int{10, 20}; !reflect.DeepEqual(got, want) {
+				t.Errorf("AscendRange() stopped at %v, want %v", got, want)
+			}
+		})
+	}
+}
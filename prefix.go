@@ -0,0 +1,81 @@
+package skip_list
+
+// PrefixRange searches the *KvPair of key with prefix, which is key in [prefix, prefixEnd(prefix)).
+func PrefixRange[T any](sl *SkipList[string, T], prefix string) []*KvPair[string, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[string, T], 0)
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	end, bounded := prefixEnd(prefix)
+	for n := sl.ceil(prefix); n != nil && (!bounded || n.key < end); n = n.next() {
+		res = append(res, newKvPair(n.key, n.val))
+	}
+	return res
+}
+
+// prefixEnd returns the least string greater than every string with prefix.
+// It is not bounded if prefix is empty or consists of 0xff bytes only.
+func prefixEnd(prefix string) (end string, bounded bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			return prefix[:i] + string([]byte{prefix[i] + 1}), true
+		}
+	}
+	return "", false
+}
+
+// ForEachPrefix calls fn for each key with prefix in ascending order until fn returns false.
+// fn must not modify sl.
+func ForEachPrefix[T any](sl *SkipList[string, T], prefix string, fn func(key string, val T) bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	end, bounded := prefixEnd(prefix)
+	for n := sl.ceil(prefix); n != nil && (!bounded || n.key < end); n = n.next() {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// DeletePrefix deletes the nodes of key with prefix in one descent as DeleteRange, and returns the number of them.
+// The inclusive end of the range is the greatest key with prefix, which is the predecessor of prefixEnd(prefix).
+func DeletePrefix[T any](sl *SkipList[string, T], prefix string) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	var last *node[string, T]
+	if end, bounded := prefixEnd(prefix); bounded {
+		last = sl.lower(end)
+	} else {
+		last = sl.last()
+	}
+	if last == sl.head || last.key < prefix {
+		return 0
+	}
+
+	// one record for all nodes
+	sl.log.deleteRange(prefix, last.key)
+	defer sl.log.mute()()
+
+	return sl.countDeleted(sl.deleteRange(prefix, last.key, nil))
+}
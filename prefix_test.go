@@ -0,0 +1,177 @@
+package skip_list
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPrefixRange(t *testing.T) {
+	type args struct {
+		prefix string
+	}
+	type testCase[T any] struct {
+		name string
+		sl   *SkipList[string, T]
+		args args
+		want []*KvPair[string, T]
+	}
+
+	var sl = NewSkipList[string, int](10, false)
+	sl.Put("a", 1)
+	sl.Put("ab", 2)
+	sl.Put("abc", 3)
+	sl.Put("ac", 4)
+	sl.Put("b", 5)
+	sl.Put("\xff", 6)
+	sl.Put("\xff\xff", 7)
+	sl.Put("\xff\xffa", 8)
+
+	tests := []testCase[int]{
+		{
+			name: "TestPrefixRange 1",
+			sl:   sl,
+			args: args{""},
+			want: []*KvPair[string, int]{{"a", 1}, {"ab", 2}, {"abc", 3}, {"ac", 4}, {"b", 5}, {"\xff", 6}, {"\xff\xff", 7}, {"\xff\xffa", 8}},
+		},
+		{
+			name: "TestPrefixRange 2",
+			sl:   sl,
+			args: args{"ab"},
+			want: []*KvPair[string, int]{{"ab", 2}, {"abc", 3}},
+		},
+		{
+			name: "TestPrefixRange 3",
+			sl:   sl,
+			args: args{"abd"},
+			want: []*KvPair[string, int]{},
+		},
+		{
+			name: "TestPrefixRange 4",
+			sl:   sl,
+			args: args{"\xff\xff"},
+			want: []*KvPair[string, int]{{"\xff\xff", 7}, {"\xff\xffa", 8}},
+		},
+		{
+			name: "TestPrefixRange 5",
+			sl:   nil,
+			args: args{"a"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrefixRange(tt.sl, tt.args.prefix); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrefixRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_prefixEnd(t *testing.T) {
+	type testCase struct {
+		name        string
+		prefix      string
+		wantEnd     string
+		wantBounded bool
+	}
+	tests := []testCase{
+		{"Test_prefixEnd 1", "", "", false},
+		{"Test_prefixEnd 2", "ab", "ac", true},
+		{"Test_prefixEnd 3", "a\xff", "b", true},
+		{"Test_prefixEnd 4", "\xff\xff", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEnd, gotBounded := prefixEnd(tt.prefix)
+			if gotEnd != tt.wantEnd || gotBounded != tt.wantBounded {
+				t.Errorf("prefixEnd() = %q, %v, want %q, %v", gotEnd, gotBounded, tt.wantEnd, tt.wantBounded)
+			}
+		})
+	}
+}
+
+func TestForEachPrefix_DeletePrefix(t *testing.T) {
+	type testCase struct {
+		name   string
+		prefix string
+	}
+	tests := []testCase{
+		{name: "TestForEachPrefix_DeletePrefix 1", prefix: ""},
+		{name: "TestForEachPrefix_DeletePrefix 2", prefix: "a"},
+		{name: "TestForEachPrefix_DeletePrefix 3", prefix: "a\xff"},
+		{name: "TestForEachPrefix_DeletePrefix 4", prefix: "\xff"},
+		{name: "TestForEachPrefix_DeletePrefix 5", prefix: "abcdefgh"},
+		{name: "TestForEachPrefix_DeletePrefix 6", prefix: "日本"},
+		{name: "TestForEachPrefix_DeletePrefix 7", prefix: "日"},
+		{name: "TestForEachPrefix_DeletePrefix 8", prefix: "0"},
+	}
+	var keys = []string{"a", "ab", "a\xff", "a\xff\xff", "a\xff\xffz", "b", "日", "日本", "日本語", "日本a", "旦", "\xff", "\xff\xff"}
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {WithTombstones()}} {
+			t.Run(tt.name, func(t *testing.T) {
+				sl := NewSkipList[string, int](0, true, opts...)
+				for i, key := range keys {
+					sl.Put(key, i)
+				}
+				var want, rest []string
+				for _, kv := range sl.Items() {
+					if strings.HasPrefix(kv.Key(), tt.prefix) {
+						want = append(want, kv.Key())
+					} else {
+						rest = append(rest, kv.Key())
+					}
+				}
+
+				var got []string
+				ForEachPrefix(sl, tt.prefix, func(key string, val int) bool {
+					if keys[val] != key {
+						t.Errorf("ForEachPrefix() passed %q with %v", key, val)
+					}
+					got = append(got, key)
+					return true
+				})
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("ForEachPrefix() = %q, want %q", got, want)
+				}
+
+				if n := DeletePrefix(sl, tt.prefix); n != len(want) {
+					t.Errorf("DeletePrefix() = %v, want %v", n, len(want))
+				}
+				var remaining []string
+				for _, kv := range sl.Items() {
+					remaining = append(remaining, kv.Key())
+				}
+				if !reflect.DeepEqual(remaining, rest) {
+					t.Errorf("keys after DeletePrefix() = %q, want %q", remaining, rest)
+				}
+				if DeletePrefix(sl, tt.prefix) != 0 {
+					t.Errorf("DeletePrefix() deleted again")
+				}
+				if err := sl.Validate(); err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+			})
+		}
+	}
+
+	// ForEachPrefix stops once fn returns false
+	sl := NewSkipList[string, int](10, false)
+	for i, key := range keys {
+		sl.Put(key, i)
+	}
+	var n int
+	ForEachPrefix(sl, "a", func(string, int) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("ForEachPrefix() called fn %d times, want 2", n)
+	}
+
+	var nilList *SkipList[string, int]
+	ForEachPrefix(nilList, "", func(string, int) bool { return true })
+	if DeletePrefix(nilList, "") != 0 {
+		t.Errorf("DeletePrefix() of a nil SkipList deleted nodes")
+	}
+}
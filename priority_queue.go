@@ -0,0 +1,188 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// Handle identifies an item pushed to a PriorityQueue, by which UpdatePriority and Remove find it without a search.
+type Handle uint64
+
+// PriorityQueue is a min priority queue of items, whose ties of priority are popped in the order they are pushed.
+// It is a SkipList of the items keyed by the Encode of CompositeKey of priority and Handle, which increases by
+// every Push, and a map of the priority of each Handle, by which the key of an item is rebuilt.
+// Both are kept consistent under the lock of the SkipList.
+type PriorityQueue[P constraints.Ordered, T any] struct {
+	sl         *SkipList[string, T]
+	priorities map[Handle]P
+
+	// the Handle of the next Push, guarded by the lock of sl
+	next Handle
+}
+
+// NewPriorityQueue returns a PriorityQueue of the SkipList NewSkipList returns for maxLevel and opts,
+// or nil as it does.
+func NewPriorityQueue[P constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *PriorityQueue[P, T] {
+	sl := NewSkipList[string, T](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &PriorityQueue[P, T]{sl: sl, priorities: make(map[Handle]P)}
+}
+
+// Push adds item with priority, and returns its Handle. NaN priorities are ordered by their bits.
+func (q *PriorityQueue[P, T]) Push(priority P, item T) Handle {
+	if q == nil {
+		return 0
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	priority = normalizePriority(priority)
+	h := q.next
+	q.next++
+	sl.put(nil, priorityKey(priority, h), item)
+	q.priorities[h] = priority
+	return h
+}
+
+// Pop removes the item of the least priority, and returns it.
+func (q *PriorityQueue[P, T]) Pop() (item T, exist bool) {
+	if q == nil {
+		return
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	n := sl.head.next()
+	if n == nil {
+		return
+	}
+	key, item := n.key, n.val
+	k, _ := DecodeCompositeKey[P, Handle](key)
+	sl.delete(key, nil)
+	sl.count(&sl.deletes)
+	delete(q.priorities, k.Second)
+	return item, true
+}
+
+// Peek returns the item of the least priority without removing it.
+func (q *PriorityQueue[P, T]) Peek() (item T, exist bool) {
+	if q == nil {
+		return
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	if n := sl.head.next(); n != nil {
+		return n.val, true
+	}
+	return
+}
+
+// UpdatePriority moves the item of h to priority, keeping its order among the items of equal priority,
+// and returns whether h is in q.
+func (q *PriorityQueue[P, T]) UpdatePriority(h Handle, priority P) bool {
+	if q == nil {
+		return false
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	old, exist := q.priorities[h]
+	if !exist {
+		return false
+	}
+	priority = normalizePriority(priority)
+	if old == priority {
+		return true
+	}
+
+	oldKey := priorityKey(old, h)
+	n := sl.get(oldKey)
+	item := n.val
+	sl.delete(oldKey, nil)
+	sl.put(nil, priorityKey(priority, h), item)
+	sl.count(&sl.puts)
+	q.priorities[h] = priority
+	return true
+}
+
+// Remove removes the item of h, and returns it.
+func (q *PriorityQueue[P, T]) Remove(h Handle) (item T, exist bool) {
+	if q == nil {
+		return
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	priority, exist := q.priorities[h]
+	if !exist {
+		return
+	}
+	key := priorityKey(priority, h)
+	item = sl.get(key).val
+	sl.delete(key, nil)
+	sl.count(&sl.deletes)
+	delete(q.priorities, h)
+	return item, true
+}
+
+// Priority returns the priority of the item of h.
+func (q *PriorityQueue[P, T]) Priority(h Handle) (priority P, exist bool) {
+	if q == nil {
+		return
+	}
+
+	sl := q.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	priority, exist = q.priorities[h]
+	return
+}
+
+// Len returns the number of items.
+func (q *PriorityQueue[P, T]) Len() int {
+	if q == nil {
+		return 0
+	}
+
+	return int(q.sl.Cap())
+}
+
+// priorityKey returns the key of the item of h with priority in the SkipList of PriorityQueue.
+func priorityKey[P constraints.Ordered](priority P, h Handle) string {
+	return NewCompositeKey(priority, h).Encode()
+}
+
+// normalizePriority returns priority with -0 as 0, so that they are equal in the SkipList as they are compared.
+func normalizePriority[P constraints.Ordered](priority P) P {
+	var zero P
+	if priority == zero {
+		return zero
+	}
+	return priority
+}
@@ -0,0 +1,239 @@
+package skip_list
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestPriorityQueue 1", isConcurrent: false},
+		{name: "TestPriorityQueue 2", isConcurrent: true},
+		{name: "TestPriorityQueue 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd     = rand.New(rand.NewSource(1))
+				q       = NewPriorityQueue[int, int](0, tt.isConcurrent, tt.opts...)
+				ref     = &refHeap{}
+				entries = make(map[Handle]*refEntry)
+				handles []Handle
+			)
+			for i := 0; i < 20000; i++ {
+				// few priorities, so that many tie
+				priority := rnd.Intn(50)
+				switch op := rnd.Intn(10); {
+				case op < 5:
+					h := q.Push(priority, i)
+					e := &refEntry{priority: priority, seq: h, item: i}
+					heap.Push(ref, e)
+					entries[h] = e
+					handles = append(handles, h)
+				case op < 7:
+					item, exist := q.Pop()
+					if exist != (ref.Len() > 0) {
+						t.Fatalf("Pop() exist = %v, want %v", exist, ref.Len() > 0)
+					}
+					if exist {
+						e := heap.Pop(ref).(*refEntry)
+						delete(entries, e.seq)
+						if item != e.item {
+							t.Fatalf("Pop() = %v, want %v", item, e.item)
+						}
+					}
+				case op < 9 && len(handles) > 0:
+					h := handles[rnd.Intn(len(handles))]
+					e, exist := entries[h]
+					if got := q.UpdatePriority(h, priority); got != exist {
+						t.Fatalf("UpdatePriority(%v) = %v, want %v", h, got, exist)
+					}
+					if exist {
+						e.priority = priority
+						heap.Fix(ref, e.index)
+					}
+				case len(handles) > 0:
+					h := handles[rnd.Intn(len(handles))]
+					e, exist := entries[h]
+					item, got := q.Remove(h)
+					if got != exist || (exist && item != e.item) {
+						t.Fatalf("Remove(%v) = %v, %v, want %v", h, item, got, exist)
+					}
+					if exist {
+						heap.Remove(ref, e.index)
+						delete(entries, h)
+					}
+				}
+
+				if q.Len() != ref.Len() {
+					t.Fatalf("Len() = %v, want %v", q.Len(), ref.Len())
+				}
+				if item, exist := q.Peek(); exist != (ref.Len() > 0) || (exist && item != (*ref)[0].item) {
+					t.Fatalf("Peek() = %v, %v", item, exist)
+				}
+			}
+
+			for ref.Len() > 0 {
+				e := heap.Pop(ref).(*refEntry)
+				if item, _ := q.Pop(); item != e.item {
+					t.Fatalf("Pop() = %v, want %v", item, e.item)
+				}
+			}
+			if _, exist := q.Pop(); exist {
+				t.Errorf("Pop() of an empty PriorityQueue exists")
+			}
+			if err := q.sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	var nilQueue *PriorityQueue[int, int]
+	if _, exist := nilQueue.Pop(); exist || nilQueue.UpdatePriority(nilQueue.Push(1, 1), 2) || nilQueue.Len() != 0 {
+		t.Errorf("nil PriorityQueue is not empty")
+	}
+	if q := NewPriorityQueue[int, int](-1, false); q != nil {
+		t.Errorf("NewPriorityQueue() = %v, want nil", q)
+	}
+}
+
+func TestPriorityQueue_Dijkstra(t *testing.T) {
+	type edge struct {
+		to     int
+		weight float64
+	}
+	var (
+		rnd   = rand.New(rand.NewSource(2))
+		n     = 300
+		graph = make([][]edge, n)
+	)
+	for from := range graph {
+		for i := 0; i < 5; i++ {
+			graph[from] = append(graph[from], edge{to: rnd.Intn(n), weight: float64(rnd.Intn(100))})
+		}
+	}
+
+	// Dijkstra by decrease-key, with each vertex pushed once
+	var (
+		q       = NewPriorityQueue[float64, int](0, false)
+		dist    = make([]float64, n)
+		handles = make([]Handle, n)
+		queued  = make([]bool, n)
+	)
+	for v := range dist {
+		dist[v] = math.Inf(1)
+	}
+	dist[0] = 0
+	handles[0], queued[0] = q.Push(0, 0), true
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		queued[v] = false
+		for _, e := range graph[v] {
+			d := dist[v] + e.weight
+			if d >= dist[e.to] {
+				continue
+			}
+			if math.IsInf(dist[e.to], 1) {
+				handles[e.to], queued[e.to] = q.Push(d, e.to), true
+			} else if !q.UpdatePriority(handles[e.to], d) {
+				t.Fatalf("UpdatePriority() of a settled vertex %v", e.to)
+			}
+			if p, _ := q.Priority(handles[e.to]); p != d {
+				t.Fatalf("Priority() = %v, want %v", p, d)
+			}
+			dist[e.to] = d
+		}
+	}
+
+	// Bellman-Ford as the reference
+	var want = make([]float64, n)
+	for v := range want {
+		want[v] = math.Inf(1)
+	}
+	want[0] = 0
+	for i := 0; i < n; i++ {
+		for from := range graph {
+			for _, e := range graph[from] {
+				if d := want[from] + e.weight; d < want[e.to] {
+					want[e.to] = d
+				}
+			}
+		}
+	}
+	for v := range want {
+		if dist[v] != want[v] {
+			t.Fatalf("dist[%v] = %v, want %v", v, dist[v], want[v])
+		}
+	}
+}
+
+func TestPriorityQueue_Stable(t *testing.T) {
+	q := NewPriorityQueue[float64, string](10, false)
+	a := q.Push(1, "a")
+	q.Push(1, "b")
+	c := q.Push(2, "c")
+	q.Push(math.Copysign(0, -1), "zero")
+
+	// an update keeps the order of the item among equal priorities by its Push
+	q.UpdatePriority(c, 1)
+	q.UpdatePriority(a, 0)
+	var got []string
+	for item, exist := q.Pop(); exist; item, exist = q.Pop() {
+		got = append(got, item)
+	}
+	if want := []string{"a", "zero", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pop() order = %v, want %v", got, want)
+	}
+	if q.UpdatePriority(a, 3) {
+		t.Errorf("UpdatePriority() of a popped item = true")
+	}
+	if _, exist := q.Remove(c); exist {
+		t.Errorf("Remove() of a popped item exists")
+	}
+}
+
+// refEntry is an item of refHeap ordered by priority and then by seq.
+type refEntry struct {
+	priority int
+	seq      Handle
+	item     int
+	index    int
+}
+
+// refHeap is the container/heap reference of PriorityQueue.
+type refHeap []*refEntry
+
+func (h refHeap) Len() int { return len(h) }
+
+func (h refHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h refHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *refHeap) Push(x any) {
+	e := x.(*refEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *refHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
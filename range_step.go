@@ -0,0 +1,75 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// RangeStep downsamples [start, end] into the buckets [start+k*step, start+(k+1)*step), and returns in key order the
+// result of pick for the *KvPair of each non-empty bucket, skipping nil ones. If pick is nil, it returns the first
+// *KvPair of each non-empty bucket, which is found by one descent per bucket instead of walking every key.
+// It returns an empty slice if step is not positive. pick must not modify sl, nor retain bucket, which is reused.
+func RangeStep[O constraints.Integer | constraints.Float, T any](sl *SkipList[O, T], start, end, step O, pick func(bucket []*KvPair[O, T]) *KvPair[O, T]) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	if !(step > 0) {
+		return res
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		isFloat = O(1)/2 != 0
+		bucket  []*KvPair[O, T]
+		n       = sl.ceil(start)
+
+		// the current bucket [lo, hi), whose bounds are computed from its index k for floats rather than accumulated
+		// so that they do not drift
+		lo = start
+		k  int64
+	)
+	for n != nil && n.key <= end {
+		hi := lo + step
+		if isFloat {
+			hi = start + O(k+1)*step
+		}
+		// the last bucket extends to end even if hi overflows
+		last := hi <= lo || hi > end
+		if !last && n.key >= hi {
+			// skip the empty buckets to the bucket of n
+			if isFloat {
+				// at least one bucket, and one less if the division rounds up
+				k = max(int64((n.key-start)/step), k+1)
+				if lo = start + O(k)*step; n.key < lo && k > 0 {
+					k--
+					lo = start + O(k)*step
+				}
+			} else {
+				// the offset of n from start modulo 2^64 is exact
+				lo = n.key - O((uint64(n.key)-uint64(start))%uint64(step))
+			}
+			continue
+		}
+
+		if pick == nil {
+			res = append(res, newKvPair(n.key, n.val))
+			n = sl.ceil(hi)
+		} else {
+			bucket = bucket[:0]
+			for ; n != nil && n.key <= end && (last || n.key < hi); n = n.next() {
+				bucket = append(bucket, newKvPair(n.key, n.val))
+			}
+			if kv := pick(bucket); kv != nil {
+				res = append(res, kv)
+			}
+		}
+		if last {
+			break
+		}
+		lo, k = hi, k+1
+	}
+	return res
+}
@@ -0,0 +1,176 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRangeStep(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+		keys         int
+		spread       int
+	}
+	tests := []testCase{
+		{name: "TestRangeStep 1", isConcurrent: false, keys: 2000, spread: 10000},
+		{name: "TestRangeStep 2", isConcurrent: true, keys: 2000, spread: 10000},
+		{name: "TestRangeStep 3", isConcurrent: false, opts: []Option{WithTombstones()}, keys: 2000, spread: 10000},
+		// sparse, so that most buckets are empty
+		{name: "TestRangeStep 4", isConcurrent: false, keys: 50, spread: 1000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				sl  = NewSkipList[int, int](0, tt.isConcurrent, tt.opts...)
+			)
+			for i := 0; i < tt.keys; i++ {
+				key := rnd.Intn(tt.spread) - tt.spread/2
+				sl.Put(key, key*3)
+			}
+			for i := 0; i < tt.keys/10; i++ {
+				sl.Delete(rnd.Intn(tt.spread) - tt.spread/2)
+			}
+			items := sl.Items()
+
+			for q := 0; q < 200; q++ {
+				start := rnd.Intn(tt.spread) - tt.spread/2
+				end := start + rnd.Intn(tt.spread)
+				step := rnd.Intn(tt.spread/10) + 1
+
+				// brute-force grouping
+				var (
+					wantBuckets = make([][]*KvPair[int, int], 0)
+					wantFirsts  = make([]*KvPair[int, int], 0)
+					prev        = -1
+				)
+				for _, kv := range items {
+					if kv.Key() < start || kv.Key() > end {
+						continue
+					}
+					if k := (kv.Key() - start) / step; k != prev {
+						wantBuckets = append(wantBuckets, nil)
+						wantFirsts = append(wantFirsts, kv)
+						prev = k
+					}
+					wantBuckets[len(wantBuckets)-1] = append(wantBuckets[len(wantBuckets)-1], kv)
+				}
+
+				if got := RangeStep(sl, start, end, step, nil); !reflect.DeepEqual(got, wantFirsts) {
+					t.Fatalf("RangeStep(%v, %v, %v, nil) = %v, want %v", start, end, step, got, wantFirsts)
+				}
+				var (
+					buckets = make([][]*KvPair[int, int], 0)
+					lasts   = make([]*KvPair[int, int], 0)
+				)
+				got := RangeStep(sl, start, end, step, func(bucket []*KvPair[int, int]) *KvPair[int, int] {
+					buckets = append(buckets, append([]*KvPair[int, int](nil), bucket...))
+					lasts = append(lasts, bucket[len(bucket)-1])
+					return bucket[len(bucket)-1]
+				})
+				if !reflect.DeepEqual(buckets, wantBuckets) {
+					t.Fatalf("buckets of RangeStep(%v, %v, %v) = %v, want %v", start, end, step, buckets, wantBuckets)
+				}
+				if !reflect.DeepEqual(got, lasts) {
+					t.Fatalf("RangeStep(%v, %v, %v) = %v, want %v", start, end, step, got, lasts)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeStep_Bounds(t *testing.T) {
+	sl := NewSkipList[int8, int](10, false)
+	for _, key := range []int8{-128, -1, 0, 1, 99, 100, 126, 127} {
+		sl.Put(key, int(key))
+	}
+
+	keysOf := func(pairs []*KvPair[int8, int]) []int8 {
+		var keys []int8
+		for _, kv := range pairs {
+			keys = append(keys, kv.Key())
+		}
+		return keys
+	}
+	// the last bucket extends to end when its bound overflows
+	lastOf := func(bucket []*KvPair[int8, int]) *KvPair[int8, int] { return bucket[len(bucket)-1] }
+	if got, want := keysOf(RangeStep(sl, -128, 127, 100, lastOf)), []int8{-128, 1, 127}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeStep() = %v, want %v", got, want)
+	}
+	if got, want := keysOf(RangeStep(sl, -128, 127, 1, nil)), []int8{-128, -1, 0, 1, 99, 100, 126, 127}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeStep() = %v, want %v", got, want)
+	}
+	// end is inclusive, and starts its own bucket at a boundary
+	if got, want := keysOf(RangeStep(sl, 0, 100, 100, nil)), []int8{0, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeStep() = %v, want %v", got, want)
+	}
+	if got := RangeStep(sl, 0, 100, 0, nil); len(got) != 0 {
+		t.Errorf("RangeStep() of step 0 = %v, want none", got)
+	}
+	if got := RangeStep(sl, 100, 0, 1, nil); len(got) != 0 {
+		t.Errorf("RangeStep() of a reversed range = %v, want none", got)
+	}
+	if got := RangeStep(sl, -128, 127, 1, func([]*KvPair[int8, int]) *KvPair[int8, int] { return nil }); len(got) != 0 {
+		t.Errorf("RangeStep() of a nil pick = %v, want none", got)
+	}
+
+	floats := NewSkipList[float64, int](10, false)
+	for i := 0; i < 100; i++ {
+		floats.Put(float64(i)*0.1, i)
+	}
+	got := RangeStep(floats, 0, 10, 0.5, nil)
+	if len(got) != 20 {
+		t.Fatalf("RangeStep() of floats = %v, want 20 buckets", got)
+	}
+	for k, kv := range got {
+		if lo := float64(k) * 0.5; kv.Key() < lo-1e-9 || kv.Key() >= lo+0.5-1e-9 {
+			t.Errorf("first key of bucket %d = %v", k, kv.Key())
+		}
+	}
+	sparse := NewSkipList[float64, int](10, false)
+	for _, key := range []float64{0.05, 3.31, 3.35, 7.75, 9.99} {
+		sparse.Put(key, 0)
+	}
+	if got, want := len(RangeStep(sparse, 0, 10, 0.1, nil)), 4; got != want {
+		t.Errorf("RangeStep() of sparse floats = %v buckets, want %v", got, want)
+	}
+	if got := RangeStep(floats, 0, 10, math.NaN(), nil); len(got) != 0 {
+		t.Errorf("RangeStep() of a NaN step = %v, want none", got)
+	}
+
+	var nilList *SkipList[int, int]
+	if RangeStep(nilList, 0, 1, 1, nil) != nil {
+		t.Errorf("RangeStep() of a nil SkipList is not nil")
+	}
+}
+
+func BenchmarkRangeStep(b *testing.B) {
+	const n = 1000000
+
+	var pairs = make([]*KvPair[int, int], n)
+	for i := range pairs {
+		pairs[i] = NewKvPair(i*1000, i)
+	}
+	sl := BulkLoad(0, false, pairs)
+
+	b.Run("Walk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var prev = -1
+			sl.AscendRange(0, n*1000, func(key int, _ int) bool {
+				if k := key / 1000000; k != prev {
+					prev = k
+				}
+				return true
+			})
+		}
+	})
+	b.Run("RangeStep", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			RangeStep(sl, 0, n*1000, 1000000, nil)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// ReadOnlySkipList is a view of SkipList with only the methods to read it, which can be handed to untrusted code.
+// Unlike Frozen, it shares the nodes of SkipList instead of copying them, so it reads the writes to SkipList.
+// Writing SkipList while the view is read is unsafe unless SkipList is concurrent, as it is for SkipList itself.
+type ReadOnlySkipList[O constraints.Ordered, T any] struct {
+	sl *SkipList[O, T]
+}
+
+// ReadOnly returns a ReadOnlySkipList of SkipList in O(1).
+func (sl *SkipList[O, T]) ReadOnly() *ReadOnlySkipList[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	return &ReadOnlySkipList[O, T]{sl: sl}
+}
+
+// Cap returns the number of keys.
+func (r *ReadOnlySkipList[O, T]) Cap() int32 {
+	if r == nil {
+		return 0
+	}
+
+	return r.sl.Cap()
+}
+
+func (r *ReadOnlySkipList[O, T]) Get(key O) (val T, exist bool) {
+	if r == nil {
+		return
+	}
+
+	return r.sl.Get(key)
+}
+
+// Range searches the *KvPair of key in [start, end].
+func (r *ReadOnlySkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if r == nil {
+		return nil
+	}
+
+	return r.sl.Range(start, end)
+}
+
+// Ceil returns *KvPair of the least key greater than or equal to target.
+func (r *ReadOnlySkipList[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	return r.sl.Ceil(target)
+}
+
+// Floor returns *KvPair of the greatest key less than or equal to target.
+func (r *ReadOnlySkipList[O, T]) Floor(target O) (*KvPair[O, T], bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	return r.sl.Floor(target)
+}
+
+// Items returns all *KvPair in key order.
+func (r *ReadOnlySkipList[O, T]) Items() []*KvPair[O, T] {
+	if r == nil {
+		return nil
+	}
+
+	return r.sl.Items()
+}
+
+// Iterator returns an Iterator positioned at the least key, which has no method to mutate SkipList either.
+func (r *ReadOnlySkipList[O, T]) Iterator() *Iterator[O, T] {
+	if r == nil {
+		return nil
+	}
+
+	return r.sl.Iterator()
+}
+
+// Seek returns an Iterator positioned at the least key greater than or equal to target.
+func (r *ReadOnlySkipList[O, T]) Seek(target O) *Iterator[O, T] {
+	if r == nil {
+		return nil
+	}
+
+	return r.sl.Seek(target)
+}
@@ -0,0 +1,84 @@
+package skip_list
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipList_ReadOnly(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipList_ReadOnly 1", isConcurrent: false},
+		{name: "TestSkipList_ReadOnly 2", isConcurrent: true},
+		{name: "TestSkipList_ReadOnly 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, string](0, tt.isConcurrent, tt.opts...)
+			for i := 0; i < 10; i += 2 {
+				sl.Put(i, string(rune('a'+i)))
+			}
+			r := sl.ReadOnly()
+
+			if r.Cap() != 5 {
+				t.Errorf("Cap() = %v, want 5", r.Cap())
+			}
+			if val, exist := r.Get(4); !exist || val != "e" {
+				t.Errorf("Get(4) = %v, %v, want e, true", val, exist)
+			}
+			if got, want := r.Range(1, 5), []*KvPair[int, string]{{2, "c"}, {4, "e"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Range() = %v, want %v", got, want)
+			}
+			if kv, _ := r.Ceil(5); kv.Key() != 6 {
+				t.Errorf("Ceil(5) = %v, want 6", kv)
+			}
+			if kv, _ := r.Floor(5); kv.Key() != 4 {
+				t.Errorf("Floor(5) = %v, want 4", kv)
+			}
+			if got := r.Items(); !reflect.DeepEqual(got, sl.Items()) {
+				t.Errorf("Items() = %v, want %v", got, sl.Items())
+			}
+			var keys []int
+			for it := r.Seek(3); it.Valid(); it.Next() {
+				keys = append(keys, it.Key())
+			}
+			if want := []int{4, 6, 8}; !reflect.DeepEqual(keys, want) {
+				t.Errorf("Seek() walked %v, want %v", keys, want)
+			}
+
+			// the nodes are shared, not copied
+			sl.Put(5, "f")
+			sl.Delete(0)
+			if val, _ := r.Get(5); val != "f" || r.Cap() != 5 {
+				t.Errorf("Get(5) = %v, Cap() = %v after writes to SkipList", val, r.Cap())
+			}
+			if it := r.Iterator(); it.Key() != 2 {
+				t.Errorf("Iterator() is at %v, want 2", it.Key())
+			}
+		})
+	}
+
+	// no method mutates SkipList
+	typ := reflect.TypeOf(&ReadOnlySkipList[int, int]{})
+	for _, name := range []string{"Put", "Delete", "Clear", "DeleteIf", "DeleteRange", "CompareAndSwap", "DeleteAt", "PutSorted"} {
+		if _, ok := typ.MethodByName(name); ok {
+			t.Errorf("ReadOnlySkipList has method %v", name)
+		}
+	}
+	for i := 0; i < typ.NumMethod(); i++ {
+		if name := typ.Method(i).Name; !map[string]bool{
+			"Cap": true, "Get": true, "Range": true, "Ceil": true, "Floor": true, "Items": true, "Iterator": true, "Seek": true,
+		}[name] {
+			t.Errorf("ReadOnlySkipList has unexpected method %v", name)
+		}
+	}
+
+	var nilList *SkipList[int, int]
+	if r := nilList.ReadOnly(); r != nil || r.Cap() != 0 || r.Items() != nil || r.Iterator() != nil {
+		t.Errorf("ReadOnly() of a nil SkipList is not nil")
+	}
+}
@@ -0,0 +1,50 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// RefSkipList is a SkipList whose nodes hold *T instead of T, for large values which are costly to copy on every
+// Put, Get and update. Get and Put keep the value semantics of SkipList by copying, and GetRef and PutRef pass
+// the pointer without copying at the cost of an indirection. The other methods of SkipList are promoted and work on
+// *T, such as Range returning kv-pairs of pointers.
+type RefSkipList[O constraints.Ordered, T any] struct {
+	*SkipList[O, *T]
+}
+
+// NewSkipListRef returns a RefSkipList of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewSkipListRef[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *RefSkipList[O, T] {
+	sl := NewSkipList[O, *T](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &RefSkipList[O, T]{SkipList: sl}
+}
+
+// Get returns a copy of the value of key.
+func (sl *RefSkipList[O, T]) Get(key O) (val T, exist bool) {
+	if ref, ok := sl.GetRef(key); ok {
+		return *ref, true
+	}
+	return
+}
+
+// GetRef returns the pointer to the value of key held by the node, through which the value must not be modified
+// concurrently with readers of a concurrent SkipList.
+func (sl *RefSkipList[O, T]) GetRef(key O) (*T, bool) {
+	if sl == nil {
+		return nil, false
+	}
+	return sl.SkipList.Get(key)
+}
+
+// Put inserts key with a copy of val, or updates its value.
+func (sl *RefSkipList[O, T]) Put(key O, val T) {
+	sl.PutRef(key, &val)
+}
+
+// PutRef inserts key with ref held by the node without copying the value, or updates its value.
+func (sl *RefSkipList[O, T]) PutRef(key O, ref *T) {
+	if sl == nil {
+		return
+	}
+	sl.SkipList.Put(key, ref)
+}
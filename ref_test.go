@@ -0,0 +1,108 @@
+package skip_list
+
+import (
+	"testing"
+)
+
+// largeValue is a value costly to copy.
+type largeValue struct {
+	id      int
+	payload [4096]byte
+}
+
+func TestRefSkipList(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+	}
+	tests := []testCase{
+		{name: "TestRefSkipList 1", isConcurrent: false},
+		{name: "TestRefSkipList 2", isConcurrent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipListRef[int, largeValue](10, tt.isConcurrent)
+			for i := 0; i < 100; i++ {
+				sl.Put(i, largeValue{id: i})
+			}
+
+			// Put copies
+			val := largeValue{id: 1000}
+			sl.Put(1000, val)
+			val.id = -1
+			if got, ok := sl.Get(1000); !ok || got.id != 1000 {
+				t.Errorf("Get() = %v, %v after modifying the value put, want 1000", got.id, ok)
+			}
+
+			// Get copies
+			got, _ := sl.Get(1)
+			got.id = -1
+			if got, _ := sl.Get(1); got.id != 1 {
+				t.Errorf("Get() = %v after modifying a copy, want 1", got.id)
+			}
+
+			// PutRef and GetRef share the value
+			ref := &largeValue{id: 2000}
+			sl.PutRef(2000, ref)
+			if got, ok := sl.GetRef(2000); !ok || got != ref {
+				t.Errorf("GetRef() = %p, %v, want %p", got, ok, ref)
+			}
+			ref.id = 2001
+			if got, _ := sl.Get(2000); got.id != 2001 {
+				t.Errorf("Get() = %v after modifying the value by the pointer, want 2001", got.id)
+			}
+
+			// promoted methods
+			sl.Delete(0)
+			if sl.Cap() != 101 {
+				t.Errorf("Cap() = %v, want 101", sl.Cap())
+			}
+			for i, kv := range sl.Range(1, 5) {
+				if kv.Val().id != i+1 {
+					t.Errorf("Range()[%d] = %v, want %v", i, kv.Val().id, i+1)
+				}
+			}
+			if _, ok := sl.Get(0); ok {
+				t.Errorf("Get() of a deleted key exists")
+			}
+		})
+	}
+
+	var nilSl *RefSkipList[int, largeValue]
+	nilSl.Put(1, largeValue{})
+	if _, ok := nilSl.Get(1); ok {
+		t.Errorf("Get() of nil exists")
+	}
+	if sl := NewSkipListRef[int, int](-1, false); sl != nil {
+		t.Errorf("NewSkipListRef() = %v, want nil", sl)
+	}
+}
+
+// BenchmarkSkipListRef compares updating and reading large values by copies in a SkipList with passing pointers to
+// a RefSkipList.
+func BenchmarkSkipListRef(b *testing.B) {
+	const keys = 1024
+
+	b.Run("SkipList", func(b *testing.B) {
+		b.ReportAllocs()
+		sl := NewSkipList[int, largeValue](20, false)
+		var val largeValue
+		for i := 0; i < b.N; i++ {
+			val.id = i
+			sl.Put(i%keys, val)
+			got, _ := sl.Get(i % keys)
+			val.id = got.id
+		}
+	})
+	b.Run("RefSkipList", func(b *testing.B) {
+		b.ReportAllocs()
+		sl := NewSkipListRef[int, largeValue](20, false)
+		var vals = make([]largeValue, keys)
+		for i := 0; i < b.N; i++ {
+			vals[i%keys].id = i
+			sl.PutRef(i%keys, &vals[i%keys])
+			got, _ := sl.GetRef(i % keys)
+			vals[i%keys].id = got.id
+		}
+	})
+}
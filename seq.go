@@ -0,0 +1,87 @@
+package skip_list
+
+import "iter"
+
+// All returns an iter.Seq2 of the keys and values in ascending order of keys.
+// A concurrent SkipList is read locked during the iteration, so the loop body must not modify it.
+func (sl *SkipList[O, T]) All() iter.Seq2[O, T] {
+	return func(yield func(O, T) bool) {
+		if sl == nil {
+			return
+		}
+
+		if sl.isConcurrent {
+			sl.RLock()
+			defer sl.RUnlock()
+		}
+
+		for n := sl.head.next(); n != nil; n = n.next() {
+			if !yield(n.key, n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iter.Seq2 of the keys and values in descending order of keys, the reverse of All.
+// Without backward links, each step searches the predecessor from the top level like PrevFrom.
+// A concurrent SkipList is read locked during the iteration, so the loop body must not modify it.
+func (sl *SkipList[O, T]) Backward() iter.Seq2[O, T] {
+	return func(yield func(O, T) bool) {
+		if sl == nil {
+			return
+		}
+
+		if sl.isConcurrent {
+			sl.RLock()
+			defer sl.RUnlock()
+		}
+
+		for n := sl.last(); n != sl.head; n = sl.liveFloor(sl.lower(n.key)) {
+			if !yield(n.key, n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Windows returns an iter.Seq of the overlapping windows of size consecutive *KvPair in ascending order of keys,
+// one starting at each pair. The last size-1 windows are incomplete, as fewer than size pairs follow their first
+// pairs, and they are yielded shrinking to the last pair if partial, or dropped otherwise. Windows of size not positive
+// are none. A concurrent SkipList is read locked during the iteration, so the loop body must not modify it.
+// The windows share the pairs, and stay valid after the loop body returns.
+func (sl *SkipList[O, T]) Windows(size int, partial bool) iter.Seq[[]*KvPair[O, T]] {
+	return func(yield func([]*KvPair[O, T]) bool) {
+		if sl == nil || size <= 0 {
+			return
+		}
+
+		if sl.isConcurrent {
+			sl.RLock()
+			defer sl.RUnlock()
+		}
+
+		// windows are subslices of buf, which is replaced by a new one keeping the last size-1 pairs once full,
+		// so that no yielded window is overwritten
+		var buf = make([]*KvPair[O, T], 0, 2*size)
+		for n := sl.head.next(); n != nil; n = n.next() {
+			if len(buf) == cap(buf) {
+				buf = append(make([]*KvPair[O, T], 0, 2*size), buf[len(buf)-size+1:]...)
+			}
+			buf = append(buf, newKvPair(n.key, n.val))
+			if len(buf) >= size && !yield(buf[len(buf)-size:len(buf):len(buf)]) {
+				return
+			}
+		}
+		if !partial {
+			return
+		}
+
+		// buf holds the last size-1 pairs, or all of them if fewer, which start the incomplete windows
+		for i := max(0, len(buf)-size+1); i < len(buf); i++ {
+			if !yield(buf[i:len(buf):len(buf)]) {
+				return
+			}
+		}
+	}
+}
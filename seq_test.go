@@ -0,0 +1,208 @@
+package skip_list
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSkipList_Windows(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		cap          int
+		size         int
+		partial      bool
+		want         [][]int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_Windows 1", isConcurrent: false, cap: 5, size: 3, want: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}},
+		{name: "TestSkipList_Windows 2", isConcurrent: true, cap: 5, size: 3, want: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}},
+		{name: "TestSkipList_Windows 3", isConcurrent: false, cap: 3, size: 3, want: [][]int{{0, 1, 2}}},
+		{name: "TestSkipList_Windows 4", isConcurrent: false, cap: 2, size: 3, want: nil},
+		{name: "TestSkipList_Windows 5", isConcurrent: false, cap: 2, size: 3, partial: true, want: [][]int{{0, 1}, {1}}},
+		{name: "TestSkipList_Windows 6", isConcurrent: false, cap: 3, size: 3, partial: true, want: [][]int{{0, 1, 2}, {1, 2}, {2}}},
+		{name: "TestSkipList_Windows 7", isConcurrent: false, cap: 0, size: 3, partial: true, want: nil},
+		{name: "TestSkipList_Windows 8", isConcurrent: false, cap: 3, size: 1, want: [][]int{{0}, {1}, {2}}},
+		{name: "TestSkipList_Windows 9", isConcurrent: false, cap: 3, size: 0, partial: true, want: nil},
+		// Cap is not a multiple of size
+		{name: "TestSkipList_Windows 10", isConcurrent: false, cap: 7, size: 3, partial: true, want: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6}, {6}}},
+		{name: "TestSkipList_Windows 11", isConcurrent: true, cap: 7, size: 3, partial: false, want: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}}},
+		{name: "TestSkipList_Windows 12", isConcurrent: false, cap: 3, size: 1, partial: true, want: [][]int{{0}, {1}, {2}}},
+		// buf is replaced at 2*size pairs before the incomplete windows
+		{name: "TestSkipList_Windows 13", isConcurrent: false, cap: 9, size: 4, partial: true, want: [][]int{{0, 1, 2, 3}, {1, 2, 3, 4}, {2, 3, 4, 5}, {3, 4, 5, 6}, {4, 5, 6, 7}, {5, 6, 7, 8}, {6, 7, 8}, {7, 8}, {8}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, tt.isConcurrent)
+			for i := 0; i < tt.cap; i++ {
+				sl.Put(i, i*10)
+			}
+
+			var got [][]int
+			for w := range sl.Windows(tt.size, tt.partial) {
+				var keys []int
+				for _, kv := range w {
+					if kv.Val() != kv.Key()*10 {
+						t.Fatalf("window pair = %v", kv)
+					}
+					keys = append(keys, kv.Key())
+				}
+				got = append(got, keys)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Windows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Windows_MovingAverage(t *testing.T) {
+	sl := NewSkipList[int, float64](0, false)
+	for i := 0; i < 1000; i++ {
+		sl.Put(i, float64(i))
+	}
+
+	var (
+		windows [][]*KvPair[int, float64]
+		i       int
+	)
+	for w := range sl.Windows(4, false) {
+		var sum float64
+		for _, kv := range w {
+			sum += kv.Val()
+		}
+		if want := float64(i) + 1.5; sum/4 != want {
+			t.Fatalf("average of window %d = %v, want %v", i, sum/4, want)
+		}
+		windows = append(windows, w)
+		i++
+	}
+	if i != 997 {
+		t.Errorf("%d windows, want 997", i)
+	}
+
+	// the retained windows are not overwritten by the later ones
+	for i, w := range windows {
+		if len(w) != 4 || w[0].Key() != i || w[3].Key() != i+3 {
+			t.Fatalf("retained window %d = %v", i, w)
+		}
+	}
+
+	// breaking stops the iteration
+	var count int
+	for range sl.Windows(4, true) {
+		if count++; count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Errorf("%d windows before break, want 10", count)
+	}
+
+	var nilList *SkipList[int, float64]
+	for range nilList.Windows(1, true) {
+		t.Errorf("nil SkipList yields a window")
+	}
+}
+
+func TestSkipList_All_Backward(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+		keys         []int
+		deleted      []int
+		want         []int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_All_Backward 1", isConcurrent: false, keys: []int{3, 1, 4, 5, 9, 2, 6}, want: []int{1, 2, 3, 4, 5, 6, 9}},
+		{name: "TestSkipList_All_Backward 2", isConcurrent: true, keys: []int{3, 1, 4, 5, 9, 2, 6}, want: []int{1, 2, 3, 4, 5, 6, 9}},
+		{name: "TestSkipList_All_Backward 3", isConcurrent: false, opts: []Option{WithTombstones()}, keys: []int{3, 1, 4, 5, 9, 2, 6}, deleted: []int{1, 5, 9}, want: []int{2, 3, 4, 6}},
+		{name: "TestSkipList_All_Backward 4", isConcurrent: false, keys: []int{1}, want: []int{1}},
+		{name: "TestSkipList_All_Backward 5", isConcurrent: false, keys: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, tt.isConcurrent, tt.opts...)
+			for _, key := range tt.keys {
+				sl.Put(key, -key)
+			}
+			for _, key := range tt.deleted {
+				sl.Delete(key)
+			}
+
+			var forward, backward []int
+			for key, val := range sl.All() {
+				if val != -key {
+					t.Errorf("All() yields %v, %v", key, val)
+				}
+				forward = append(forward, key)
+			}
+			for key, val := range sl.Backward() {
+				if val != -key {
+					t.Errorf("Backward() yields %v, %v", key, val)
+				}
+				backward = append(backward, key)
+			}
+			if !reflect.DeepEqual(forward, tt.want) {
+				t.Errorf("All() = %v, want %v", forward, tt.want)
+			}
+			slices.Reverse(backward)
+			if !reflect.DeepEqual(backward, tt.want) {
+				t.Errorf("Backward() reversed = %v, want %v", backward, tt.want)
+			}
+		})
+	}
+
+	var nilSl *SkipList[int, int]
+	for range nilSl.Backward() {
+		t.Errorf("Backward() of a nil SkipList yields")
+	}
+}
+
+func TestSkipList_Backward_Break(t *testing.T) {
+	sl := NewSkipList[int, int](0, true)
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+
+	var keys []int
+	for key := range sl.Backward() {
+		if key < 95 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if want := []int{99, 98, 97, 96, 95}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Backward() = %v, want %v", keys, want)
+	}
+
+	// the read lock is released by break
+	sl.Put(100, 100)
+	for key := range sl.Backward() {
+		if key != 100 {
+			t.Errorf("Backward() starts at %v, want 100", key)
+		}
+		break
+	}
+}
+
+func TestSkipList_Windows_PartialBreak(t *testing.T) {
+	sl := NewSkipList[int, int](0, true)
+	for i := 0; i < 5; i++ {
+		sl.Put(i, i)
+	}
+
+	// breaking among the incomplete windows stops the iteration and releases the read lock
+	var lens []int
+	for w := range sl.Windows(3, true) {
+		if lens = append(lens, len(w)); len(w) == 2 {
+			break
+		}
+	}
+	if want := []int{3, 3, 3, 2}; !reflect.DeepEqual(lens, want) {
+		t.Errorf("lengths of windows = %v, want %v", lens, want)
+	}
+	sl.Put(5, 5)
+}
@@ -0,0 +1,128 @@
+package skip_list
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// Sharded partitions keys by range into independent concurrent SkipLists, so that writes to different shards do not contend,
+	// while ordered iteration is still possible by scanning the shards in order.
+	Sharded[O constraints.Ordered, T any] struct {
+		// shards[i] holds the keys in [splitPoints[i-1], splitPoints[i])
+		shards      []*SkipList[O, T]
+		splitPoints []O
+	}
+)
+
+// NewSharded returns a Sharded of shards SkipLists with at most maxLevel+1 levels and opts,
+// split by the ascending splitPoints, of which there must be shards-1. It returns nil if any argument is invalid.
+func NewSharded[O constraints.Ordered, T any](shards int, maxLevel int32, splitPoints []O, opts ...Option) *Sharded[O, T] {
+	if shards < 1 || len(splitPoints) != shards-1 {
+		return nil
+	}
+	for i := 1; i < len(splitPoints); i++ {
+		if splitPoints[i-1] >= splitPoints[i] {
+			return nil
+		}
+	}
+
+	s := &Sharded[O, T]{
+		shards:      make([]*SkipList[O, T], shards),
+		splitPoints: append([]O(nil), splitPoints...),
+	}
+	for i := range s.shards {
+		if s.shards[i] = NewSkipList[O, T](maxLevel, true, opts...); s.shards[i] == nil {
+			return nil
+		}
+	}
+	return s
+}
+
+// Cap returns the sum of the Cap of shards, which is not a snapshot under concurrent writes.
+func (s *Sharded[O, T]) Cap() int32 {
+	if s == nil {
+		return 0
+	}
+
+	var cap int32
+	for _, shard := range s.shards {
+		cap += shard.Cap()
+	}
+	return cap
+}
+
+func (s *Sharded[O, T]) Get(key O) (val T, exist bool) {
+	if s == nil {
+		return
+	}
+	return s.shard(key).Get(key)
+}
+
+func (s *Sharded[O, T]) Put(key O, val T) {
+	if s == nil {
+		return
+	}
+	s.shard(key).Put(key, val)
+}
+
+func (s *Sharded[O, T]) Delete(key O) {
+	if s == nil {
+		return
+	}
+	s.shard(key).Delete(key)
+}
+
+// Range searches the *KvPair of key in [start, end] across shards in key order.
+// Each shard is read under its own lock, so the result is not a snapshot under concurrent writes.
+func (s *Sharded[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if s == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	if start > end {
+		return res
+	}
+	for i, last := s.index(start), s.index(end); i <= last; i++ {
+		res = append(res, s.shards[i].Range(start, end)...)
+	}
+	return res
+}
+
+// Items returns all *KvPair in key order, read shard by shard as Range.
+func (s *Sharded[O, T]) Items() []*KvPair[O, T] {
+	if s == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0, s.Cap())
+	for _, shard := range s.shards {
+		res = append(res, shard.Items()...)
+	}
+	return res
+}
+
+// Stats returns the Stats of every shard in key order, by which skew can be detected.
+func (s *Sharded[O, T]) Stats() []Stats {
+	if s == nil {
+		return nil
+	}
+
+	var stats = make([]Stats, len(s.shards))
+	for i, shard := range s.shards {
+		stats[i] = shard.Stats()
+	}
+	return stats
+}
+
+// shard returns the SkipList holding key.
+func (s *Sharded[O, T]) shard(key O) *SkipList[O, T] {
+	return s.shards[s.index(key)]
+}
+
+// index returns the index of the shard holding key, which is the number of split points less than or equal to key.
+func (s *Sharded[O, T]) index(key O) int {
+	return sort.Search(len(s.splitPoints), func(i int) bool { return key < s.splitPoints[i] })
+}
@@ -0,0 +1,227 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNewSharded(t *testing.T) {
+	type testCase struct {
+		name        string
+		shards      int
+		maxLevel    int32
+		splitPoints []int
+		wantNil     bool
+	}
+	tests := []testCase{
+		{
+			name:     "TestNewSharded 1",
+			shards:   1,
+			maxLevel: 10,
+		},
+		{
+			name:        "TestNewSharded 2",
+			shards:      3,
+			maxLevel:    10,
+			splitPoints: []int{10, 20},
+		},
+		{
+			name:     "TestNewSharded 3",
+			shards:   0,
+			maxLevel: 10,
+			wantNil:  true,
+		},
+		{
+			name:        "TestNewSharded 4",
+			shards:      2,
+			maxLevel:    10,
+			splitPoints: []int{10, 20},
+			wantNil:     true,
+		},
+		{
+			name:        "TestNewSharded 5",
+			shards:      3,
+			maxLevel:    10,
+			splitPoints: []int{20, 20},
+			wantNil:     true,
+		},
+		{
+			name:        "TestNewSharded 6",
+			shards:      2,
+			maxLevel:    -1,
+			splitPoints: []int{10},
+			wantNil:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSharded[int, int](tt.shards, tt.maxLevel, tt.splitPoints)
+			if gotNil := s == nil; gotNil != tt.wantNil {
+				t.Errorf("NewSharded() nil = %v, want %v", gotNil, tt.wantNil)
+			}
+		})
+	}
+
+	var s *Sharded[int, int]
+	s.Put(1, 1)
+	s.Delete(1)
+	if _, ok := s.Get(1); ok || s.Cap() != 0 || s.Range(0, 1) != nil || s.Items() != nil || s.Stats() != nil {
+		t.Errorf("nil Sharded is not empty")
+	}
+}
+
+func TestSharded(t *testing.T) {
+	var (
+		r    = rand.New(rand.NewSource(1))
+		s    = NewSharded[int, int](4, 0, []int{100, 250, 400})
+		want = NewSkipList[int, int](0, false)
+	)
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(500)
+		switch r.Intn(4) {
+		case 0, 1:
+			s.Put(key, i)
+			want.Put(key, i)
+		case 2:
+			s.Delete(key)
+			want.Delete(key)
+		case 3:
+			got, gotOk := s.Get(key)
+			val, ok := want.Get(key)
+			if got != val || gotOk != ok {
+				t.Fatalf("Get(%v) = %v, %v, want %v, %v", key, got, gotOk, val, ok)
+			}
+			// spans up to 2 shards
+			if got, want := s.Range(key, key+200), want.Range(key, key+200); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Range(%v, %v) = %v, want %v", key, key+200, got, want)
+			}
+		}
+	}
+
+	t.Run("TestSharded 1", func(t *testing.T) {
+		if got := s.Cap(); got != want.Cap() {
+			t.Errorf("Cap() = %v, want %v", got, want.Cap())
+		}
+		if got := s.Items(); !reflect.DeepEqual(got, want.Items()) {
+			t.Errorf("Items() = %v, want %v", got, want.Items())
+		}
+		if got, want := s.Range(-100, 1000), want.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Range() = %v, want %v", got, want)
+		}
+		if got := s.Range(300, 200); len(got) != 0 {
+			t.Errorf("Range(300, 200) = %v, want empty", got)
+		}
+	})
+
+	t.Run("TestSharded 2", func(t *testing.T) {
+		stats := s.Stats()
+		if len(stats) != 4 {
+			t.Fatalf("Stats() has %v shards, want 4", len(stats))
+		}
+		bounds := []int{-1 << 31, 100, 250, 400, 1 << 31}
+		for i, st := range stats {
+			if want := int32(len(want.Range(bounds[i], bounds[i+1]-1))); st.Cap != want {
+				t.Errorf("Stats()[%v].Cap = %v, want %v", i, st.Cap, want)
+			}
+		}
+	})
+}
+
+func TestSharded_Stress(t *testing.T) {
+	const (
+		keys    = 400
+		workers = 16
+		ops     = 2000
+	)
+
+	var (
+		s  = NewSharded[int, int](4, 0, []int{100, 200, 300})
+		wg sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < ops; i++ {
+				key := r.Intn(keys)
+				switch r.Intn(4) {
+				case 0:
+					s.Put(key, key)
+				case 1:
+					s.Delete(key)
+				case 2:
+					if val, ok := s.Get(key); ok && val != key {
+						t.Errorf("Get(%v) = %v", key, val)
+					}
+				case 3:
+					kvs := s.Range(key, key+150)
+					for j := 1; j < len(kvs); j++ {
+						if kvs[j-1].key >= kvs[j].key {
+							t.Errorf("Range(%v) is not ascending", key)
+						}
+					}
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	for _, shard := range s.shards {
+		if err := shard.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkSharded(b *testing.B) {
+	const keys = 1 << 16
+
+	type list interface {
+		Put(key, val int)
+		Delete(key int)
+	}
+	var splitPoints []int
+	for i := 1; i < 16; i++ {
+		splitPoints = append(splitPoints, i*keys/16)
+	}
+	lists := []struct {
+		name string
+		new  func() list
+	}{
+		{"ConcurrentSkipList", func() list { return NewConcurrent[int, int](0) }},
+		{"Sharded", func() list { return NewSharded[int, int](16, 0, splitPoints) }},
+	}
+	for _, l := range lists {
+		for _, goroutines := range []int{1, 4, 16, 64} {
+			b.Run(fmt.Sprintf("%s/goroutines=%d", l.name, goroutines), func(b *testing.B) {
+				sl := l.new()
+				for i := 0; i < keys; i += 2 {
+					sl.Put(i, i)
+				}
+
+				var wg sync.WaitGroup
+				b.ResetTimer()
+				for g := 0; g < goroutines; g++ {
+					wg.Add(1)
+					go func(seed int64) {
+						defer wg.Done()
+						r := rand.New(rand.NewSource(seed))
+						// writes only
+						for i := 0; i < b.N/goroutines; i++ {
+							if key := r.Intn(keys); i%2 == 0 {
+								sl.Put(key, i)
+							} else {
+								sl.Delete(key)
+							}
+						}
+					}(int64(g))
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
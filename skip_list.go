@@ -1,8 +1,13 @@
 package skip_list
 
 import (
+	"errors"
+	"math"
+	"math/bits"
 	"math/rand"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/exp/constraints"
@@ -10,41 +15,234 @@ import (
 
 type (
 	SkipList[O constraints.Ordered, T any] struct {
+		// operation counters, first for the 64-bit alignment of atomic operations
+		gets, puts, deletes uint64
+
 		level, maxLevel, cap int32
 
-		// head node of SkipList
-		head *node[O, T]
+		// mark deleted nodes instead of unlinking them, tombstoneCap is the number of marked nodes
+		tombstones   bool
+		tombstoneCap int32
+
+		// maxLevel is tuned to the number of nodes
+		autoMaxLevel bool
+
+		// probability of promoting a node to the next level
+		p float64
+
+		// head node of SkipList, whose tower has the capacity of every level maxLevel may reach if pregrow
+		head    *node[O, T]
+		pregrow bool
 
 		// randomly generate level when inserting a node
 		r *rand.Rand
 
+		// replaces r to generate level if not nil
+		levelFunc func(n int) int
+
 		// reduce the pressure of GC
+		nodePool  bool
 		nodeCache sync.Pool
+		arena     *arena[O, T]
+		allocator Allocator[O, T]
+
+		// invoked after mutations
+		hooks Hooks[O, T]
+
+		// appended a record by mutations if attached
+		log *opLog[O, T]
+
+		// encodes and decodes values by MarshalBinary, SaveFile and the log
+		codec Codec[T]
+
+		// keys of each hash of values if WithValueIndex
+		values *valueIndex[O, T]
+
+		// predecessors on every level of the previous search if not nil
+		finger []*node[O, T]
+
+		// the number of insertions and deletions, by which Iterators detect modifications during iteration
+		mods uint64
+
+		// unlinked nodes are retired while iterators, the number of open Iterators, is not 0
+		safeIterators bool
+		iterators     int32
+		retired       []*node[O, T]
+
+		// version of writes, viewCount is the number of open ReadViews, and staleViews is set if any is garbage collected
+		version    uint64
+		views      *views
+		viewCount  int32
+		staleViews int32
+
+		// set if any node may have history
+		histories bool
+
+		// every write has a new version, and reads of version before watermark are discarded by GC
+		mvcc      bool
+		watermark uint64
 
 		// concurrent
 		isConcurrent bool
 		sync.RWMutex
 	}
 
-	node[O constraints.Ordered, T any] struct {
-		*KvPair[O, T]
+	node[O any, T any] struct {
+		// val precedes key so that a zero-size T, as of SkipSet, takes no padding
+		val T
+		key O
+
 		nextNodes []*node[O, T]
+
+		// tombstone, which is unlinked if retired for safeIterators
+		deleted, unlinked bool
+
+		// states before the writes of open ReadViews
+		history *record[T]
+
+		// nextNodes of a tower up to inlineLevels levels is stored inline to save an allocation
+		inline [inlineLevels]*node[O, T]
 	}
 )
 
-func NewSkipList[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool) *SkipList[O, T] {
-	if maxLevel <= 0 {
+// inlineLevels is the number of levels stored inline in a node.
+const inlineLevels = 3
+
+var ErrInvalidMaxLevel = errors.New("skip_list: invalid maxLevel")
+
+// autoMaxLevelSlack is the number of levels allowed above ceil(log2(n)) when maxLevel is tuned.
+const autoMaxLevelSlack = 2
+
+// NewSkipList returns a SkipList whose nodes have at most maxLevel+1 levels.
+// If maxLevel is 0, it is tuned to ceil(log2(n)) + autoMaxLevelSlack as the number of nodes n changes.
+func NewSkipList[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *SkipList[O, T] {
+	if maxLevel < 0 {
+		return nil
+	}
+
+	var autoMaxLevel = maxLevel == 0
+	if autoMaxLevel {
+		maxLevel = autoMaxLevelOf(0)
+	}
+
+	o := newOptions(opts...)
+	if !o.valid() {
+		return nil
+	}
+
+	hooks, ok := o.hooks.(Hooks[O, T])
+	if o.hooks != nil && !ok {
+		return nil
+	}
+	codec, ok := o.codec.(Codec[T])
+	if o.codec != nil && !ok {
 		return nil
 	}
+	if codec == nil {
+		codec = defaultCodec[T]()
+	}
+	allocator, ok := o.allocator.(Allocator[O, T])
+	if o.allocator != nil && !ok {
+		return nil
+	}
+	var values *valueIndex[O, T]
+	if o.valueIndex != nil {
+		funcs, ok := o.valueIndex.(valueIndexFuncs[T])
+		if !ok {
+			return nil
+		}
+		values = newValueIndex[O](funcs)
+	}
+
+	src := o.randSource
+	if src == nil {
+		src = rand.NewSource(time.Now().Unix())
+	}
+
+	var a *arena[O, T]
+	if o.arena && allocator == nil {
+		a = &arena[O, T]{}
+	}
+
+	var (
+		head   = newHead[O, T](o.pregrow, autoMaxLevel, maxLevel)
+		finger []*node[O, T]
+	)
+	if o.finger && !isConcurrent {
+		finger = []*node[O, T]{head}
+	}
+
+	return &SkipList[O, T]{
+		level:         1,
+		maxLevel:      maxLevel,
+		autoMaxLevel:  autoMaxLevel,
+		cap:           0,
+		p:             o.p,
+		head:          head,
+		pregrow:       o.pregrow,
+		r:             rand.New(src),
+		levelFunc:     o.levelFunc,
+		nodePool:      o.nodePool,
+		nodeCache:     sync.Pool{New: func() any { return &node[O, T]{} }},
+		arena:         a,
+		allocator:     allocator,
+		tombstones:    o.tombstones,
+		hooks:         hooks,
+		codec:         codec,
+		values:        values,
+		finger:        finger,
+		safeIterators: o.safeIterators,
+		mvcc:          o.mvcc,
+		isConcurrent:  isConcurrent,
+	}
+}
+
+// emptyCopy returns an empty SkipList with the same configuration, whose coin flips are time seeded.
+func (sl *SkipList[O, T]) emptyCopy() *SkipList[O, T] {
+	var maxLevel = sl.maxLevel
+	if sl.autoMaxLevel {
+		maxLevel = autoMaxLevelOf(0)
+	}
+
+	var a *arena[O, T]
+	if sl.arena != nil {
+		a = &arena[O, T]{}
+	}
+
+	var (
+		head   = newHead[O, T](sl.pregrow, sl.autoMaxLevel, maxLevel)
+		finger []*node[O, T]
+		values *valueIndex[O, T]
+	)
+	if sl.finger != nil {
+		finger = []*node[O, T]{head}
+	}
+	if sl.values != nil {
+		values = newValueIndex[O](sl.values.valueIndexFuncs)
+	}
 
 	return &SkipList[O, T]{
-		level:        1,
-		maxLevel:     maxLevel,
-		cap:          0,
-		head:         &node[O, T]{nextNodes: make([]*node[O, T], 1)},
-		r:            rand.New(rand.NewSource(time.Now().Unix())),
-		nodeCache:    sync.Pool{New: func() any { return &node[O, T]{} }},
-		isConcurrent: isConcurrent,
+		level:         1,
+		maxLevel:      maxLevel,
+		autoMaxLevel:  sl.autoMaxLevel,
+		cap:           0,
+		p:             sl.p,
+		head:          head,
+		pregrow:       sl.pregrow,
+		r:             rand.New(rand.NewSource(time.Now().Unix())),
+		levelFunc:     sl.levelFunc,
+		nodePool:      sl.nodePool,
+		nodeCache:     sync.Pool{New: func() any { return &node[O, T]{} }},
+		arena:         a,
+		allocator:     sl.allocator,
+		tombstones:    sl.tombstones,
+		hooks:         sl.hooks,
+		codec:         sl.codec,
+		values:        values,
+		finger:        finger,
+		safeIterators: sl.safeIterators,
+		mvcc:          sl.mvcc,
+		isConcurrent:  sl.isConcurrent,
 	}
 }
 
@@ -52,6 +250,12 @@ func (sl *SkipList[O, T]) Level() int32 {
 	if sl == nil {
 		return 0
 	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
 	return sl.level
 }
 
@@ -59,42 +263,1012 @@ func (sl *SkipList[O, T]) Cap() int32 {
 	if sl == nil {
 		return 0
 	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
 	return sl.cap
 }
 
+// Tombstones returns the number of nodes marked as tombstones, which are unlinked by Compact.
+func (sl *SkipList[O, T]) Tombstones() int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.tombstoneCap
+}
+
+// SetMaxLevel changes maxLevel in place, 0 tunes it as NewSkipList does.
+// Raising continues the coin flips of the nodes clamped by the previous maxLevel, lowering truncates the towers above it.
+func (sl *SkipList[O, T]) SetMaxLevel(maxLevel int32) error {
+	if sl == nil || maxLevel < 0 {
+		return ErrInvalidMaxLevel
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.autoMaxLevel = maxLevel == 0
+	if sl.autoMaxLevel {
+		maxLevel = autoMaxLevelOf(sl.cap)
+	}
+	sl.setMaxLevel(maxLevel)
+	return nil
+}
+
 func (sl *SkipList[O, T]) Get(key O) (val T, exist bool) {
-	if sl.Level() == 0 {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	if n := sl.get(key); n != nil && !n.deleted {
+		return n.val, true
+	}
+	return
+}
+
+// GetOrDefault returns the value of key, or def if not exist.
+func (sl *SkipList[O, T]) GetOrDefault(key O, def T) T {
+	if val, ok := sl.Get(key); ok {
+		return val
+	}
+	return def
+}
+
+// GetRef returns the pointer to the value of key to mutate it in place.
+// The pointer is invalidated once key is deleted, and mutating through it is neither guarded by the concurrent lock
+// nor hidden from open ReadViews.
+func (sl *SkipList[O, T]) GetRef(key O) (*T, bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.get(key); n != nil && !n.deleted {
+		return &n.val, true
+	}
+	return nil, false
+}
+
+func (sl *SkipList[O, T]) Put(key O, val T) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	sl.put(nil, key, val)
+}
+
+// PutAndLevel is Put returning the number of levels of the node of key, which is randomly determined if key is inserted.
+func (sl *SkipList[O, T]) PutAndLevel(key O, val T) int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	return int32(len(sl.put(nil, key, val).nextNodes))
+}
+
+// PutWithHint is Put starting the search from the node it is positioned at if its key is not greater than key,
+// which saves the descent when keys are put in ascending order, and returns an Iterator positioned at the node of key.
+// A nil, exhausted or misplaced it falls back to Put.
+func (sl *SkipList[O, T]) PutWithHint(it *Iterator[O, T], key O, val T) *Iterator[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	sl.count(&sl.puts)
+
+	var hint *node[O, T]
+	if it != nil && it.sl == sl && it.n != nil && !it.n.unlinked && it.n.key <= key {
+		hint = it.n
+	}
+	n := sl.put(hint, key, val)
+	return &Iterator[O, T]{sl: sl, n: n, mods: sl.mods}
+}
+
+// Clear deletes all nodes, and releases the memory of arena. It takes O(n) to invoke OnDelete for each node if set,
+// or to delete each node keeping the history WithMVCC.
+func (sl *SkipList[O, T]) Clear() {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	// one record for all nodes
+	sl.log.clear()
+	defer sl.log.mute()()
+
+	if sl.mvcc {
+		// keep the history
+		sl.deleteIf(func(O, T) bool { return true })
+		return
+	}
+
+	if sl.hooks.OnDelete != nil {
+		// before clear marks the nodes for open Iterators, which next skips
+		for n := sl.head.next(); n != nil; n = n.next() {
+			sl.deleted(n.key, n.val)
+		}
+	}
+	sl.clear()
+}
+
+// PrevFrom returns the *KvPair of up to n keys less than or equal to key in descending order, starting at the floor of key.
+// Without backward links, each step searches the predecessor from the top level.
+func (sl *SkipList[O, T]) PrevFrom(key O, n int) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	for m := sl.floor(key); m != sl.head && len(res) < n; m = sl.liveFloor(sl.lower(m.key)) {
+		res = append(res, newKvPair(m.key, m.val))
+	}
+	return res
+}
+
+// SetAll sets the value of every node to val in one pass over level 0, leaving keys and links untouched.
+func (sl *SkipList[O, T]) SetAll(val T) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.reclaimStaleViews()
+
+	for n := sl.head.next(); n != nil; n = n.next() {
+		sl.record(n)
+		old := n.val
+		n.val = val
+		sl.updated(n.key, old, val)
+	}
+}
+
+// CompareAndSwap swaps the value of key for new if it equals old by eq, and returns whether it is swapped.
+func (sl *SkipList[O, T]) CompareAndSwap(key O, old, new T, eq func(a, b T) bool) bool {
+	if sl == nil {
+		return false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if n := sl.get(key); n != nil && !n.deleted && eq(n.val, old) {
+		sl.record(n)
+		n.val = new
+		sl.updated(key, old, new)
+		sl.count(&sl.puts)
+		return true
+	}
+	return false
+}
+
+func (sl *SkipList[O, T]) Delete(key O) {
+	if sl == nil {
 		return
 	}
 
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+	if sl.delete(key, nil) {
+		sl.count(&sl.deletes)
+	}
+}
+
+// CompareAndDelete deletes the node of key if its value equals old by eq, and returns whether it is deleted.
+func (sl *SkipList[O, T]) CompareAndDelete(key O, old T, eq func(a, b T) bool) bool {
+	if sl == nil {
+		return false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if !sl.delete(key, func(n *node[O, T]) bool { return eq(n.val, old) }) {
+		return false
+	}
+	sl.count(&sl.deletes)
+	return true
+}
+
+// DeleteAt deletes the node it is positioned at and moves it to the next key, and returns whether it is deleted.
+// it is left not Valid if the last key is deleted, or stopped with ErrConcurrentModification as Next does.
+func (sl *SkipList[O, T]) DeleteAt(it *Iterator[O, T]) bool {
+	if sl == nil || !it.Valid() || it.sl != sl {
+		return false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if !it.consistent() {
+		return false
+	}
+
+	var (
+		n    = it.n
+		next = n.next()
+	)
+	if !sl.delete(n.key, func(m *node[O, T]) bool { return m == n }) {
+		return false
+	}
+	sl.count(&sl.deletes)
+	it.n, it.mods = next, sl.mods
+	return true
+}
+
+// Min returns *KvPair of the least key.
+func (sl *SkipList[O, T]) Min() (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
 	if sl.isConcurrent {
 		sl.RLock()
 		defer sl.RUnlock()
 	}
 
-	if n := sl.get(key); n != nil {
-		return n.val, true
+	if n := sl.head.next(); n != nil {
+		return newKvPair(n.key, n.val), true
+	}
+	return nil, false
+}
+
+// Max returns *KvPair of the greatest key.
+func (sl *SkipList[O, T]) Max() (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.last(); n != sl.head {
+		return newKvPair(n.key, n.val), true
+	}
+	return nil, false
+}
+
+// MinKey returns the least key in O(1) by level 0 of head, without allocating a *KvPair.
+func (sl *SkipList[O, T]) MinKey() (key O, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.head.next(); n != nil {
+		return n.key, true
+	}
+	return
+}
+
+// MaxKey returns the greatest key, without allocating a *KvPair.
+func (sl *SkipList[O, T]) MaxKey() (key O, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := sl.last(); n != sl.head {
+		return n.key, true
+	}
+	return
+}
+
+// PopMin deletes the node of the least key, and returns its *KvPair.
+func (sl *SkipList[O, T]) PopMin() (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	return sl.pop(sl.head.next())
+}
+
+// PopMax deletes the node of the greatest key, and returns its *KvPair.
+func (sl *SkipList[O, T]) PopMax() (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if n := sl.last(); n != sl.head {
+		return sl.pop(n)
+	}
+	return nil, false
+}
+
+// ExtractMin is PopMin returning the value only.
+func (sl *SkipList[O, T]) ExtractMin() (val T, exist bool) {
+	if kv, ok := sl.PopMin(); ok {
+		return kv.val, true
+	}
+	return
+}
+
+// ExtractMax is PopMax returning the value only.
+func (sl *SkipList[O, T]) ExtractMax() (val T, exist bool) {
+	if kv, ok := sl.PopMax(); ok {
+		return kv.val, true
+	}
+	return
+}
+
+// DeleteIf deletes the nodes for which pred returns true in a single pass, and returns the number of deleted nodes.
+func (sl *SkipList[O, T]) DeleteIf(pred func(key O, val T) bool) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	return sl.countDeleted(sl.deleteIf(pred))
+}
+
+// RetainIf deletes the nodes for which pred returns false in a single pass, and returns the number of deleted nodes.
+func (sl *SkipList[O, T]) RetainIf(pred func(key O, val T) bool) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	return sl.countDeleted(sl.deleteIf(func(key O, val T) bool { return !pred(key, val) }))
+}
+
+// DeleteRange deletes the nodes of key in [start, end] in one descent, and returns the number of deleted nodes.
+func (sl *SkipList[O, T]) DeleteRange(start, end O) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	// one record for all nodes
+	if start <= end {
+		sl.log.deleteRange(start, end)
+	}
+	defer sl.log.mute()()
+
+	return sl.countDeleted(sl.deleteRange(start, end, nil))
+}
+
+// DeleteKeys deletes the nodes of keys, and returns the number of deleted nodes. Ascending keys are deleted in one pass
+// resuming the search of each key from the predecessors of the previous one, and other keys are sorted in a copy first.
+func (sl *SkipList[O, T]) DeleteKeys(keys []O) int {
+	if sl == nil {
+		return 0
+	}
+
+	if !sort.SliceIsSorted(keys, func(i, j int) bool { return keys[i] < keys[j] }) {
+		keys = append([]O(nil), keys...)
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	return sl.countDeleted(sl.deleteKeys(keys))
+}
+
+// KeepLargest deletes the nodes of all but the n greatest keys, and returns the number of deleted nodes.
+// It walks level 0 to the greatest key to delete, and deletes the keys up to it as DeleteRange does.
+func (sl *SkipList[O, T]) KeepLargest(n int) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if n >= int(sl.cap) {
+		return 0
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	var (
+		first = sl.head.next()
+		last  = first
+	)
+	for i := int(sl.cap) - n; i > 1; i-- {
+		last = last.next()
+	}
+	return sl.countDeleted(sl.deleteRange(first.key, last.key, nil))
+}
+
+// PopRange deletes the nodes of key in [start, end] in one descent, and returns their *KvPair in key order.
+func (sl *SkipList[O, T]) PopRange(start, end O) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	sl.countDeleted(sl.deleteRange(start, end, func(n *node[O, T]) {
+		res = append(res, newKvPair(n.key, n.val))
+	}))
+	return res
+}
+
+// Range searches the *KvPair of key in [start, end].
+func (sl *SkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	return sl.RangeAppend(make([]*KvPair[O, T], 0), start, end)
+}
+
+// RangeAppend appends kv-pairs of keys in [start, end] to dst and returns the extended slice like append,
+// so a dst reused across calls saves growing a new slice of each Range.
+func (sl *SkipList[O, T]) RangeAppend(dst []*KvPair[O, T], start, end O) []*KvPair[O, T] {
+	if sl == nil {
+		return dst
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	// starting point
+	ceilingNode := sl.ceil(start)
+	if ceilingNode == nil {
+		return dst
+	}
+
+	// range
+	for n := ceilingNode; n != nil && n.key <= end; n = n.next() {
+		dst = append(dst, newKvPair(n.key, n.val))
+	}
+	return dst
+}
+
+// Between returns kv-pairs of keys in (low, high), excluding both bounds unlike Range.
+func (sl *SkipList[O, T]) Between(low, high O) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+	if low >= high {
+		return res
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	// starting point
+	n := sl.ceil(low)
+	if n != nil && n.key == low {
+		n = n.next()
+	}
+
+	// range
+	for ; n != nil && n.key < high; n = n.next() {
+		res = append(res, newKvPair(n.key, n.val))
+	}
+	return res
+}
+
+// AscendRange calls fn for each key in [start, end] in ascending order until fn returns false, without copying the kv-pairs.
+// fn must not modify sl.
+func (sl *SkipList[O, T]) AscendRange(start, end O, fn func(key O, val T) bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.ceil(start); n != nil && n.key <= end; n = n.next() {
+		if !fn(n.key, n.val) {
+			return
+		}
+	}
+}
+
+// AnyInRange returns whether any key is in [start, end], which only searches the least key greater than or equal to start.
+func (sl *SkipList[O, T]) AnyInRange(start, end O) bool {
+	if sl == nil {
+		return false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	ceilingNode := sl.ceil(start)
+	return ceilingNode != nil && ceilingNode.key <= end
+}
+
+// Items returns all *KvPair in key order.
+func (sl *SkipList[O, T]) Items() []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var res = make([]*KvPair[O, T], 0, sl.cap)
+	for n := sl.head.next(); n != nil; n = n.next() {
+		res = append(res, newKvPair(n.key, n.val))
+	}
+	return res
+}
+
+// Head returns the *KvPair of the n least keys in ascending order, or all of them if n is greater than Cap.
+func (sl *SkipList[O, T]) Head(n int) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n > int(sl.cap) {
+		n = int(sl.cap)
+	}
+	if n < 0 {
+		n = 0
+	}
+	var res = make([]*KvPair[O, T], 0, n)
+	for m := sl.head.next(); m != nil && len(res) < n; m = m.next() {
+		res = append(res, newKvPair(m.key, m.val))
+	}
+	return res
+}
+
+// Tail returns the *KvPair of the n greatest keys in ascending order, or all of them if n is greater than Cap.
+// Without backward links, it walks level 0 past the Cap-n least keys first.
+func (sl *SkipList[O, T]) Tail(n int) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n > int(sl.cap) {
+		n = int(sl.cap)
+	}
+	if n < 0 {
+		n = 0
+	}
+	var m = sl.head.next()
+	for skip := int(sl.cap) - n; skip > 0; skip-- {
+		m = m.next()
+	}
+	var res = make([]*KvPair[O, T], 0, n)
+	for ; m != nil && len(res) < n; m = m.next() {
+		res = append(res, newKvPair(m.key, m.val))
+	}
+	return res
+}
+
+// CopyRange returns a new SkipList of the *KvPair of key in [start, end], built in one pass as the range is sorted.
+func (sl *SkipList[O, T]) CopyRange(start, end O) *SkipList[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		cp   = sl.emptyCopy()
+		tail = cp.head
+	)
+	for n := sl.ceil(start); n != nil && n.key <= end; n = n.next() {
+		tail = cp.pushBack(tail, n.key, n.val)
+	}
+	cp.build()
+	return cp
+}
+
+// Ceil returns *KvPair of the least key greater than or equal to target.
+func (sl *SkipList[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if ceilingNode := sl.ceil(target); ceilingNode != nil {
+		return newKvPair(ceilingNode.key, ceilingNode.val), true
+	}
+	return nil, false
+}
+
+// Floor returns *KvPair of the greatest key less than or equal to target.
+func (sl *SkipList[O, T]) Floor(target O) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if floorNode := sl.floor(target); floorNode != sl.head {
+		return newKvPair(floorNode.key, floorNode.val), true
+	}
+	return nil, false
+}
+
+// CeilKey returns the least key greater than or equal to target, without allocating a *KvPair.
+func (sl *SkipList[O, T]) CeilKey(target O) (key O, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if ceilingNode := sl.ceil(target); ceilingNode != nil {
+		return ceilingNode.key, true
+	}
+	return
+}
+
+// FloorKey returns the greatest key less than or equal to target, without allocating a *KvPair.
+func (sl *SkipList[O, T]) FloorKey(target O) (key O, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if floorNode := sl.floor(target); floorNode != sl.head {
+		return floorNode.key, true
+	}
+	return
+}
+
+// FloorIndex returns the index of the greatest key less than or equal to target in key order, or -1 if not exist.
+// Without spans in the towers, it counts the keys on level 0.
+func (sl *SkipList[O, T]) FloorIndex(target O) int {
+	if sl == nil {
+		return -1
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.countUntil(func(key O) bool { return key > target }) - 1
+}
+
+// CeilIndex returns the index of the least key greater than or equal to target in key order, or Cap if not exist,
+// which is the insertion position of target as sort.Search reports for a sorted slice.
+// Without spans in the towers, it counts the keys on level 0.
+func (sl *SkipList[O, T]) CeilIndex(target O) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.countUntil(func(key O) bool { return key >= target })
+}
+
+// Rank returns the index of key in key order and whether it exists, or the index it would be inserted at if not exist.
+// Without spans in the towers, it counts the keys on level 0.
+func (sl *SkipList[O, T]) Rank(key O) (int, bool) {
+	if sl == nil {
+		return 0, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var rank int
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if n.key >= key {
+			return rank, n.key == key
+		}
+		rank++
+	}
+	return rank, false
+}
+
+// ApproxRank estimates the index of the least key greater than or equal to key in key order, as Rank returns,
+// without spans. It walks level h, half the levels in use, from the head, and then descends as a search does,
+// counting each step to the right on level l as the expected 1/p^l nodes it passes, where p is the probability of
+// promoting a node. This takes about sqrt(n) steps instead of the n of Rank. Steps on level 0 are exact and the error
+// comes from the random gaps between the towers passed, so the estimate is unbiased with a standard deviation of
+// about sqrt(rank/p^h), that is a relative error of about sqrt(1/(p^h*rank)) which is at most n^(-1/4) near the end.
+// Towers built by BulkLoad or Compact have no random gaps. Tombstones are counted.
+func (sl *SkipList[O, T]) ApproxRank(key O) int {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		rank float64
+		h    = (sl.level - 1) / 2
+		span = math.Pow(1/sl.p, float64(h))
+		move = sl.head
+	)
+	for l := h; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+			// search to the right
+			move = move.nextNodes[l]
+			rank += span
+		}
+
+		// search down
+		span *= sl.p
+	}
+	return int(math.Round(rank))
+}
+
+// RangeWithRank searches the RankedPair of key in [start, end].
+// Without spans in the towers, it counts the keys before start on level 0 and then keeps a running rank.
+func (sl *SkipList[O, T]) RangeWithRank(start, end O) []RankedPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]RankedPair[O, T], 0)
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		rank int
+		n    = sl.head.next()
+	)
+	for ; n != nil && n.key < start; n = n.next() {
+		rank++
+	}
+	for ; n != nil && n.key <= end; n = n.next() {
+		res = append(res, RankedPair[O, T]{KvPair: KvPair[O, T]{key: n.key, val: n.val}, rank: rank})
+		rank++
+	}
+	return res
+}
+
+// SelectRange returns the kv-pairs at indexes [i, j) in key order, where j is clamped to Cap and a negative i to 0.
+// Without spans in the towers, it skips the first i keys on level 0.
+func (sl *SkipList[O, T]) SelectRange(i, j int) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if i < 0 {
+		i = 0
+	}
+	if j > int(sl.cap) {
+		j = int(sl.cap)
+	}
+	if i >= j {
+		return make([]*KvPair[O, T], 0)
+	}
+
+	var (
+		res = make([]*KvPair[O, T], 0, j-i)
+		n   = sl.head.next()
+	)
+	for k := 0; k < i; k++ {
+		n = n.next()
+	}
+	for ; len(res) < j-i; n = n.next() {
+		res = append(res, newKvPair(n.key, n.val))
+	}
+	return res
+}
+
+// countUntil returns the number of keys before the first key for which stop returns true, which is monotone in key.
+func (sl *SkipList[O, T]) countUntil(stop func(key O) bool) int {
+	var count int
+	for n := sl.head.next(); n != nil && !stop(n.key); n = n.next() {
+		count++
+	}
+	return count
+}
+
+func (sl *SkipList[O, T]) get(key O) *node[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.finger != nil {
+		if n := sl.seek(key); n != nil && n.key == key {
+			return n
+		}
+		return nil
+	}
+
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		if move.nextNodes[l] != nil && move.nextNodes[l].key == key {
+			// exist
+			return move.nextNodes[l]
+		}
+
+		// search down
 	}
-	return
+	// not exist
+	return nil
 }
 
-func (sl *SkipList[O, T]) Put(key O, val T) {
-	if sl.Level() == 0 {
-		return
+// put inserts or updates key, searching from hint if not nil, whose key must not be greater than key.
+// It returns the node of key.
+func (sl *SkipList[O, T]) put(hint *node[O, T], key O, val T) *node[O, T] {
+	if sl.finger != nil {
+		// finger must stay the exact predecessors of its key, and saves the descent as well
+		hint = nil
 	}
 
-	if sl.isConcurrent {
-		sl.Lock()
-		defer sl.Unlock()
-	}
+	sl.reclaimStaleViews()
 
-	n := sl.get(key)
+	var n *node[O, T]
+	if hint != nil {
+		n = sl.getFrom(hint, key)
+	} else {
+		n = sl.get(key)
+	}
 	if n != nil {
+		sl.record(n)
+		if n.deleted {
+			// revive
+			n.deleted = false
+			sl.tombstoneCap--
+			sl.cap++
+			n.val = val
+			sl.inserted(key, val)
+			return n
+		}
 		// update
+		old := n.val
 		n.val = val
-		return
+		sl.updated(key, old, val)
+		return n
 	}
 
+	// tune before randomly determining level
+	sl.tune(sl.cap + 1)
+
 	// randomly determined level
 	var randL = sl.randLevel()
 
@@ -102,52 +1276,136 @@ func (sl *SkipList[O, T]) Put(key O, val T) {
 	sl.grow(randL + 1)
 
 	// new node
-	n, _ = sl.nodeCache.Get().(*node[O, T])
-	n.KvPair = newKvPair(key, val)
-	n.nextNodes = make([]*node[O, T], randL+1)
+	n = sl.newNode(key, val, randL+1)
+	if sl.keepsHistory() {
+		// absent before
+		n.history = &record[T]{version: sl.writeVersion(), deleted: true}
+		sl.histories = true
+	}
 
-	move := sl.head
-	for l := sl.Level() - 1; l >= 0; l-- {
+	switch {
+	case sl.finger != nil:
+		sl.seek(key)
+		for l := int32(0); l <= randL; l++ {
+			// insert
+			n.nextNodes[l] = sl.finger[l].nextNodes[l]
+			sl.finger[l].nextNodes[l] = n
+		}
+	case hint != nil && randL < int32(len(hint.nextNodes)):
+		// hint precedes key on every level of n
+		sl.linkFrom(hint, n, randL)
+	default:
+		sl.linkFrom(sl.head, n, randL)
+	}
+
+	sl.cap++
+	sl.inserted(key, val)
+	return n
+}
+
+// getFrom searches key starting from n, whose key must not be greater than key.
+func (sl *SkipList[O, T]) getFrom(n *node[O, T], key O) *node[O, T] {
+	if n.key == key {
+		return n
+	}
+
+	move := n
+	for l := len(n.nextNodes) - 1; l >= 0; l-- {
 		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
 			// search to the right
 			move = move.nextNodes[l]
 		}
 
+		if move.nextNodes[l] != nil && move.nextNodes[l].key == key {
+			// exist
+			return move.nextNodes[l]
+		}
+
+		// search down
+	}
+	// not exist
+	return nil
+}
+
+// linkFrom inserts newNode on levels [0, randL] searching from n, whose key must be less than the key of newNode
+// and whose tower must be higher than randL.
+func (sl *SkipList[O, T]) linkFrom(n, newNode *node[O, T], randL int32) {
+	move := n
+	for l := int32(len(n.nextNodes)) - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < newNode.key {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
 		if l <= randL {
 			// insert
-			n.nextNodes[l] = move.nextNodes[l]
-			move.nextNodes[l] = n
+			newNode.nextNodes[l] = move.nextNodes[l]
+			move.nextNodes[l] = newNode
 		}
 
 		// search down
 	}
+}
 
-	sl.cap++
+func (sl *SkipList[O, T]) clear() {
+	if sl.safeIterators && atomic.LoadInt32(&sl.iterators) > 0 {
+		// open Iterators skip the cleared nodes, and open ReadViews read their states before from the history
+		for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+			sl.record(n)
+			n.deleted, n.unlinked = true, true
+		}
+	}
+
+	sl.head = newHead[O, T](sl.pregrow, sl.autoMaxLevel, sl.maxLevel)
+	sl.resetFinger()
+	sl.mods++
+	sl.level = 1
+	sl.cap = 0
+	sl.tombstoneCap = 0
+	if sl.arena != nil {
+		sl.arena = &arena[O, T]{}
+	}
+	sl.values.rebuild(sl.head)
+
+	// tune
+	sl.tune(sl.cap)
 }
 
-func (sl *SkipList[O, T]) Delete(key O) {
-	if sl.Level() == 0 {
-		return
+// delete unlinks the node of key if cond is nil or returns true for it, and returns whether it is unlinked.
+func (sl *SkipList[O, T]) delete(key O, cond func(n *node[O, T]) bool) bool {
+	sl.reclaimStaleViews()
+
+	if sl.tombstones || sl.keepsHistory() {
+		n := sl.get(key)
+		if n == nil || n.deleted || (cond != nil && !cond(n)) {
+			return false
+		}
+		sl.bury(n)
+		sl.deleted(n.key, n.val)
+		return true
 	}
 
-	if sl.isConcurrent {
-		sl.Lock()
-		defer sl.Unlock()
+	if sl.finger != nil {
+		return sl.deleteFinger(key, cond)
 	}
 
 	var deleteNode *node[O, T]
 	move := sl.head
-	for l := sl.Level() - 1; l >= 0; l-- {
+	for l := sl.level - 1; l >= 0; l-- {
 		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
 			// search to the right
 			move = move.nextNodes[l]
 		}
 
 		if move.nextNodes[l] != nil && move.nextNodes[l].key == key {
-			// delete
 			if deleteNode == nil {
+				if cond != nil && !cond(move.nextNodes[l]) {
+					// condition is not met
+					return false
+				}
 				deleteNode = move.nextNodes[l]
 			}
+			// delete
 			move.nextNodes[l] = move.nextNodes[l].nextNodes[l]
 		}
 
@@ -156,107 +1414,272 @@ func (sl *SkipList[O, T]) Delete(key O) {
 
 	if deleteNode == nil {
 		// not exist
-		return
+		return false
 	}
-	deleteNode.nextNodes = nil
-	sl.nodeCache.Put(deleteNode)
+	val := deleteNode.val
+	sl.freeNode(deleteNode)
 
 	// cut
 	sl.cut()
 
 	sl.cap--
-}
 
-// Range searches the *KvPair of key in [start, end].
-func (sl *SkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
-	if sl.Level() == 0 {
-		return nil
-	}
+	// tune
+	sl.tune(sl.cap)
 
-	var res = make([]*KvPair[O, T], 0)
+	sl.deleted(key, val)
+	return true
+}
 
-	if sl.isConcurrent {
-		sl.RLock()
-		defer sl.RUnlock()
+// pop deletes n if not nil, and returns its *KvPair.
+func (sl *SkipList[O, T]) pop(n *node[O, T]) (*KvPair[O, T], bool) {
+	if n == nil {
+		return nil, false
 	}
 
-	// starting point
-	ceilingNode := sl.ceil(start)
-	if ceilingNode == nil {
-		return res
-	}
+	kv := newKvPair(n.key, n.val)
+	sl.delete(n.key, func(m *node[O, T]) bool { return m == n })
+	sl.count(&sl.deletes)
+	return kv, true
+}
 
-	// range
-	for n := ceilingNode; n != nil && n.key <= end; n = n.nextNodes[0] {
-		res = append(res, newKvPair(n.key, n.val))
+// last returns the node of the greatest key which is not deleted, or sl.head if not exist.
+func (sl *SkipList[O, T]) last() *node[O, T] {
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		// search down
 	}
-	return res
+	return sl.liveFloor(move)
 }
 
-// Ceil returns *KvPair of the least key greater than or equal to target.
-func (sl *SkipList[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
-	if sl.Level() == 0 {
-		return nil, false
+// deleteIf unlinks the nodes for which pred returns true while scanning level 0, and returns the number of them.
+func (sl *SkipList[O, T]) deleteIf(pred func(key O, val T) bool) int {
+	sl.reclaimStaleViews()
+
+	if sl.tombstones || sl.keepsHistory() {
+		var deleted int
+		for n := sl.head.next(); n != nil; n = n.next() {
+			if pred(n.key, n.val) {
+				sl.bury(n)
+				sl.deleted(n.key, n.val)
+				deleted++
+			}
+		}
+		return deleted
 	}
 
-	if sl.isConcurrent {
-		sl.RLock()
-		defer sl.RUnlock()
+	// nodes of finger may be unlinked
+	sl.resetFinger()
+
+	// update[l] is the last retained node on level l
+	var update = make([]*node[O, T], sl.level)
+	for l := range update {
+		update[l] = sl.head
 	}
 
-	if ceilingNode := sl.ceil(target); ceilingNode != nil {
-		return newKvPair(ceilingNode.key, ceilingNode.val), true
+	var deleted int
+	for n := sl.head.nextNodes[0]; n != nil; {
+		next := n.nextNodes[0]
+		if !n.deleted && pred(n.key, n.val) {
+			// delete
+			for l := range n.nextNodes {
+				update[l].nextNodes[l] = n.nextNodes[l]
+			}
+			sl.deleted(n.key, n.val)
+			sl.freeNode(n)
+			deleted++
+		} else {
+			// retain
+			for l := range n.nextNodes {
+				update[l] = n
+			}
+		}
+		n = next
 	}
-	return nil, false
+	if deleted == 0 {
+		return 0
+	}
+
+	// cut
+	sl.cut()
+
+	sl.cap -= int32(deleted)
+
+	// tune
+	sl.tune(sl.cap)
+	return deleted
 }
 
-// Floor returns *KvPair of the greatest key less than or equal to target.
-func (sl *SkipList[O, T]) Floor(target O) (*KvPair[O, T], bool) {
-	if sl.Level() == 0 {
-		return nil, false
-	}
+// deleteRange unlinks the nodes of key in [start, end], calling fn if not nil for each of them in key order,
+// and returns the number of them.
+func (sl *SkipList[O, T]) deleteRange(start, end O, fn func(n *node[O, T])) int {
+	sl.reclaimStaleViews()
 
-	if sl.isConcurrent {
-		sl.RLock()
-		defer sl.RUnlock()
+	if start > end {
+		return 0
 	}
 
-	if floorNode := sl.floor(target); floorNode != sl.head {
-		return newKvPair(floorNode.key, floorNode.val), true
+	if sl.tombstones || sl.keepsHistory() {
+		var deleted int
+		for n := sl.ceil(start); n != nil && n.key <= end; n = n.next() {
+			if fn != nil {
+				fn(n)
+			}
+			sl.bury(n)
+			sl.deleted(n.key, n.val)
+			deleted++
+		}
+		return deleted
 	}
-	return nil, false
-}
 
-func (sl *SkipList[O, T]) get(key O) *node[O, T] {
-	if sl.Level() == 0 {
-		return nil
-	}
+	// nodes of finger may be unlinked
+	sl.resetFinger()
 
-	move := sl.head
-	for l := sl.Level() - 1; l >= 0; l-- {
-		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+	// unlink [start, end] on every level
+	var (
+		first *node[O, T]
+		move  = sl.head
+	)
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < start {
 			// search to the right
 			move = move.nextNodes[l]
 		}
 
-		if move.nextNodes[l] != nil && move.nextNodes[l].key == key {
-			// exist
-			return move.nextNodes[l]
+		succ := move.nextNodes[l]
+		if l == 0 {
+			first = succ
+		}
+		for succ != nil && succ.key <= end {
+			succ = succ.nextNodes[l]
 		}
+		move.nextNodes[l] = succ
 
 		// search down
 	}
-	// not exist
-	return nil
+
+	// level 0 of the unlinked nodes is intact until freed
+	var deleted int
+	for n := first; n != nil && n.key <= end; {
+		next := n.nextNodes[0]
+		if fn != nil {
+			fn(n)
+		}
+		sl.deleted(n.key, n.val)
+		sl.freeNode(n)
+		deleted++
+		n = next
+	}
+	if deleted == 0 {
+		return 0
+	}
+
+	// cut
+	sl.cut()
+
+	sl.cap -= int32(deleted)
+
+	// tune
+	sl.tune(sl.cap)
+	return deleted
+}
+
+// deleteKeys unlinks the nodes of keys, which must be ascending, and returns the number of them.
+// The search of each key climbs from the predecessors of the previous key only up to the highest level it moves on,
+// as a finger search, so that close keys cost O(log(d)) of their distance d rather than a descent from the top.
+func (sl *SkipList[O, T]) deleteKeys(keys []O) int {
+	sl.reclaimStaleViews()
+
+	if sl.tombstones || sl.keepsHistory() {
+		var deleted int
+		for _, key := range keys {
+			if n := sl.get(key); n != nil && !n.deleted {
+				sl.bury(n)
+				sl.deleted(n.key, n.val)
+				deleted++
+			}
+		}
+		return deleted
+	}
+
+	// nodes of finger may be unlinked
+	sl.resetFinger()
+
+	// update[l] is the last node on level l whose key is less than the current key
+	var update = make([]*node[O, T], sl.level)
+	for l := range update {
+		update[l] = sl.head
+	}
+
+	var deleted int
+	for _, key := range keys {
+		// climb while the successor on the level above precedes key
+		var top int32
+		for top+1 < sl.level && update[top+1].nextNodes[top+1] != nil && update[top+1].nextNodes[top+1].key < key {
+			top++
+		}
+
+		move := update[top]
+		for l := top; l >= 0; l-- {
+			// resume from the predecessor of the previous key if it is ahead
+			if prev := update[l]; prev != sl.head && (move == sl.head || prev.key > move.key) {
+				move = prev
+			}
+			for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+				// search to the right
+				move = move.nextNodes[l]
+			}
+			update[l] = move
+
+			// search down
+		}
+
+		n := update[0].nextNodes[0]
+		if n == nil || n.key != key {
+			// not exist
+			continue
+		}
+		for l := range n.nextNodes {
+			update[l].nextNodes[l] = n.nextNodes[l]
+		}
+		sl.deleted(n.key, n.val)
+		sl.freeNode(n)
+		deleted++
+	}
+	if deleted == 0 {
+		return 0
+	}
+
+	// cut
+	sl.cut()
+
+	sl.cap -= int32(deleted)
+
+	// tune
+	sl.tune(sl.cap)
+	return deleted
+}
+
+// bury marks n as a tombstone, which is kept linked for open ReadViews even if not tombstones.
+func (sl *SkipList[O, T]) bury(n *node[O, T]) {
+	sl.record(n)
+	n.deleted = true
+	sl.tombstoneCap++
+	sl.cap--
 }
 
 func (sl *SkipList[O, T]) ceil(target O) *node[O, T] {
-	if sl.Level() == 0 {
+	if sl == nil {
 		return nil
 	}
 
 	move := sl.head
-	for l := sl.Level() - 1; l >= 0; l-- {
+	for l := sl.level - 1; l >= 0; l-- {
 		for move.nextNodes[l] != nil && move.nextNodes[l].key < target {
 			// search to the right
 			move = move.nextNodes[l]
@@ -264,22 +1687,22 @@ func (sl *SkipList[O, T]) ceil(target O) *node[O, T] {
 
 		if move.nextNodes[l] != nil && move.nextNodes[l].key == target {
 			// equal
-			return move.nextNodes[l]
+			return move.nextNodes[l].live()
 		}
 
 		// search down
 	}
 	// move.nextNodes[0] is ceil || move.nextNodes[0] == nil(tail node means ceil is not exist)
-	return move.nextNodes[0]
+	return move.nextNodes[0].live()
 }
 
 func (sl *SkipList[O, T]) floor(target O) *node[O, T] {
-	if sl.Level() == 0 {
+	if sl == nil {
 		return nil
 	}
 
 	move := sl.head
-	for l := sl.Level() - 1; l >= 0; l-- {
+	for l := sl.level - 1; l >= 0; l-- {
 		for move.nextNodes[l] != nil && move.nextNodes[l].key < target {
 			// search to the right
 			move = move.nextNodes[l]
@@ -287,39 +1710,255 @@ func (sl *SkipList[O, T]) floor(target O) *node[O, T] {
 
 		if move.nextNodes[l] != nil && move.nextNodes[l].key == target {
 			// equal
-			return move.nextNodes[l]
+			return sl.liveFloor(move.nextNodes[l])
 		}
 
 		// search down
 	}
 	// move is floor || move == sl.head(head node means floor is not exist)
+	return sl.liveFloor(move)
+}
+
+// liveFloor returns n or the greatest node before it which is not deleted, or sl.head if not exist.
+func (sl *SkipList[O, T]) liveFloor(n *node[O, T]) *node[O, T] {
+	for n != sl.head && n.deleted {
+		n = sl.lower(n.key)
+	}
+	return n
+}
+
+// lower returns the greatest node whose key is less than target, or sl.head if not exist.
+func (sl *SkipList[O, T]) lower(target O) *node[O, T] {
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < target {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		// search down
+	}
 	return move
 }
 
 func (sl *SkipList[O, T]) randLevel() int32 {
-	var randL int32
-	for sl.r.Intn(2) == 0 && randL < sl.maxLevel {
+	if sl.levelFunc != nil {
+		randL := sl.levelFunc(int(sl.cap))
+		if randL < 0 {
+			return 0
+		}
+		if randL > int(sl.maxLevel) {
+			return sl.maxLevel
+		}
+		return int32(randL)
+	}
+
+	var (
+		randL int32
+		limit = sl.levelLimit(sl.cap + 1)
+	)
+	for sl.r.Float64() < sl.p && randL < limit {
 		randL++
 	}
 	return randL
 }
 
+// levelLimit returns the highest level of the coin flips for n nodes, which is floor(log2(n)) bounded by maxLevel.
+// Thus the head of a small SkipList does not carry useless levels.
+func (sl *SkipList[O, T]) levelLimit(n int32) int32 {
+	if limit := int32(bits.Len32(uint32(n))) - 1; limit < sl.maxLevel {
+		return limit
+	}
+	return sl.maxLevel
+}
+
+// pushBack links a node of 1 level after tail, whose key must be greater than every key, and returns it as the new tail.
+// The towers are built by build after the last pushBack.
+func (sl *SkipList[O, T]) pushBack(tail *node[O, T], key O, val T) *node[O, T] {
+	n := sl.newNode(key, val, 1)
+	tail.nextNodes[0] = n
+	sl.cap++
+	return n
+}
+
+// build builds the towers of the nodes linked by pushBack.
+func (sl *SkipList[O, T]) build() {
+	if sl.autoMaxLevel {
+		sl.maxLevel = autoMaxLevelOf(sl.cap)
+	}
+	sl.compact()
+	sl.values.rebuild(sl.head)
+}
+
+// newNode returns a node of h levels.
+func (sl *SkipList[O, T]) newNode(key O, val T, h int32) *node[O, T] {
+	var n *node[O, T]
+	switch {
+	case sl.allocator != nil:
+		n = (*node[O, T])(sl.allocator.New())
+	case sl.arena != nil:
+		n = sl.arena.newNode()
+	case sl.nodePool:
+		n, _ = sl.nodeCache.Get().(*node[O, T])
+	default:
+		n = &node[O, T]{}
+	}
+	n.key, n.val = key, val
+	if h <= inlineLevels {
+		n.nextNodes = n.inline[:h]
+	} else {
+		n.nextNodes = sl.newTower(h)
+	}
+	return n
+}
+
+// newTower returns nextNodes of h levels out of inline.
+func (sl *SkipList[O, T]) newTower(h int32) []*node[O, T] {
+	if sl.arena != nil {
+		return sl.arena.newTower(h)
+	}
+	return make([]*node[O, T], h)
+}
+
+// freeNode recycles an unlinked node if nodePool or by the allocator, zeroing it so that no key or value leaks.
+// A node of arena is zeroed but not recycled.
+// If safeIterators, n is marked as a tombstone keeping its links, and retired until no Iterator is open.
+func (sl *SkipList[O, T]) freeNode(n *node[O, T]) {
+	if sl.safeIterators {
+		n.deleted, n.unlinked = true, true
+		if atomic.LoadInt32(&sl.iterators) > 0 {
+			sl.retired = append(sl.retired, n)
+			return
+		}
+	}
+
+	switch {
+	case sl.allocator != nil:
+		*n = node[O, T]{}
+		sl.allocator.Free((*Node[O, T])(n))
+	case sl.arena != nil:
+		*n = node[O, T]{}
+	case sl.nodePool:
+		*n = node[O, T]{}
+		sl.nodeCache.Put(n)
+	}
+}
+
+// next returns the next node on level 0 which is not deleted.
+func (n *node[O, T]) next() *node[O, T] {
+	return n.nextNodes[0].live()
+}
+
+// live returns n, or the next node on level 0 which is not deleted if n is deleted.
+func (n *node[O, T]) live() *node[O, T] {
+	for n != nil && n.deleted {
+		n = n.nextNodes[0]
+	}
+	return n
+}
+
 func (sl *SkipList[O, T]) grow(newL int32) {
-	if sl.Level() < newL {
-		sl.head.nextNodes = append(sl.head.nextNodes, make([]*node[O, T], newL-sl.Level())...)
+	if sl.level < newL {
+		sl.head.nextNodes = append(sl.head.nextNodes, make([]*node[O, T], newL-sl.level)...)
 		sl.level = newL
 	}
 }
 
 func (sl *SkipList[O, T]) cut() {
 	var dif int32
-	for l := sl.Level() - 1; l > 0; l-- {
+	for l := sl.level - 1; l > 0; l-- {
 		if sl.head.nextNodes[l] != nil {
 			break
 		}
 		dif++
 	}
-	sl.head.nextNodes = sl.head.nextNodes[:sl.Level()-dif]
+	sl.head.nextNodes = sl.head.nextNodes[:sl.level-dif]
 
 	sl.level -= dif
 }
+
+// tune adjusts maxLevel to n nodes and truncates the towers above it if maxLevel is tuned.
+func (sl *SkipList[O, T]) tune(n int32) {
+	if !sl.autoMaxLevel {
+		return
+	}
+
+	sl.setMaxLevel(autoMaxLevelOf(n))
+}
+
+func (sl *SkipList[O, T]) setMaxLevel(maxLevel int32) {
+	var oldMaxLevel = sl.maxLevel
+	sl.maxLevel = maxLevel
+	sl.resetFinger()
+
+	switch {
+	case maxLevel < oldMaxLevel && sl.level > maxLevel+1:
+		sl.truncate(maxLevel + 1)
+	case maxLevel > oldMaxLevel && sl.level == oldMaxLevel+1:
+		sl.promote(oldMaxLevel)
+	}
+}
+
+// promote continues the coin flips of the nodes on the top level fromL, which were clamped by the previous maxLevel.
+func (sl *SkipList[O, T]) promote(fromL int32) {
+	if sl.levelFunc != nil {
+		return
+	}
+
+	// tails[i] is the last node on level fromL+1+i
+	var tails []*node[O, T]
+	for n := sl.head.nextNodes[fromL]; n != nil; n = n.nextNodes[fromL] {
+		var (
+			randL = fromL
+			limit = sl.levelLimit(sl.cap)
+		)
+		for sl.r.Float64() < sl.p && randL < limit {
+			randL++
+		}
+
+		sl.grow(randL + 1)
+		for l := fromL + 1; l <= randL; l++ {
+			if int(l-fromL) > len(tails) {
+				tails = append(tails, sl.head)
+			}
+			n.nextNodes = append(n.nextNodes, nil)
+			tails[l-fromL-1].nextNodes[l] = n
+			tails[l-fromL-1] = n
+		}
+	}
+}
+
+// truncate cuts the towers above newL levels.
+func (sl *SkipList[O, T]) truncate(newL int32) {
+	for l := sl.level - 1; l >= newL; l-- {
+		for n := sl.head.nextNodes[l]; n != nil; {
+			next := n.nextNodes[l]
+			n.nextNodes = n.nextNodes[:l]
+			n = next
+		}
+	}
+	sl.head.nextNodes = sl.head.nextNodes[:newL]
+
+	sl.level = newL
+}
+
+// newHead returns a head node of 1 level. If pregrow, its tower has the capacity of maxLevel+1 levels,
+// or of the greatest maxLevel if it is tuned, so that growing the levels does not reallocate it.
+func newHead[O any, T any](pregrow, autoMaxLevel bool, maxLevel int32) *node[O, T] {
+	var levels int32 = 1
+	switch {
+	case pregrow && autoMaxLevel:
+		levels = autoMaxLevelOf(math.MaxInt32) + 1
+	case pregrow:
+		levels = maxLevel + 1
+	}
+	return &node[O, T]{nextNodes: make([]*node[O, T], 1, levels)}
+}
+
+// autoMaxLevelOf returns ceil(log2(n)) + autoMaxLevelSlack.
+func autoMaxLevelOf(n int32) int32 {
+	if n <= 1 {
+		return autoMaxLevelSlack
+	}
+	return int32(bits.Len32(uint32(n-1))) + autoMaxLevelSlack
+}
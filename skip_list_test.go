@@ -1,8 +1,12 @@
 package skip_list
 
 import (
+	"fmt"
 	"golang.org/x/exp/constraints"
+	"math"
+	"math/rand"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -207,6 +211,44 @@ func TestSkipList_Put(t *testing.T) {
 	})
 }
 
+func TestSkipList_PutAndLevel(t *testing.T) {
+	type testCase struct {
+		name string
+		key  int
+		want int32
+	}
+
+	// the n-th inserted key has n%4+1 levels, capped at maxLevel+1
+	var sl = NewSkipList[int, int](2, false, WithLevelFunc(func(n int) int { return n % 4 }))
+	tests := []testCase{
+		{"TestSkipList_PutAndLevel 1", 10, 1},
+		{"TestSkipList_PutAndLevel 2", 20, 2},
+		{"TestSkipList_PutAndLevel 3", 30, 3},
+		{"TestSkipList_PutAndLevel 4", 40, 3},
+		{"TestSkipList_PutAndLevel 5", 50, 1},
+		// update keeps the levels
+		{"TestSkipList_PutAndLevel 6", 30, 3},
+		{"TestSkipList_PutAndLevel 7", 20, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.PutAndLevel(tt.key, -tt.key); got != tt.want {
+				t.Errorf("PutAndLevel(%v) = %v, want %v", tt.key, got, tt.want)
+			}
+			if v, ok := sl.Get(tt.key); !ok || v != -tt.key {
+				t.Errorf("Get(%v) = %v, %v, want %v, true", tt.key, v, ok, -tt.key)
+			}
+		})
+	}
+
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if got := (*SkipList[int, int])(nil).PutAndLevel(1, 1); got != 0 {
+		t.Errorf("nil PutAndLevel() = %v, want 0", got)
+	}
+}
+
 func TestSkipList_Delete(t *testing.T) {
 	type args[O constraints.Ordered] struct {
 		key O
@@ -293,6 +335,141 @@ func TestSkipList_Range(t *testing.T) {
 	}
 }
 
+func TestSkipList_RangeAppend(t *testing.T) {
+	type testCase struct {
+		name       string
+		dst        []*KvPair[int, int]
+		start, end int
+		want       []*KvPair[int, int]
+	}
+
+	var sl = NewSkipList[int, int](10, true)
+	sl.Put(1, 1)
+	sl.Put(2, 2)
+	sl.Put(3, 3)
+
+	tests := []testCase{
+		{name: "TestSkipList_RangeAppend 1", dst: nil, start: 1, end: 2, want: []*KvPair[int, int]{{1, 1}, {2, 2}}},
+		{name: "TestSkipList_RangeAppend 2", dst: []*KvPair[int, int]{{0, 0}}, start: 2, end: 5, want: []*KvPair[int, int]{{0, 0}, {2, 2}, {3, 3}}},
+		{name: "TestSkipList_RangeAppend 3", dst: []*KvPair[int, int]{{0, 0}}, start: 4, end: 5, want: []*KvPair[int, int]{{0, 0}}},
+		{name: "TestSkipList_RangeAppend 4", dst: nil, start: 4, end: 5, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.RangeAppend(tt.dst, tt.start, tt.end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RangeAppend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the capacity of dst is reused
+	buf := make([]*KvPair[int, int], 0, 3)
+	if got := sl.RangeAppend(buf, 1, 3); &got[0] != &buf[:1][0] {
+		t.Errorf("RangeAppend() grew a new slice within the capacity of dst")
+	}
+
+	var nilSl *SkipList[int, int]
+	if got := nilSl.RangeAppend(buf, 1, 3); len(got) != 0 || cap(got) != 3 {
+		t.Errorf("RangeAppend() of nil = %v, want dst", got)
+	}
+}
+
+func BenchmarkSkipList_RangeAppend(b *testing.B) {
+	var sl = NewSkipList[int, int](16, false)
+	for i := 0; i < 1<<16; i++ {
+		sl.Put(i, i)
+	}
+
+	b.Run("Range", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			start := i % (1<<16 - 100)
+			sl.Range(start, start+99)
+		}
+	})
+	b.Run("RangeAppend", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []*KvPair[int, int]
+		for i := 0; i < b.N; i++ {
+			start := i % (1<<16 - 100)
+			buf = sl.RangeAppend(buf[:0], start, start+99)
+		}
+	})
+}
+
+func TestSkipList_Between(t *testing.T) {
+	type args[O constraints.Ordered] struct {
+		low  O
+		high O
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		args args[O]
+		want []*KvPair[O, T]
+	}
+
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 1; i <= 6; i++ {
+		sl.Put(i, i)
+	}
+	sl.Delete(4)
+
+	tests := []testCase[int, int]{
+		{
+			name: "TestSkipList_Between 1",
+			sl:   sl,
+			args: args[int]{1, 6},
+			want: []*KvPair[int, int]{{2, 2}, {3, 3}, {5, 5}},
+		},
+		{
+			name: "TestSkipList_Between 2",
+			sl:   sl,
+			args: args[int]{0, 9},
+			want: []*KvPair[int, int]{{1, 1}, {2, 2}, {3, 3}, {5, 5}, {6, 6}},
+		},
+		{
+			// adjacent keys
+			name: "TestSkipList_Between 3",
+			sl:   sl,
+			args: args[int]{2, 3},
+			want: []*KvPair[int, int]{},
+		},
+		{
+			// adjacent live keys around a deleted one
+			name: "TestSkipList_Between 4",
+			sl:   sl,
+			args: args[int]{3, 5},
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestSkipList_Between 5",
+			sl:   sl,
+			args: args[int]{3, 3},
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestSkipList_Between 6",
+			sl:   sl,
+			args: args[int]{5, 2},
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestSkipList_Between 7",
+			sl:   nil,
+			args: args[int]{1, 6},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.Between(tt.args.low, tt.args.high); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSkipList_Ceil(t *testing.T) {
 	type args[O constraints.Ordered] struct {
 		target O
@@ -425,3 +602,1719 @@ func TestSkipList_Floor(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipList_AutoMaxLevel(t *testing.T) {
+	const n = 1 << 20
+
+	var sl = NewSkipList[int, int](0, false)
+	for i := 0; i < n; i++ {
+		sl.Put(i, i)
+	}
+
+	log2 := func(n int32) float64 { return math.Log2(float64(n)) }
+	t.Run("TestSkipList_AutoMaxLevel 1", func(t *testing.T) {
+		if got := sl.Stats().MaxLevel; got != autoMaxLevelOf(n) {
+			t.Errorf("Stats().MaxLevel = %v, want %v", got, autoMaxLevelOf(n))
+		}
+		if got := float64(sl.Level()); math.Abs(got-log2(sl.Cap())) > autoMaxLevelSlack+1 {
+			t.Errorf("Level() = %v, want about %v", got, log2(sl.Cap()))
+		}
+	})
+
+	t.Run("TestSkipList_AutoMaxLevel 2", func(t *testing.T) {
+		var total int
+		for i := 0; i < n; i += 97 {
+			total += probeLength(sl, i)
+		}
+		if avg := float64(total) / float64(n/97+1); avg > 3*log2(n) {
+			t.Errorf("average probe length = %v, want at most %v", avg, 3*log2(n))
+		}
+	})
+
+	for i := 100; i < n; i++ {
+		sl.Delete(i)
+	}
+	t.Run("TestSkipList_AutoMaxLevel 3", func(t *testing.T) {
+		if got, want := sl.Level(), autoMaxLevelOf(100)+1; got > want {
+			t.Errorf("Level() = %v, want at most %v", got, want)
+		}
+		for i := 0; i < 100; i++ {
+			if v, ok := sl.Get(i); !ok || v != i {
+				t.Fatalf("Get(%v) = %v, %v, want %v, true", i, v, ok, i)
+			}
+		}
+	})
+}
+
+// probeLength returns the number of nodes visited while searching key.
+func probeLength[O constraints.Ordered, T any](sl *SkipList[O, T], key O) int {
+	var probes int
+	move := sl.head
+	for l := sl.Level() - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < key {
+			move = move.nextNodes[l]
+			probes++
+		}
+		probes++
+		if move.nextNodes[l] != nil && move.nextNodes[l].key == key {
+			break
+		}
+	}
+	return probes
+}
+
+func TestSkipList_CompareAndDelete(t *testing.T) {
+	type args[O constraints.Ordered, T any] struct {
+		key O
+		old T
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name    string
+		sl      *SkipList[O, T]
+		args    args[O, T]
+		want    bool
+		wantCap int32
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	sl.Put(1, 1)
+	sl.Put(2, 2)
+	sl.Put(3, 3)
+
+	tests := []testCase[int, int]{
+		{
+			name:    "TestSkipList_CompareAndDelete 1",
+			sl:      sl,
+			args:    args[int, int]{2, 20},
+			want:    false,
+			wantCap: 3,
+		},
+		{
+			name:    "TestSkipList_CompareAndDelete 2",
+			sl:      sl,
+			args:    args[int, int]{4, 4},
+			want:    false,
+			wantCap: 3,
+		},
+		{
+			name:    "TestSkipList_CompareAndDelete 3",
+			sl:      sl,
+			args:    args[int, int]{2, 2},
+			want:    true,
+			wantCap: 2,
+		},
+		{
+			name:    "TestSkipList_CompareAndDelete 4",
+			sl:      sl,
+			args:    args[int, int]{2, 2},
+			want:    false,
+			wantCap: 2,
+		},
+	}
+	eq := func(a, b int) bool { return a == b }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.CompareAndDelete(tt.args.key, tt.args.old, eq); got != tt.want {
+				t.Errorf("CompareAndDelete() = %v, want %v", got, tt.want)
+			}
+			if got := tt.sl.Cap(); got != tt.wantCap {
+				t.Errorf("Cap() = %v, want %v", got, tt.wantCap)
+			}
+		})
+	}
+
+	if got, want := sl.Range(0, 4), []*KvPair[int, int]{{1, 1}, {3, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+}
+
+func TestSkipList_SetMaxLevel(t *testing.T) {
+	const n = 1 << 14
+
+	var sl = NewSkipList[int, int](2, true)
+	for i := 0; i < n; i++ {
+		sl.Put(i, i)
+	}
+
+	t.Run("TestSkipList_SetMaxLevel 1", func(t *testing.T) {
+		if err := sl.SetMaxLevel(-1); err != ErrInvalidMaxLevel {
+			t.Errorf("SetMaxLevel() error = %v, want %v", err, ErrInvalidMaxLevel)
+		}
+		var nilSl *SkipList[int, int]
+		if err := nilSl.SetMaxLevel(10); err != ErrInvalidMaxLevel {
+			t.Errorf("SetMaxLevel() error = %v, want %v", err, ErrInvalidMaxLevel)
+		}
+	})
+
+	// searches run while restructuring
+	var (
+		done = make(chan struct{})
+		errs = make(chan error, 1)
+	)
+	go func() {
+		defer close(errs)
+		for i := 0; ; i = (i + 7) % n {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if v, ok := sl.Get(i); !ok || v != i {
+				errs <- fmt.Errorf("Get(%v) = %v, %v, want %v, true", i, v, ok, i)
+				return
+			}
+		}
+	}()
+
+	type testCase struct {
+		name      string
+		maxLevel  int32
+		wantLevel func(level int32) bool
+	}
+	tests := []testCase{
+		{
+			name:      "TestSkipList_SetMaxLevel 2",
+			maxLevel:  16,
+			wantLevel: func(level int32) bool { return level > 3 && level <= 17 },
+		},
+		{
+			name:      "TestSkipList_SetMaxLevel 3",
+			maxLevel:  4,
+			wantLevel: func(level int32) bool { return level == 5 },
+		},
+		{
+			name:      "TestSkipList_SetMaxLevel 4",
+			maxLevel:  1,
+			wantLevel: func(level int32) bool { return level == 2 },
+		},
+		{
+			name:      "TestSkipList_SetMaxLevel 5",
+			maxLevel:  0,
+			wantLevel: func(level int32) bool { return level > 2 && level <= autoMaxLevelOf(n)+1 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := sl.SetMaxLevel(tt.maxLevel); err != nil {
+				t.Fatalf("SetMaxLevel() error = %v", err)
+			}
+			s := sl.Stats()
+			if want := tt.maxLevel; want != 0 && s.MaxLevel != want {
+				t.Errorf("Stats().MaxLevel = %v, want %v", s.MaxLevel, want)
+			}
+			if !tt.wantLevel(s.Level) {
+				t.Errorf("Stats().Level = %v", s.Level)
+			}
+			if s.Cap != n {
+				t.Errorf("Stats().Cap = %v, want %v", s.Cap, n)
+			}
+			for i := 0; i < n; i++ {
+				if v, ok := sl.Get(i); !ok || v != i {
+					t.Fatalf("Get(%v) = %v, %v, want %v, true", i, v, ok, i)
+				}
+			}
+		})
+	}
+
+	close(done)
+	if err := <-errs; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSkipList_CompareAndSwap(t *testing.T) {
+	type args[O constraints.Ordered, T any] struct {
+		key O
+		old T
+		new T
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name      string
+		sl        *SkipList[O, T]
+		args      args[O, T]
+		want      bool
+		wantVal   T
+		wantExist bool
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	sl.Put(1, 1)
+	sl.Put(2, 2)
+
+	tests := []testCase[int, int]{
+		{
+			name:      "TestSkipList_CompareAndSwap 1",
+			sl:        sl,
+			args:      args[int, int]{1, 1, 10},
+			want:      true,
+			wantVal:   10,
+			wantExist: true,
+		},
+		{
+			name:      "TestSkipList_CompareAndSwap 2",
+			sl:        sl,
+			args:      args[int, int]{2, 1, 20},
+			want:      false,
+			wantVal:   2,
+			wantExist: true,
+		},
+		{
+			name:      "TestSkipList_CompareAndSwap 3",
+			sl:        sl,
+			args:      args[int, int]{3, 0, 30},
+			want:      false,
+			wantVal:   0,
+			wantExist: false,
+		},
+	}
+	eq := func(a, b int) bool { return a == b }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.CompareAndSwap(tt.args.key, tt.args.old, tt.args.new, eq); got != tt.want {
+				t.Errorf("CompareAndSwap() = %v, want %v", got, tt.want)
+			}
+			gotVal, gotExist := tt.sl.Get(tt.args.key)
+			if gotVal != tt.wantVal || gotExist != tt.wantExist {
+				t.Errorf("Get() = %v, %v, want %v, %v", gotVal, gotExist, tt.wantVal, tt.wantExist)
+			}
+		})
+	}
+}
+
+func TestSkipList_GetRef(t *testing.T) {
+	type value struct {
+		count int
+		tags  [8]string
+	}
+
+	var sl = NewSkipList[int, value](10, false)
+	sl.Put(1, value{count: 1})
+	sl.Put(2, value{count: 2})
+
+	t.Run("TestSkipList_GetRef 1", func(t *testing.T) {
+		ref, ok := sl.GetRef(1)
+		if !ok {
+			t.Fatalf("GetRef() exist = false, want true")
+		}
+		ref.count++
+		ref.tags[0] = "mutated"
+
+		want := value{count: 2}
+		want.tags[0] = "mutated"
+		if got, _ := sl.Get(1); !reflect.DeepEqual(got, want) {
+			t.Errorf("Get() = %v, want %v", got, want)
+		}
+		if got, _ := sl.Get(2); got.count != 2 {
+			t.Errorf("Get() = %v, want count 2", got)
+		}
+	})
+
+	t.Run("TestSkipList_GetRef 2", func(t *testing.T) {
+		if ref, ok := sl.GetRef(3); ref != nil || ok {
+			t.Errorf("GetRef() = %v, %v, want nil, false", ref, ok)
+		}
+	})
+}
+
+func BenchmarkSkipList_Get_Small(b *testing.B) {
+	for _, n := range []int{4, 16, 64} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			var sl = NewSkipList[int, int](20, false)
+			for i := 0; i < n; i++ {
+				sl.Put(i, i)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sl.Get(i % n)
+			}
+		})
+	}
+}
+
+func TestSkipList_LevelLimit(t *testing.T) {
+	var sl = NewSkipList[int, int](20, false)
+	for i := 1; i <= 10000; i++ {
+		sl.Put(i, i)
+		if got, want := sl.Level(), int32(math.Log2(float64(i)))+1; got > want {
+			t.Fatalf("Level() = %v with %v nodes, want at most %v", got, i, want)
+		}
+	}
+	if got := sl.Level(); got < 10 {
+		t.Errorf("Level() = %v with 10000 nodes, want at least 10", got)
+	}
+}
+
+func TestSkipList_DeleteIf(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name      string
+		sl        *SkipList[O, T]
+		pred      func(key O, val T) bool
+		want      int
+		wantRange []*KvPair[O, T]
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	for i := 1; i <= 6; i++ {
+		sl.Put(i, i*10)
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name:      "TestSkipList_DeleteIf 1",
+			sl:        sl,
+			pred:      func(key, val int) bool { return val > 40 },
+			want:      2,
+			wantRange: []*KvPair[int, int]{{1, 10}, {2, 20}, {3, 30}, {4, 40}},
+		},
+		{
+			name:      "TestSkipList_DeleteIf 2",
+			sl:        sl,
+			pred:      func(key, val int) bool { return false },
+			want:      0,
+			wantRange: []*KvPair[int, int]{{1, 10}, {2, 20}, {3, 30}, {4, 40}},
+		},
+		{
+			name:      "TestSkipList_DeleteIf 3",
+			sl:        sl,
+			pred:      func(key, val int) bool { return true },
+			want:      4,
+			wantRange: []*KvPair[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.DeleteIf(tt.pred); got != tt.want {
+				t.Errorf("DeleteIf() = %v, want %v", got, tt.want)
+			}
+			if got := tt.sl.Range(0, 100); !reflect.DeepEqual(got, tt.wantRange) {
+				t.Errorf("Range() = %v, want %v", got, tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestSkipList_RetainIf(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name      string
+		sl        *SkipList[O, T]
+		pred      func(key O, val T) bool
+		want      int
+		wantRange []*KvPair[O, T]
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	for i := 1; i <= 1000; i++ {
+		sl.Put(i, i)
+	}
+	var wantEven = make([]*KvPair[int, int], 0)
+	for i := 2; i <= 1000; i += 2 {
+		wantEven = append(wantEven, &KvPair[int, int]{i, i})
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name:      "TestSkipList_RetainIf 1",
+			sl:        sl,
+			pred:      func(key, val int) bool { return key%2 == 0 },
+			want:      500,
+			wantRange: wantEven,
+		},
+		{
+			name:      "TestSkipList_RetainIf 2",
+			sl:        sl,
+			pred:      func(key, val int) bool { return true },
+			want:      0,
+			wantRange: wantEven,
+		},
+		{
+			name:      "TestSkipList_RetainIf 3",
+			sl:        sl,
+			pred:      func(key, val int) bool { return false },
+			want:      500,
+			wantRange: []*KvPair[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.RetainIf(tt.pred); got != tt.want {
+				t.Errorf("RetainIf() = %v, want %v", got, tt.want)
+			}
+			if got := tt.sl.Range(0, 1000); !reflect.DeepEqual(got, tt.wantRange) {
+				t.Errorf("Range() = %v, want %v", got, tt.wantRange)
+			}
+			if got := tt.sl.Cap(); got != int32(len(tt.wantRange)) {
+				t.Errorf("Cap() = %v, want %v", got, len(tt.wantRange))
+			}
+			for _, kv := range tt.wantRange {
+				if v, ok := tt.sl.Get(kv.key); !ok || v != kv.val {
+					t.Fatalf("Get(%v) = %v, %v, want %v, true", kv.key, v, ok, kv.val)
+				}
+			}
+		})
+	}
+
+	if got := sl.Level(); got != 1 {
+		t.Errorf("Level() = %v, want 1", got)
+	}
+}
+
+func BenchmarkSkipList_Put(b *testing.B) {
+	b.ReportAllocs()
+	var sl = NewSkipList[int, int](20, false)
+	for i := 0; i < b.N; i++ {
+		sl.Put(i, i)
+	}
+}
+
+func TestSkipList_Items(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		want []*KvPair[O, T]
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	sl.Put(3, 3)
+	sl.Put(1, 1)
+	sl.Put(2, 2)
+
+	tests := []testCase[int, int]{
+		{
+			name: "TestSkipList_Items 1",
+			sl:   nil,
+			want: nil,
+		},
+		{
+			name: "TestSkipList_Items 2",
+			sl:   NewSkipList[int, int](10, false),
+			want: []*KvPair[int, int]{},
+		},
+		{
+			name: "TestSkipList_Items 3",
+			sl:   sl,
+			want: []*KvPair[int, int]{{1, 1}, {2, 2}, {3, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.Items(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Items() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_CopyRange(t *testing.T) {
+	type args[O constraints.Ordered] struct {
+		start O
+		end   O
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		args args[O]
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	for i := 0; i < 1000; i += 2 {
+		sl.Put(i, i)
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name: "TestSkipList_CopyRange 1",
+			sl:   sl,
+			args: args[int]{100, 300},
+		},
+		{
+			name: "TestSkipList_CopyRange 2",
+			sl:   sl,
+			args: args[int]{-100, 2000},
+		},
+		{
+			name: "TestSkipList_CopyRange 3",
+			sl:   sl,
+			args: args[int]{301, 301},
+		},
+		{
+			name: "TestSkipList_CopyRange 4",
+			sl:   NewSkipList[int, int](0, false),
+			args: args[int]{0, 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.sl.Range(tt.args.start, tt.args.end)
+			before := tt.sl.Items()
+
+			cp := tt.sl.CopyRange(tt.args.start, tt.args.end)
+			if got := cp.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("CopyRange().Items() = %v, want %v", got, want)
+			}
+			if err := cp.Validate(); err != nil {
+				t.Errorf("CopyRange().Validate() error = %v", err)
+			}
+
+			// independent of the source
+			cp.Put(tt.args.start+1, -1)
+			cp.DeleteIf(func(key, val int) bool { return key%4 == 0 })
+			if got := tt.sl.Items(); !reflect.DeepEqual(got, before) {
+				t.Errorf("Items() = %v, want %v", got, before)
+			}
+			if err := cp.Validate(); err != nil {
+				t.Errorf("CopyRange().Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSkipList_Clear(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+
+	tests := []testCase[int, int]{
+		{
+			name: "TestSkipList_Clear 1",
+			sl:   nil,
+		},
+		{
+			name: "TestSkipList_Clear 2",
+			sl:   sl,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.sl.Clear()
+			if got := tt.sl.Cap(); got != 0 {
+				t.Errorf("Cap() = %v, want 0", got)
+			}
+			if err := tt.sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if got := tt.sl.Items(); len(got) != 0 {
+				t.Errorf("Items() = %v, want empty", got)
+			}
+		})
+	}
+
+	sl.Put(1, 1)
+	if got, want := sl.Items(), []*KvPair[int, int]{{1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestSkipList_PutWithHint(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+	}
+	tests := []testCase{
+		{
+			name:     "TestSkipList_PutWithHint 1",
+			maxLevel: 16,
+		},
+		{
+			name:     "TestSkipList_PutWithHint 2",
+			maxLevel: 0,
+		},
+		{
+			name:     "TestSkipList_PutWithHint 3",
+			maxLevel: 16,
+			opts:     []Option{WithTombstones()},
+		},
+		{
+			name:     "TestSkipList_PutWithHint 4",
+			maxLevel: 16,
+			opts:     []Option{WithFinger()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				r     = rand.New(rand.NewSource(1))
+				sl    = NewSkipList[int, int](tt.maxLevel, false, append(tt.opts, WithRandSource(rand.NewSource(2)))...)
+				want  = NewSkipList[int, int](tt.maxLevel, false, tt.opts...)
+				other = NewSkipList[int, int](16, false)
+				it    *Iterator[int, int]
+				key   int
+			)
+			other.Put(0, 0)
+			for i := 0; i < 50000; i++ {
+				switch op := r.Intn(100); {
+				case op < 5:
+					// misplaced hint
+					it = other.Iterator()
+				case op < 10:
+					it = nil
+				case op < 20:
+					key = r.Intn(10000)
+				case op < 30:
+					sl.Delete(key)
+					want.Delete(key)
+					// it is invalidated
+					it = nil
+					continue
+				}
+				key += r.Intn(5)
+
+				it = sl.PutWithHint(it, key, i)
+				want.Put(key, i)
+				if !it.Valid() || it.Key() != key || it.Val() != i {
+					t.Fatalf("PutWithHint(%d, %d) is positioned at %v", key, i, it.n)
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got, want := sl.Items(), want.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	var sl *SkipList[int, int]
+	if got := sl.PutWithHint(nil, 1, 1); got != nil {
+		t.Errorf("PutWithHint() = %v, want nil", got)
+	}
+}
+
+func BenchmarkSkipList_PutWithHint(b *testing.B) {
+	const n = 1 << 20
+
+	bench := func(b *testing.B, hint bool) {
+		var (
+			sl *SkipList[int, int]
+			it *Iterator[int, int]
+		)
+		for i := 0; i < b.N; i++ {
+			if i%(n/2) == 0 {
+				b.StopTimer()
+				// merge the odd keys into the even ones
+				sl = NewSkipList[int, int](20, false)
+				for j := 0; j < n; j += 2 {
+					sl.Put(j, j)
+				}
+				it = nil
+				b.StartTimer()
+			}
+
+			key := i%(n/2)*2 + 1
+			if hint {
+				it = sl.PutWithHint(it, key, i)
+			} else {
+				sl.Put(key, i)
+			}
+		}
+	}
+	b.Run("SortedStream", func(b *testing.B) { bench(b, false) })
+	b.Run("SortedStreamWithHint", func(b *testing.B) { bench(b, true) })
+}
+
+func TestSkipList_DeleteAt(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestSkipList_DeleteAt 1",
+		},
+		{
+			name: "TestSkipList_DeleteAt 2",
+			opts: []Option{WithTombstones()},
+		},
+		{
+			name: "TestSkipList_DeleteAt 3",
+			opts: []Option{WithNodePool(), WithFinger()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, false, tt.opts...)
+			for i := 0; i < 1000; i++ {
+				sl.Put(i, -i)
+			}
+
+			// delete every other key in a single pass
+			var (
+				it   = sl.Iterator()
+				want = make([]*KvPair[int, int], 0)
+			)
+			for i := 0; it.Valid(); i++ {
+				if it.Key() != i {
+					t.Fatalf("Key() = %v, want %v", it.Key(), i)
+				}
+				if i%2 == 0 {
+					if !sl.DeleteAt(it) {
+						t.Fatalf("DeleteAt() = false at %v", i)
+					}
+				} else {
+					want = append(want, NewKvPair(i, -i))
+					it.Next()
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+
+			// delete the last key
+			it = sl.Seek(999)
+			if !sl.DeleteAt(it) || it.Valid() {
+				t.Errorf("DeleteAt() leaves it Valid = %v after the last key", it.Valid())
+			}
+			if sl.DeleteAt(it) {
+				t.Errorf("DeleteAt() = true for an exhausted Iterator")
+			}
+			if got := sl.Cap(); got != 499 {
+				t.Errorf("Cap() = %v, want 499", got)
+			}
+		})
+	}
+
+	var sl, other = NewSkipList[int, int](10, false), NewSkipList[int, int](10, false)
+	sl.Put(1, 1)
+	other.Put(1, 1)
+	if other.DeleteAt(sl.Iterator()) || other.Cap() != 1 || sl.Cap() != 1 {
+		t.Errorf("DeleteAt() deletes through an Iterator of another SkipList")
+	}
+}
+
+func TestSkipList_AnyInRange(t *testing.T) {
+	type args[O constraints.Ordered] struct {
+		start O
+		end   O
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		args args[O]
+		want bool
+	}
+
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 10; i <= 50; i += 10 {
+		sl.Put(i, i)
+	}
+	sl.Delete(30)
+
+	tests := []testCase[int, int]{
+		{"TestSkipList_AnyInRange 1", sl, args[int]{11, 19}, false},
+		{"TestSkipList_AnyInRange 2", sl, args[int]{15, 20}, true},
+		{"TestSkipList_AnyInRange 3", sl, args[int]{20, 20}, true},
+		{"TestSkipList_AnyInRange 4", sl, args[int]{25, 35}, false},
+		{"TestSkipList_AnyInRange 5", sl, args[int]{-5, 9}, false},
+		{"TestSkipList_AnyInRange 6", sl, args[int]{51, 100}, false},
+		{"TestSkipList_AnyInRange 7", sl, args[int]{0, 100}, true},
+		{"TestSkipList_AnyInRange 8", sl, args[int]{40, 10}, false},
+		{"TestSkipList_AnyInRange 9", NewSkipList[int, int](10, false), args[int]{0, 100}, false},
+		{"TestSkipList_AnyInRange 10", nil, args[int]{0, 100}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.AnyInRange(tt.args.start, tt.args.end); got != tt.want {
+				t.Errorf("AnyInRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_GetOrDefault(t *testing.T) {
+	type args[O constraints.Ordered, T any] struct {
+		key O
+		def T
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *SkipList[O, T]
+		args args[O, T]
+		want T
+	}
+
+	var sl = NewSkipList[string, int](10, false, WithTombstones())
+	sl.Put("a", 1)
+	sl.Put("b", 0)
+	sl.Put("c", 3)
+	sl.Delete("c")
+
+	tests := []testCase[string, int]{
+		{"TestSkipList_GetOrDefault 1", sl, args[string, int]{"a", -1}, 1},
+		{"TestSkipList_GetOrDefault 2", sl, args[string, int]{"b", -1}, 0},
+		{"TestSkipList_GetOrDefault 3", sl, args[string, int]{"c", -1}, -1},
+		{"TestSkipList_GetOrDefault 4", sl, args[string, int]{"d", -1}, -1},
+		{"TestSkipList_GetOrDefault 5", nil, args[string, int]{"a", -1}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.GetOrDefault(tt.args.key, tt.args.def); got != tt.want {
+				t.Errorf("GetOrDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipList_CeilKey_FloorKey(t *testing.T) {
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 0; i <= 100; i += 10 {
+		sl.Put(i, i)
+	}
+	sl.Delete(50)
+
+	for target := -5; target <= 105; target++ {
+		t.Run(fmt.Sprintf("TestSkipList_CeilKey_FloorKey %d", target), func(t *testing.T) {
+			ceil, ceilOk := sl.Ceil(target)
+			if got, ok := sl.CeilKey(target); ok != ceilOk || (ok && got != ceil.Key()) {
+				t.Errorf("CeilKey(%v) = %v, %v, want %v, %v", target, got, ok, ceil, ceilOk)
+			}
+			floor, floorOk := sl.Floor(target)
+			if got, ok := sl.FloorKey(target); ok != floorOk || (ok && got != floor.Key()) {
+				t.Errorf("FloorKey(%v) = %v, %v, want %v, %v", target, got, ok, floor, floorOk)
+			}
+		})
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { sl.CeilKey(51); sl.FloorKey(51) }); allocs != 0 {
+		t.Errorf("CeilKey() and FloorKey() allocate %v times, want 0", allocs)
+	}
+
+	var nilSl *SkipList[int, int]
+	if _, ok := nilSl.CeilKey(1); ok {
+		t.Errorf("CeilKey() = true for nil")
+	}
+	if _, ok := nilSl.FloorKey(1); ok {
+		t.Errorf("FloorKey() = true for nil")
+	}
+}
+
+func TestSkipList_MinKey_MaxKey(t *testing.T) {
+	type testCase struct {
+		name      string
+		keys      []int
+		deleted   []int
+		opts      []Option
+		wantExist bool
+		wantMin   int
+		wantMax   int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_MinKey_MaxKey 1", keys: nil, wantExist: false},
+		{name: "TestSkipList_MinKey_MaxKey 2", keys: []int{5}, wantExist: true, wantMin: 5, wantMax: 5},
+		{name: "TestSkipList_MinKey_MaxKey 3", keys: []int{3, -7, 12, 0}, wantExist: true, wantMin: -7, wantMax: 12},
+		{name: "TestSkipList_MinKey_MaxKey 4", keys: []int{3, -7, 12, 0}, deleted: []int{-7, 12}, opts: []Option{WithTombstones()}, wantExist: true, wantMin: 0, wantMax: 3},
+		{name: "TestSkipList_MinKey_MaxKey 5", keys: []int{3, -7}, deleted: []int{3, -7}, wantExist: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, true, tt.opts...)
+			for _, key := range tt.keys {
+				sl.Put(key, key)
+			}
+			for _, key := range tt.deleted {
+				sl.Delete(key)
+			}
+
+			if got, ok := sl.MinKey(); ok != tt.wantExist || got != tt.wantMin {
+				t.Errorf("MinKey() = %v, %v, want %v, %v", got, ok, tt.wantMin, tt.wantExist)
+			}
+			if got, ok := sl.MaxKey(); ok != tt.wantExist || got != tt.wantMax {
+				t.Errorf("MaxKey() = %v, %v, want %v, %v", got, ok, tt.wantMax, tt.wantExist)
+			}
+			if allocs := testing.AllocsPerRun(100, func() { sl.MinKey(); sl.MaxKey() }); allocs != 0 {
+				t.Errorf("MinKey() and MaxKey() allocate %v times, want 0", allocs)
+			}
+		})
+	}
+
+	var nilSl *SkipList[int, int]
+	if _, ok := nilSl.MinKey(); ok {
+		t.Errorf("MinKey() = true for nil")
+	}
+	if _, ok := nilSl.MaxKey(); ok {
+		t.Errorf("MaxKey() = true for nil")
+	}
+}
+
+func BenchmarkSkipList_CeilKey_FloorKey(b *testing.B) {
+	var sl = NewSkipList[int, int](16, false)
+	for i := 0; i < 1<<16; i += 2 {
+		sl.Put(i, i)
+	}
+
+	b.Run("Ceil", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sl.Ceil(i % (1 << 16))
+		}
+	})
+	b.Run("CeilKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sl.CeilKey(i % (1 << 16))
+		}
+	})
+	b.Run("FloorKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sl.FloorKey(i % (1 << 16))
+		}
+	})
+}
+
+func TestSkipList_ExtractMin_ExtractMax(t *testing.T) {
+	type testCase struct {
+		name    string
+		opts    []Option
+		extract func(sl *SkipList[int, string]) (string, bool)
+		want    []string
+	}
+	var (
+		extractMin = func(sl *SkipList[int, string]) (string, bool) { return sl.ExtractMin() }
+		extractMax = func(sl *SkipList[int, string]) (string, bool) { return sl.ExtractMax() }
+	)
+	tests := []testCase{
+		{"TestSkipList_ExtractMin_ExtractMax 1", nil, extractMin, []string{"a", "b", "c", "e"}},
+		{"TestSkipList_ExtractMin_ExtractMax 2", nil, extractMax, []string{"e", "c", "b", "a"}},
+		{"TestSkipList_ExtractMin_ExtractMax 3", []Option{WithTombstones()}, extractMin, []string{"a", "b", "c", "e"}},
+		{"TestSkipList_ExtractMin_ExtractMax 4", []Option{WithTombstones()}, extractMax, []string{"e", "c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, string](0, false, tt.opts...)
+			for i, val := range []string{"c", "a", "e", "b", "d"} {
+				sl.Put(int(val[0]), val)
+				if i == 4 {
+					sl.Delete(int(val[0]))
+				}
+			}
+
+			var got []string
+			for {
+				val, ok := tt.extract(sl)
+				if !ok {
+					break
+				}
+				got = append(got, val)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extracted %v, want %v", got, tt.want)
+			}
+			if err := sl.Validate(); err != nil || sl.Cap() != 0 {
+				t.Errorf("Validate() error = %v, Cap() = %v", err, sl.Cap())
+			}
+		})
+	}
+
+	var sl *SkipList[int, int]
+	if _, ok := sl.PopMin(); ok {
+		t.Errorf("PopMin() = true for nil")
+	}
+	if _, ok := sl.PopMax(); ok {
+		t.Errorf("PopMax() = true for nil")
+	}
+}
+
+func TestSkipList_PopMin_PopMax(t *testing.T) {
+	var sl = NewSkipList[int, int](10, false)
+	for i := 1; i <= 5; i++ {
+		sl.Put(i, i*10)
+	}
+
+	if got, ok := sl.PopMin(); !ok || !reflect.DeepEqual(got, NewKvPair(1, 10)) {
+		t.Errorf("PopMin() = %v, %v, want 1: 10", got, ok)
+	}
+	if got, ok := sl.PopMax(); !ok || !reflect.DeepEqual(got, NewKvPair(5, 50)) {
+		t.Errorf("PopMax() = %v, %v, want 5: 50", got, ok)
+	}
+	if got, want := sl.Items(), []*KvPair[int, int]{{2, 20}, {3, 30}, {4, 40}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+}
+
+func TestSkipList_PopRange(t *testing.T) {
+	type testCase struct {
+		name       string
+		opts       []Option
+		start, end int
+	}
+	tests := []testCase{
+		{
+			name:  "TestSkipList_PopRange 1",
+			start: 20,
+			end:   60,
+		},
+		{
+			name:  "TestSkipList_PopRange 2",
+			start: -10,
+			end:   200,
+		},
+		{
+			name:  "TestSkipList_PopRange 3",
+			start: 61,
+			end:   61,
+		},
+		{
+			name:  "TestSkipList_PopRange 4",
+			start: 60,
+			end:   20,
+		},
+		{
+			name:  "TestSkipList_PopRange 5",
+			opts:  []Option{WithFinger(), WithNodePool()},
+			start: 0,
+			end:   50,
+		},
+		{
+			name:  "TestSkipList_PopRange 6",
+			opts:  []Option{WithTombstones()},
+			start: 30,
+			end:   98,
+		},
+		{
+			name:  "TestSkipList_PopRange 7",
+			opts:  []Option{WithSafeIterators(), WithArena()},
+			start: 10,
+			end:   90,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, false, tt.opts...)
+			for i := 0; i < 100; i += 2 {
+				sl.Put(i, -i)
+			}
+			sl.Get(40)
+			it := sl.Seek(tt.start)
+
+			var (
+				want   = sl.Range(tt.start, tt.end)
+				remain = make([]*KvPair[int, int], 0)
+			)
+			for _, kv := range sl.Items() {
+				if kv.key < tt.start || kv.key > tt.end {
+					remain = append(remain, kv)
+				}
+			}
+
+			if got := sl.PopRange(tt.start, tt.end); !reflect.DeepEqual(got, want) {
+				t.Errorf("PopRange() = %v, want %v", got, want)
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, remain) {
+				t.Errorf("Items() = %v, want %v", got, remain)
+			}
+			if got := sl.Cap(); got != int32(len(remain)) {
+				t.Errorf("Cap() = %v, want %v", got, len(remain))
+			}
+			for _, kv := range want {
+				if _, ok := sl.Get(kv.key); ok {
+					t.Errorf("Get(%v) exists after PopRange()", kv.key)
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			it.Close()
+		})
+	}
+}
+
+func TestSkipList_DeleteRange(t *testing.T) {
+	var (
+		deleted []int
+		sl      = NewSkipList[int, int](10, false, WithHooks(Hooks[int, int]{
+			OnDelete: func(key, _ int) { deleted = append(deleted, key) },
+		}))
+	)
+	for i := 1; i <= 10; i++ {
+		sl.Put(i, i)
+	}
+
+	if got := sl.DeleteRange(3, 6); got != 4 {
+		t.Errorf("DeleteRange() = %v, want 4", got)
+	}
+	if want := []int{3, 4, 5, 6}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("OnDelete keys = %v, want %v", deleted, want)
+	}
+	if got := sl.DeleteRange(3, 6); got != 0 {
+		t.Errorf("DeleteRange() = %v, want 0", got)
+	}
+
+	// a ReadView keeps the deleted range
+	v := sl.Snapshot()
+	if got := sl.DeleteRange(0, 8); got != 4 {
+		t.Errorf("DeleteRange() = %v, want 4", got)
+	}
+	if got, want := sl.Items(), []*KvPair[int, int]{{9, 9}, {10, 10}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if got, want := v.Range(0, 8), []*KvPair[int, int]{{1, 1}, {2, 2}, {7, 7}, {8, 8}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadView.Range() = %v, want %v", got, want)
+	}
+	v.Close()
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestSkipList_DeleteKeys(t *testing.T) {
+	type testCase struct {
+		name        string
+		keys        []int
+		opts        []Option
+		wantDeleted int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_DeleteKeys 1", keys: []int{0, 2, 4, 6, 8, 10, 12}, wantDeleted: 6},
+		{name: "TestSkipList_DeleteKeys 2", keys: []int{12, 0, 6, 2, 10, 8, 4}, wantDeleted: 6},
+		{name: "TestSkipList_DeleteKeys 3", keys: []int{-1, 4, 4, 5, 13, 100}, wantDeleted: 2},
+		{name: "TestSkipList_DeleteKeys 4", keys: []int{5, 4, 4, -1}, opts: []Option{WithTombstones()}, wantDeleted: 2},
+		{name: "TestSkipList_DeleteKeys 5", keys: []int{9, 7, 5, 3, 1}, opts: []Option{WithFinger()}, wantDeleted: 5},
+		{name: "TestSkipList_DeleteKeys 6", keys: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, opts: []Option{WithNodePool()}, wantDeleted: 12},
+		{name: "TestSkipList_DeleteKeys 7", keys: nil, wantDeleted: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				deleted []int
+				hooks   = WithHooks(Hooks[int, int]{OnDelete: func(key, _ int) { deleted = append(deleted, key) }})
+				sl      = NewSkipList[int, int](10, false, append(tt.opts, hooks)...)
+				ref     = make(map[int]bool)
+			)
+			for i := 1; i <= 12; i++ {
+				sl.Put(i, i)
+				ref[i] = true
+			}
+			var input = append([]int(nil), tt.keys...)
+
+			if got := sl.DeleteKeys(tt.keys); got != tt.wantDeleted {
+				t.Errorf("DeleteKeys() = %v, want %v", got, tt.wantDeleted)
+			}
+			if !reflect.DeepEqual(tt.keys, input) {
+				t.Errorf("DeleteKeys() modified keys to %v", tt.keys)
+			}
+			var wantDeleted []int
+			for _, key := range tt.keys {
+				if ref[key] {
+					wantDeleted = append(wantDeleted, key)
+					delete(ref, key)
+				}
+			}
+			sort.Ints(wantDeleted)
+			if !reflect.DeepEqual(deleted, wantDeleted) {
+				t.Errorf("OnDelete keys = %v, want %v", deleted, wantDeleted)
+			}
+			for i := 1; i <= 12; i++ {
+				if _, ok := sl.Get(i); ok != ref[i] {
+					t.Errorf("Get(%v) exists = %v, want %v", i, ok, ref[i])
+				}
+			}
+			if sl.Cap() != int32(len(ref)) {
+				t.Errorf("Cap() = %v, want %v", sl.Cap(), len(ref))
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	// random keys of a large list
+	var (
+		rnd  = rand.New(rand.NewSource(1))
+		sl   = NewSkipList[int, int](0, true)
+		keys []int
+		want = make(map[int]bool)
+	)
+	for i := 0; i < 10000; i++ {
+		sl.Put(i, i)
+		want[i] = true
+	}
+	for i := 0; i < 3000; i++ {
+		key := rnd.Intn(12000)
+		keys = append(keys, key)
+		delete(want, key)
+	}
+	if got := sl.DeleteKeys(keys); got != 10000-len(want) {
+		t.Errorf("DeleteKeys() = %v, want %v", got, 10000-len(want))
+	}
+	for i := 0; i < 10000; i++ {
+		if _, ok := sl.Get(i); ok != want[i] {
+			t.Fatalf("Get(%v) exists = %v, want %v", i, ok, want[i])
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	var nilSl *SkipList[int, int]
+	if got := nilSl.DeleteKeys([]int{1}); got != 0 {
+		t.Errorf("DeleteKeys() of nil = %v, want 0", got)
+	}
+}
+
+// BenchmarkSkipList_DeleteKeys compares deleting every 4th of 100000 keys by DeleteKeys with a Delete per key.
+func BenchmarkSkipList_DeleteKeys(b *testing.B) {
+	const n = 100000
+	var keys []int
+	for i := 0; i < n; i += 4 {
+		keys = append(keys, i)
+	}
+	var pairs = make([]*KvPair[int, int], n)
+	for i := range pairs {
+		pairs[i] = NewKvPair(i, i)
+	}
+
+	b.Run("Delete", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			sl := BulkLoad[int, int](0, false, pairs)
+			b.StartTimer()
+			for _, key := range keys {
+				sl.Delete(key)
+			}
+		}
+	})
+	b.Run("DeleteKeys", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			sl := BulkLoad[int, int](0, false, pairs)
+			b.StartTimer()
+			sl.DeleteKeys(keys)
+		}
+	})
+}
+
+func TestSkipList_KeepLargest(t *testing.T) {
+	type testCase struct {
+		name        string
+		n           int
+		opts        []Option
+		wantDeleted int
+		wantKeys    []int
+	}
+	tests := []testCase{
+		{name: "TestSkipList_KeepLargest 1", n: 10, wantDeleted: 0, wantKeys: []int{1, 3, 4, 6, 7, 8}},
+		{name: "TestSkipList_KeepLargest 2", n: 6, wantDeleted: 0, wantKeys: []int{1, 3, 4, 6, 7, 8}},
+		{name: "TestSkipList_KeepLargest 3", n: 4, wantDeleted: 2, wantKeys: []int{4, 6, 7, 8}},
+		{name: "TestSkipList_KeepLargest 4", n: 1, opts: []Option{WithTombstones()}, wantDeleted: 5, wantKeys: []int{8}},
+		{name: "TestSkipList_KeepLargest 5", n: 0, wantDeleted: 6, wantKeys: []int{}},
+		{name: "TestSkipList_KeepLargest 6", n: -1, opts: []Option{WithTombstones()}, wantDeleted: 6, wantKeys: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, false, tt.opts...)
+			for i := 1; i <= 8; i++ {
+				sl.Put(i, i)
+			}
+			sl.Delete(2)
+			sl.Delete(5)
+
+			if got := sl.KeepLargest(tt.n); got != tt.wantDeleted {
+				t.Errorf("KeepLargest(%v) = %v, want %v", tt.n, got, tt.wantDeleted)
+			}
+			keys := make([]int, 0)
+			for _, kv := range sl.Items() {
+				keys = append(keys, kv.Key())
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if got := sl.Cap(); int(got) != len(tt.wantKeys) {
+				t.Errorf("Cap() = %v, want %v", got, len(tt.wantKeys))
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+
+	if got := (*SkipList[int, int])(nil).KeepLargest(1); got != 0 {
+		t.Errorf("nil KeepLargest() = %v, want 0", got)
+	}
+}
+
+func TestSkipList_Head_Tail(t *testing.T) {
+	type testCase struct {
+		name     string
+		n        int
+		wantHead []*KvPair[int, int]
+		wantTail []*KvPair[int, int]
+	}
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 1; i <= 6; i++ {
+		sl.Put(i, i*10)
+	}
+	sl.Delete(2)
+	sl.Delete(5)
+	tests := []testCase{
+		{
+			name:     "TestSkipList_Head_Tail 1",
+			n:        0,
+			wantHead: []*KvPair[int, int]{},
+			wantTail: []*KvPair[int, int]{},
+		},
+		{
+			name:     "TestSkipList_Head_Tail 2",
+			n:        -1,
+			wantHead: []*KvPair[int, int]{},
+			wantTail: []*KvPair[int, int]{},
+		},
+		{
+			name:     "TestSkipList_Head_Tail 3",
+			n:        2,
+			wantHead: []*KvPair[int, int]{{1, 10}, {3, 30}},
+			wantTail: []*KvPair[int, int]{{4, 40}, {6, 60}},
+		},
+		{
+			name:     "TestSkipList_Head_Tail 4",
+			n:        4,
+			wantHead: []*KvPair[int, int]{{1, 10}, {3, 30}, {4, 40}, {6, 60}},
+			wantTail: []*KvPair[int, int]{{1, 10}, {3, 30}, {4, 40}, {6, 60}},
+		},
+		{
+			name:     "TestSkipList_Head_Tail 5",
+			n:        100,
+			wantHead: []*KvPair[int, int]{{1, 10}, {3, 30}, {4, 40}, {6, 60}},
+			wantTail: []*KvPair[int, int]{{1, 10}, {3, 30}, {4, 40}, {6, 60}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.Head(tt.n); !reflect.DeepEqual(got, tt.wantHead) {
+				t.Errorf("Head() = %v, want %v", got, tt.wantHead)
+			}
+			if got := sl.Tail(tt.n); !reflect.DeepEqual(got, tt.wantTail) {
+				t.Errorf("Tail() = %v, want %v", got, tt.wantTail)
+			}
+		})
+	}
+
+	var empty *SkipList[int, int]
+	if empty.Head(1) != nil || empty.Tail(1) != nil {
+		t.Errorf("Head(), Tail() of nil SkipList are not nil")
+	}
+}
+
+func TestSkipList_PrevFrom(t *testing.T) {
+	type testCase struct {
+		name string
+		key  int
+		n    int
+		want []*KvPair[int, int]
+	}
+
+	var sl = NewSkipList[int, int](10, false, WithTombstones())
+	for i := 10; i <= 100; i += 10 {
+		sl.Put(i, -i)
+	}
+	sl.Delete(40)
+
+	tests := []testCase{
+		{"TestSkipList_PrevFrom 1", 50, 3, []*KvPair[int, int]{{50, -50}, {30, -30}, {20, -20}}},
+		{"TestSkipList_PrevFrom 2", 45, 2, []*KvPair[int, int]{{30, -30}, {20, -20}}},
+		{"TestSkipList_PrevFrom 3", 25, 10, []*KvPair[int, int]{{20, -20}, {10, -10}}},
+		{"TestSkipList_PrevFrom 4", 5, 3, []*KvPair[int, int]{}},
+		{"TestSkipList_PrevFrom 5", 1000, 1, []*KvPair[int, int]{{100, -100}}},
+		{"TestSkipList_PrevFrom 6", 50, 0, []*KvPair[int, int]{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.PrevFrom(tt.key, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrevFrom(%v, %v) = %v, want %v", tt.key, tt.n, got, tt.want)
+			}
+		})
+	}
+
+	if got := (*SkipList[int, int])(nil).PrevFrom(1, 1); got != nil {
+		t.Errorf("nil PrevFrom() = %v, want nil", got)
+	}
+}
+
+func TestSkipList_SetAll(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+	}
+	tests := []testCase{
+		{
+			name: "TestSkipList_SetAll 1",
+		},
+		{
+			name: "TestSkipList_SetAll 2",
+			opts: []Option{WithTombstones()},
+		},
+		{
+			name: "TestSkipList_SetAll 3",
+			opts: []Option{WithMVCC()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				updates int
+				sl      = NewSkipList[int, int](10, false, append(tt.opts, WithHooks(Hooks[int, int]{
+					OnUpdate: func(key, old, new int) {
+						if old != key*10 || new != -1 {
+							t.Errorf("OnUpdate(%v, %v, %v), want old %v, new -1", key, old, new, key*10)
+						}
+						updates++
+					},
+				}))...)
+			)
+			for i := 5; i >= 1; i-- {
+				sl.Put(i, i*10)
+			}
+			sl.Delete(3)
+			v := sl.Snapshot()
+
+			sl.SetAll(-1)
+			if got, want := sl.Items(), []*KvPair[int, int]{{1, -1}, {2, -1}, {4, -1}, {5, -1}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+			if updates != 4 {
+				t.Errorf("OnUpdate is called %v times, want 4", updates)
+			}
+			// an open ReadView is not affected
+			if got, want := v.Range(0, 10), []*KvPair[int, int]{{1, 10}, {2, 20}, {4, 40}, {5, 50}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadView.Range() = %v, want %v", got, want)
+			}
+			v.Close()
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSkipList_FloorIndex_CeilIndex(t *testing.T) {
+	var (
+		r    = rand.New(rand.NewSource(1))
+		sl   = NewSkipList[int, int](10, false, WithTombstones())
+		keys []int
+	)
+	for i := 0; i < 200; i++ {
+		sl.Put(r.Intn(1000), i)
+	}
+	for i := 0; i < 50; i++ {
+		sl.Delete(r.Intn(1000))
+	}
+	for _, kv := range sl.Items() {
+		keys = append(keys, kv.key)
+	}
+
+	for target := -10; target <= 1010; target++ {
+		ceil := sort.Search(len(keys), func(i int) bool { return keys[i] >= target })
+		if got := sl.CeilIndex(target); got != ceil {
+			t.Fatalf("CeilIndex(%v) = %v, want %v", target, got, ceil)
+		}
+		floor := sort.Search(len(keys), func(i int) bool { return keys[i] > target }) - 1
+		if got := sl.FloorIndex(target); got != floor {
+			t.Fatalf("FloorIndex(%v) = %v, want %v", target, got, floor)
+		}
+	}
+
+	var empty *SkipList[int, int]
+	if empty.FloorIndex(1) != -1 || empty.CeilIndex(1) != 0 {
+		t.Errorf("FloorIndex(), CeilIndex() of nil SkipList = %v, %v, want -1, 0", empty.FloorIndex(1), empty.CeilIndex(1))
+	}
+}
+
+func TestSkipList_ApproxRank(t *testing.T) {
+	type testCase struct {
+		name    string
+		p       float64
+		n       int
+		compact bool
+	}
+	tests := []testCase{
+		{name: "TestSkipList_ApproxRank 1", p: 0.5, n: 20000},
+		{name: "TestSkipList_ApproxRank 2", p: 0.25, n: 20000},
+		{name: "TestSkipList_ApproxRank 3", p: 0.5, n: 20000, compact: true},
+		{name: "TestSkipList_ApproxRank 4", p: 0.5, n: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				r  = rand.New(rand.NewSource(1))
+				sl = NewSkipList[int, int](0, false, WithProbability(tt.p), WithRandSource(r))
+			)
+			for i := 0; i < tt.n; i++ {
+				sl.Put(r.Int(), i)
+			}
+			if tt.compact {
+				sl.Compact()
+			}
+
+			var (
+				n       = float64(tt.n)
+				maxDiff = 6 * math.Pow(n, 0.75)
+				maxRel  = 6 * math.Pow(n, -0.25)
+			)
+			for q := 0; q < 1000; q++ {
+				key := r.Int()
+				rank, _ := sl.Rank(key)
+				got := sl.ApproxRank(key)
+				diff := math.Abs(float64(got - rank))
+				if tt.compact && diff != 0 {
+					t.Fatalf("ApproxRank(%v) = %v of compacted towers, want %v", key, got, rank)
+				}
+				if diff > maxDiff {
+					t.Fatalf("ApproxRank(%v) = %v, want %v within %v", key, got, rank, maxDiff)
+				}
+				if rank >= tt.n/2 && diff/float64(rank) > maxRel {
+					t.Fatalf("ApproxRank(%v) = %v, want %v within a relative error of %v", key, got, rank, maxRel)
+				}
+			}
+		})
+	}
+
+	var nilSl *SkipList[int, int]
+	if got := nilSl.ApproxRank(1); got != 0 {
+		t.Errorf("ApproxRank() of nil = %v, want 0", got)
+	}
+}
+
+func TestSkipList_RangeWithRank(t *testing.T) {
+	type testCase struct {
+		name       string
+		start, end int
+		wantKeys   []int
+	}
+
+	var (
+		r  = rand.New(rand.NewSource(2))
+		sl = NewSkipList[int, int](10, false, WithTombstones())
+	)
+	for i := 0; i < 300; i++ {
+		sl.Put(r.Intn(1000), i)
+	}
+	for i := 0; i < 80; i++ {
+		sl.Delete(r.Intn(1000))
+	}
+	for _, k := range []int{10, 11, 12, 990} {
+		sl.Put(k, k)
+	}
+
+	tests := []testCase{
+		{name: "TestSkipList_RangeWithRank 1", start: -10, end: 2000},
+		{name: "TestSkipList_RangeWithRank 2", start: 10, end: 12, wantKeys: []int{10, 11, 12}},
+		{name: "TestSkipList_RangeWithRank 3", start: 250, end: 600},
+		{name: "TestSkipList_RangeWithRank 4", start: 990, end: 990, wantKeys: []int{990}},
+		{name: "TestSkipList_RangeWithRank 5", start: 600, end: 250, wantKeys: []int{}},
+		{name: "TestSkipList_RangeWithRank 6", start: 2000, end: 3000, wantKeys: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sl.RangeWithRank(tt.start, tt.end)
+			if want := sl.Range(tt.start, tt.end); len(got) != len(want) {
+				t.Fatalf("RangeWithRank() has %v kv-pairs, want %v", len(got), len(want))
+			}
+
+			keys := make([]int, 0)
+			for i := range got {
+				rp := &got[i]
+				keys = append(keys, rp.Key())
+				if rank, ok := sl.Rank(rp.Key()); !ok || rank != rp.Rank() {
+					t.Errorf("Rank(%v) = %v, %v, want %v, true", rp.Key(), rank, ok, rp.Rank())
+				}
+				if i > 0 && rp.Rank() != got[i-1].Rank()+1 {
+					t.Errorf("ranks %v, %v are not contiguous", got[i-1].Rank(), rp.Rank())
+				}
+				if v, _ := sl.Get(rp.Key()); v != rp.Val() {
+					t.Errorf("Val() = %v, want %v", rp.Val(), v)
+				}
+			}
+			if tt.wantKeys != nil && !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("RangeWithRank() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if len(got) > 0 {
+				if want, _ := sl.Rank(tt.start); got[0].Rank() != want {
+					t.Errorf("first rank = %v, want Rank(start) = %v", got[0].Rank(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipList_SelectRange(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []Option
+		i, j int
+	}
+
+	tests := []testCase{
+		{name: "TestSkipList_SelectRange 1", i: 0, j: 10},
+		{name: "TestSkipList_SelectRange 2", i: 3, j: 7},
+		{name: "TestSkipList_SelectRange 3", i: 95, j: 200},
+		{name: "TestSkipList_SelectRange 4", i: 7, j: 7},
+		{name: "TestSkipList_SelectRange 5", i: 8, j: 3},
+		{name: "TestSkipList_SelectRange 6", i: 100, j: 110},
+		{name: "TestSkipList_SelectRange 7", i: -5, j: 2},
+		{name: "TestSkipList_SelectRange 8", opts: []Option{WithTombstones()}, i: 40, j: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				sl   = NewSkipList[int, int](10, true, tt.opts...)
+				keys []int
+			)
+			for k := 0; k < 150; k++ {
+				sl.Put(k*3, k)
+				if k%3 == 0 {
+					sl.Delete(k * 3)
+				} else {
+					keys = append(keys, k*3)
+				}
+			}
+
+			var want = make([]*KvPair[int, int], 0)
+			for r := tt.i; r < tt.j && r < len(keys); r++ {
+				if r >= 0 {
+					want = append(want, newKvPair(keys[r], keys[r]/3))
+				}
+			}
+			if got := sl.SelectRange(tt.i, tt.j); !reflect.DeepEqual(got, want) {
+				t.Errorf("SelectRange() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSkipList_Rank(t *testing.T) {
+	type testCase struct {
+		name      string
+		key       int
+		wantRank  int
+		wantExist bool
+	}
+
+	var sl = NewSkipList[int, int](10, false)
+	for i := 0; i < 20; i += 2 {
+		sl.Put(i, i)
+	}
+
+	tests := []testCase{
+		{"TestSkipList_Rank 1", -1, 0, false},
+		{"TestSkipList_Rank 2", 0, 0, true},
+		{"TestSkipList_Rank 3", 7, 4, false},
+		{"TestSkipList_Rank 4", 18, 9, true},
+		{"TestSkipList_Rank 5", 19, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rank, exist := sl.Rank(tt.key); rank != tt.wantRank || exist != tt.wantExist {
+				t.Errorf("Rank(%v) = %v, %v, want %v, %v", tt.key, rank, exist, tt.wantRank, tt.wantExist)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// SkipSet is an ordered set of keys, which is a SkipList of struct{} values taking no memory in the nodes.
+type SkipSet[O constraints.Ordered] struct {
+	sl *SkipList[O, struct{}]
+}
+
+// NewSkipSet returns a SkipSet of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewSkipSet[O constraints.Ordered](maxLevel int32, isConcurrent bool, opts ...Option) *SkipSet[O] {
+	sl := NewSkipList[O, struct{}](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &SkipSet[O]{sl: sl}
+}
+
+// Add inserts key, and returns whether it is absent before.
+func (s *SkipSet[O]) Add(key O) bool {
+	if s == nil {
+		return false
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if n := sl.get(key); n != nil && !n.deleted {
+		return false
+	}
+	sl.put(nil, key, struct{}{})
+	sl.count(&sl.puts)
+	return true
+}
+
+// Remove deletes key, and returns whether it is present before.
+func (s *SkipSet[O]) Remove(key O) bool {
+	if s == nil {
+		return false
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if !sl.delete(key, nil) {
+		return false
+	}
+	sl.count(&sl.deletes)
+	return true
+}
+
+// Contains returns whether key is in s.
+func (s *SkipSet[O]) Contains(key O) bool {
+	if s == nil {
+		return false
+	}
+
+	_, exist := s.sl.Get(key)
+	return exist
+}
+
+// Len returns the number of keys.
+func (s *SkipSet[O]) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return int(s.sl.Cap())
+}
+
+// Min returns the least key.
+func (s *SkipSet[O]) Min() (key O, exist bool) {
+	if s == nil {
+		return
+	}
+
+	return s.sl.MinKey()
+}
+
+// Max returns the greatest key.
+func (s *SkipSet[O]) Max() (key O, exist bool) {
+	if s == nil {
+		return
+	}
+
+	return s.sl.MaxKey()
+}
+
+// Ceil returns the least key greater than or equal to target.
+func (s *SkipSet[O]) Ceil(target O) (key O, exist bool) {
+	if s == nil {
+		return
+	}
+
+	return s.sl.CeilKey(target)
+}
+
+// Floor returns the greatest key less than or equal to target.
+func (s *SkipSet[O]) Floor(target O) (key O, exist bool) {
+	if s == nil {
+		return
+	}
+
+	return s.sl.FloorKey(target)
+}
+
+// Range returns the keys in [start, end] in ascending order.
+func (s *SkipSet[O]) Range(start, end O) []O {
+	if s == nil {
+		return nil
+	}
+
+	var res = make([]O, 0)
+	s.sl.AscendRange(start, end, func(key O, _ struct{}) bool {
+		res = append(res, key)
+		return true
+	})
+	return res
+}
+
+// ForEach calls fn for each key in ascending order until fn returns false.
+// fn must not modify s.
+func (s *SkipSet[O]) ForEach(fn func(key O) bool) {
+	if s == nil {
+		return
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if !fn(n.key) {
+			return
+		}
+	}
+}
+
+// Union returns a new SkipSet of the options of s holding the keys in s or other, built in one pass as BulkLoad does.
+func (s *SkipSet[O]) Union(other *SkipSet[O]) *SkipSet[O] {
+	return s.merge(other, true, true, true)
+}
+
+// Intersect returns a new SkipSet of the options of s holding the keys in both s and other.
+func (s *SkipSet[O]) Intersect(other *SkipSet[O]) *SkipSet[O] {
+	return s.merge(other, false, true, false)
+}
+
+// Difference returns a new SkipSet of the options of s holding the keys in s but not in other.
+func (s *SkipSet[O]) Difference(other *SkipSet[O]) *SkipSet[O] {
+	return s.merge(other, true, false, false)
+}
+
+// merge merges the keys of s and other in one pass, keeping the keys only in s if onlyS, the keys in both if both,
+// and the keys only in other if onlyOther. The keys of other are copied first, so that no two locks are held at once.
+func (s *SkipSet[O]) merge(other *SkipSet[O], onlyS, both, onlyOther bool) *SkipSet[O] {
+	if s == nil {
+		return nil
+	}
+
+	var keys []O
+	other.ForEach(func(key O) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var (
+		res  = sl.emptyCopy()
+		tail = res.head
+		n    = sl.head.next()
+		i    int
+	)
+	for n != nil || i < len(keys) {
+		switch {
+		case i == len(keys) || (n != nil && n.key < keys[i]):
+			if onlyS {
+				tail = res.pushBack(tail, n.key, struct{}{})
+			}
+			n = n.next()
+		case n == nil || keys[i] < n.key:
+			if onlyOther {
+				tail = res.pushBack(tail, keys[i], struct{}{})
+			}
+			i++
+		default:
+			if both {
+				tail = res.pushBack(tail, n.key, struct{}{})
+			}
+			n = n.next()
+			i++
+		}
+	}
+	res.build()
+	return &SkipSet[O]{sl: res}
+}
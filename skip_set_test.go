@@ -0,0 +1,227 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"unsafe"
+)
+
+func TestSkipSet(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipSet 1", isConcurrent: false},
+		{name: "TestSkipSet 2", isConcurrent: true},
+		{name: "TestSkipSet 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+		{name: "TestSkipSet 4", isConcurrent: false, opts: []Option{WithNodePool()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				s   = NewSkipSet[int](0, tt.isConcurrent, tt.opts...)
+				ref = make(map[int]struct{})
+			)
+			for i := 0; i < 5000; i++ {
+				key := rnd.Intn(1000)
+				_, exist := ref[key]
+				if rnd.Intn(3) == 0 {
+					if got := s.Remove(key); got != exist {
+						t.Fatalf("Remove(%v) = %v, want %v", key, got, exist)
+					}
+					delete(ref, key)
+				} else {
+					if got := s.Add(key); got != !exist {
+						t.Fatalf("Add(%v) = %v, want %v", key, got, !exist)
+					}
+					ref[key] = struct{}{}
+				}
+			}
+
+			want := sortedKeys(ref)
+			if s.Len() != len(want) {
+				t.Fatalf("Len() = %v, want %v", s.Len(), len(want))
+			}
+			for key := -1; key <= 1000; key++ {
+				if _, exist := ref[key]; s.Contains(key) != exist {
+					t.Fatalf("Contains(%v) = %v, want %v", key, !exist, exist)
+				}
+			}
+			if got := s.Range(-1, 1000); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Range() = %v, want %v", got, want)
+			}
+			if got, want := s.Range(100, 200), rangeOf(want, 100, 200); !reflect.DeepEqual(got, want) {
+				t.Errorf("Range(100, 200) = %v, want %v", got, want)
+			}
+			if got := s.Range(200, 100); len(got) != 0 {
+				t.Errorf("Range(200, 100) = %v, want none", got)
+			}
+
+			if key, ok := s.Min(); !ok || key != want[0] {
+				t.Errorf("Min() = %v, %v, want %v", key, ok, want[0])
+			}
+			if key, ok := s.Max(); !ok || key != want[len(want)-1] {
+				t.Errorf("Max() = %v, %v, want %v", key, ok, want[len(want)-1])
+			}
+			for target := -1; target <= 1001; target += 7 {
+				i := sort.SearchInts(want, target)
+				if key, ok := s.Ceil(target); ok != (i < len(want)) || (ok && key != want[i]) {
+					t.Errorf("Ceil(%v) = %v, %v", target, key, ok)
+				}
+				j := sort.SearchInts(want, target+1) - 1
+				if key, ok := s.Floor(target); ok != (j >= 0) || (ok && key != want[j]) {
+					t.Errorf("Floor(%v) = %v, %v", target, key, ok)
+				}
+			}
+
+			var got []int
+			s.ForEach(func(key int) bool {
+				got = append(got, key)
+				return len(got) < 10
+			})
+			if !reflect.DeepEqual(got, want[:10]) {
+				t.Errorf("ForEach() = %v, want %v", got, want[:10])
+			}
+		})
+	}
+
+	var nilSet *SkipSet[int]
+	if nilSet.Add(1) || nilSet.Remove(1) || nilSet.Contains(1) || nilSet.Len() != 0 {
+		t.Errorf("nil SkipSet is not empty")
+	}
+	if _, ok := nilSet.Min(); ok {
+		t.Errorf("Min() of nil exists")
+	}
+	if s := NewSkipSet[int](-1, false); s != nil {
+		t.Errorf("NewSkipSet() = %v, want nil", s)
+	}
+	empty := NewSkipSet[int](10, false)
+	if _, ok := empty.Max(); ok {
+		t.Errorf("Max() of an empty set exists")
+	}
+	if _, ok := empty.Ceil(0); ok {
+		t.Errorf("Ceil() of an empty set exists")
+	}
+}
+
+func TestSkipSet_Algebra(t *testing.T) {
+	type testCase struct {
+		name   string
+		a, b   []int
+		isSame bool
+	}
+	var (
+		rnd        = rand.New(rand.NewSource(2))
+		randomKeys = func(n, max int) []int {
+			var keys = make([]int, n)
+			for i := range keys {
+				keys[i] = rnd.Intn(max)
+			}
+			return keys
+		}
+	)
+	tests := []testCase{
+		{name: "TestSkipSet_Algebra 1", a: randomKeys(1000, 2000), b: randomKeys(1000, 2000)},
+		{name: "TestSkipSet_Algebra 2", a: randomKeys(1000, 2000), b: nil},
+		{name: "TestSkipSet_Algebra 3", a: nil, b: randomKeys(1000, 2000)},
+		{name: "TestSkipSet_Algebra 4", a: []int{1, 2, 3}, b: []int{4, 5, 6}},
+		{name: "TestSkipSet_Algebra 5", a: randomKeys(100, 200), isSame: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, isConcurrent := range []bool{false, true} {
+				var (
+					a, b       = NewSkipSet[int](0, isConcurrent), NewSkipSet[int](0, isConcurrent)
+					refA, refB = make(map[int]struct{}), make(map[int]struct{})
+				)
+				for _, key := range tt.a {
+					a.Add(key)
+					refA[key] = struct{}{}
+				}
+				for _, key := range tt.b {
+					b.Add(key)
+					refB[key] = struct{}{}
+				}
+				if tt.isSame {
+					b, refB = a, refA
+				}
+
+				var union, intersect, difference = make(map[int]struct{}), make(map[int]struct{}), make(map[int]struct{})
+				for key := range refA {
+					union[key] = struct{}{}
+					if _, ok := refB[key]; ok {
+						intersect[key] = struct{}{}
+					} else {
+						difference[key] = struct{}{}
+					}
+				}
+				for key := range refB {
+					union[key] = struct{}{}
+				}
+
+				for _, op := range []struct {
+					name string
+					got  *SkipSet[int]
+					want map[int]struct{}
+				}{
+					{"Union", a.Union(b), union},
+					{"Intersect", a.Intersect(b), intersect},
+					{"Difference", a.Difference(b), difference},
+				} {
+					if got, want := op.got.Range(-1, 2000), sortedKeys(op.want); !reflect.DeepEqual(got, want) {
+						t.Errorf("%s() = %v, want %v", op.name, got, want)
+					}
+					if op.got.Len() != len(op.want) {
+						t.Errorf("Len() of %s() = %v, want %v", op.name, op.got.Len(), len(op.want))
+					}
+					if err := op.got.sl.Validate(); err != nil {
+						t.Errorf("Validate() of %s() error = %v", op.name, err)
+					}
+					if op.got.sl.isConcurrent != isConcurrent {
+						t.Errorf("%s() is not of the options of the receiver", op.name)
+					}
+				}
+
+				// operands are intact
+				if a.Len() != len(refA) || b.Len() != len(refB) {
+					t.Errorf("Len() of the operands = %v, %v, want %v, %v", a.Len(), b.Len(), len(refA), len(refB))
+				}
+			}
+		})
+	}
+
+	var nilSet *SkipSet[int]
+	if nilSet.Union(NewSkipSet[int](10, false)) != nil {
+		t.Errorf("Union() of nil is not nil")
+	}
+	if got := NewSkipSet[int](10, false).Union(nilSet); got == nil || got.Len() != 0 {
+		t.Errorf("Union() with nil = %v, want an empty set", got)
+	}
+}
+
+func TestSkipSet_NodeSize(t *testing.T) {
+	// the values of a set take no memory in the nodes
+	if set, kv := unsafe.Sizeof(node[int, struct{}]{}), unsafe.Sizeof(node[int, int]{}); set != kv-unsafe.Sizeof(0) {
+		t.Errorf("node of SkipSet has %d bytes, want %d", set, kv-unsafe.Sizeof(0))
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[int]struct{}) []int {
+	var keys = make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// rangeOf returns the keys of sorted in [start, end].
+func rangeOf(sorted []int, start, end int) []int {
+	return sorted[sort.SearchInts(sorted, start):sort.SearchInts(sorted, end+1)]
+}
@@ -0,0 +1,313 @@
+package skip_list
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// ReadView is a read view of SkipList as of Snapshot, which subsequent writes do not affect.
+	// While any ReadView is open, deleted nodes stay linked as tombstones and overwritten values are kept
+	// in the history of their nodes, which is reclaimed once the ReadViews needing it are closed or garbage collected.
+	ReadView[O constraints.Ordered, T any] struct {
+		sl   *SkipList[O, T]
+		head *node[O, T]
+
+		// writes of version greater than it are invisible
+		version uint64
+		cap     int32
+
+		closed int32
+	}
+
+	// record is the state of a node before a write of version, newer records first.
+	record[T any] struct {
+		version uint64
+		val     T
+		deleted bool
+		next    *record[T]
+	}
+
+	// views counts the open ReadViews of SkipList by version.
+	views struct {
+		sync.Mutex
+		open map[uint64]int
+	}
+)
+
+// Snapshot returns a ReadView of SkipList as of now in O(1), which should be closed once it is no longer used.
+func (sl *SkipList[O, T]) Snapshot() *ReadView[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if sl.views == nil {
+		sl.views = &views{open: make(map[uint64]int)}
+	}
+
+	v := &ReadView[O, T]{sl: sl, head: sl.head, version: sl.version, cap: sl.cap}
+	sl.views.Lock()
+	sl.views.open[v.version]++
+	sl.views.Unlock()
+	atomic.AddInt32(&sl.viewCount, 1)
+
+	// subsequent writes are invisible
+	sl.version++
+
+	runtime.SetFinalizer(v, func(v *ReadView[O, T]) {
+		if v.release() {
+			// the finalizer goroutine must not write SkipList, so the next write reclaims
+			atomic.StoreInt32(&v.sl.staleViews, 1)
+		}
+	})
+	return v
+}
+
+// Close releases v, and reclaims the history no open ReadView needs.
+func (v *ReadView[O, T]) Close() {
+	if v == nil || !v.release() {
+		return
+	}
+	runtime.SetFinalizer(v, nil)
+
+	sl := v.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	sl.reclaimViews()
+}
+
+// release unregisters v, and returns whether it is released by this call.
+func (v *ReadView[O, T]) release() bool {
+	if !atomic.CompareAndSwapInt32(&v.closed, 0, 1) {
+		return false
+	}
+
+	views := v.sl.views
+	views.Lock()
+	if views.open[v.version]--; views.open[v.version] == 0 {
+		delete(views.open, v.version)
+	}
+	views.Unlock()
+	atomic.AddInt32(&v.sl.viewCount, -1)
+	return true
+}
+
+// Cap returns the number of nodes as of Snapshot.
+func (v *ReadView[O, T]) Cap() int32 {
+	if v == nil || atomic.LoadInt32(&v.closed) == 1 {
+		return 0
+	}
+	return v.cap
+}
+
+// Get returns the value of key as of Snapshot.
+func (v *ReadView[O, T]) Get(key O) (val T, exist bool) {
+	if v == nil || atomic.LoadInt32(&v.closed) == 1 {
+		return
+	}
+
+	sl := v.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if n := v.lower(key).nextNodes[0]; n != nil && n.key == key {
+		return n.at(v.version)
+	}
+	return
+}
+
+// Range searches the *KvPair of key in [start, end] as of Snapshot.
+func (v *ReadView[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if v == nil || atomic.LoadInt32(&v.closed) == 1 {
+		return nil
+	}
+
+	sl := v.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	// the search of start is read locked as the walk is, since writers link nodes onto the same levels
+	var res = make([]*KvPair[O, T], 0)
+	v.forEach(v.lower(start).nextNodes[0], func(key O, val T) bool {
+		if key > end {
+			return false
+		}
+		res = append(res, newKvPair(key, val))
+		return true
+	})
+	return res
+}
+
+// ForEach calls fn for each key in order as of Snapshot until fn returns false.
+func (v *ReadView[O, T]) ForEach(fn func(key O, val T) bool) {
+	if v == nil || atomic.LoadInt32(&v.closed) == 1 {
+		return
+	}
+
+	sl := v.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	v.forEach(v.head.nextNodes[0], fn)
+}
+
+// forEach calls fn for each key visible to v from n until fn returns false. SkipList must be read locked if concurrent.
+func (v *ReadView[O, T]) forEach(n *node[O, T], fn func(key O, val T) bool) {
+	for ; n != nil; n = n.nextNodes[0] {
+		if val, ok := n.at(v.version); ok && !fn(n.key, val) {
+			return
+		}
+	}
+}
+
+// lower returns the greatest node whose key is less than target including tombstones, or head if not exist.
+func (v *ReadView[O, T]) lower(target O) *node[O, T] {
+	move := v.head
+	for l := len(v.head.nextNodes) - 1; l >= 0; l-- {
+		for move.nextNodes[l] != nil && move.nextNodes[l].key < target {
+			// search to the right
+			move = move.nextNodes[l]
+		}
+
+		// search down
+	}
+	return move
+}
+
+// at returns the value of n as of version.
+func (n *node[O, T]) at(version uint64) (val T, exist bool) {
+	val, exist = n.val, !n.deleted
+	for r := n.history; r != nil && r.version > version; r = r.next {
+		// the oldest write after version
+		val, exist = r.val, !r.deleted
+	}
+	if !exist {
+		var zero T
+		return zero, false
+	}
+	return
+}
+
+// keepsHistory returns whether writes keep the history, which is if mvcc or any ReadView is open.
+func (sl *SkipList[O, T]) keepsHistory() bool {
+	return sl.mvcc || atomic.LoadInt32(&sl.viewCount) > 0
+}
+
+// writeVersion returns the version of a write, which is new for every write if mvcc.
+func (sl *SkipList[O, T]) writeVersion() uint64 {
+	if sl.mvcc {
+		sl.version++
+	}
+	return sl.version
+}
+
+// record keeps the state of n in its history before it is written if keepsHistory.
+func (sl *SkipList[O, T]) record(n *node[O, T]) {
+	if !sl.keepsHistory() {
+		return
+	}
+
+	version := sl.writeVersion()
+	if n.history != nil && n.history.version == version {
+		// the state before this version is kept already
+		return
+	}
+	n.history = &record[T]{version: version, val: n.val, deleted: n.deleted, next: n.history}
+	sl.histories = true
+}
+
+// reclaimStaleViews reclaims the history of ReadViews released by the finalizer.
+func (sl *SkipList[O, T]) reclaimStaleViews() {
+	if atomic.LoadInt32(&sl.staleViews) == 1 {
+		sl.reclaimViews()
+	}
+}
+
+// reclaimViews trims the history no open ReadView needs, nor reads after the watermark of GC if mvcc,
+// and unlinks the tombstones kept for them.
+func (sl *SkipList[O, T]) reclaimViews() {
+	atomic.StoreInt32(&sl.staleViews, 0)
+	if !sl.histories {
+		return
+	}
+
+	// records of version not greater than oldest are needed by no read
+	var oldest = sl.version
+	if sl.mvcc {
+		oldest = sl.watermark
+	}
+	if sl.views != nil {
+		sl.views.Lock()
+		for version := range sl.views.open {
+			if version < oldest {
+				oldest = version
+			}
+		}
+		sl.views.Unlock()
+	}
+
+	// update[l] is the last retained node on level l
+	var update = make([]*node[O, T], sl.level)
+	for l := range update {
+		update[l] = sl.head
+	}
+
+	var unlinked int32
+	sl.histories = false
+	for n := sl.head.nextNodes[0]; n != nil; {
+		next := n.nextNodes[0]
+
+		// trim
+		for r := &n.history; *r != nil; r = &(*r).next {
+			if (*r).version <= oldest {
+				*r = nil
+				break
+			}
+		}
+
+		if n.deleted && n.history == nil && !sl.tombstones {
+			// unlink
+			for l := range n.nextNodes {
+				update[l].nextNodes[l] = n.nextNodes[l]
+			}
+			sl.freeNode(n)
+			unlinked++
+		} else {
+			// retain
+			for l := range n.nextNodes {
+				update[l] = n
+			}
+			sl.histories = sl.histories || n.history != nil
+		}
+		n = next
+	}
+	if unlinked == 0 {
+		return
+	}
+	sl.resetFinger()
+
+	// cut
+	sl.cut()
+
+	sl.tombstoneCap -= unlinked
+
+	// tune
+	sl.tune(sl.cap)
+}
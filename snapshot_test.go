@@ -0,0 +1,304 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// readAll returns all *KvPair of v through ForEach.
+func readAll(v *ReadView[int, int]) []*KvPair[int, int] {
+	var res = make([]*KvPair[int, int], 0)
+	v.ForEach(func(key, val int) bool {
+		res = append(res, NewKvPair(key, val))
+		return true
+	})
+	return res
+}
+
+func TestSkipList_Snapshot(t *testing.T) {
+	type testCase struct {
+		name     string
+		maxLevel int32
+		opts     []Option
+	}
+	tests := []testCase{
+		{
+			name:     "TestSkipList_Snapshot 1",
+			maxLevel: 16,
+		},
+		{
+			name:     "TestSkipList_Snapshot 2",
+			maxLevel: 0,
+			opts:     []Option{WithNodePool(), WithFinger()},
+		},
+		{
+			name:     "TestSkipList_Snapshot 3",
+			maxLevel: 16,
+			opts:     []Option{WithTombstones(), WithArena()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				r     = rand.New(rand.NewSource(1))
+				sl    = NewSkipList[int, int](tt.maxLevel, false, tt.opts...)
+				views []*ReadView[int, int]
+				wants [][]*KvPair[int, int]
+				eq    = func(a, b int) bool { return a == b }
+			)
+			for i := 0; i < 20000; i++ {
+				key := r.Intn(2000)
+				switch op := r.Intn(1000); {
+				case op < 500:
+					sl.Put(key, i)
+				case op < 800:
+					sl.Delete(key)
+				case op < 900:
+					sl.CompareAndSwap(key, key, -key, eq)
+				case op < 990:
+					sl.Put(key, key)
+				case op < 993:
+					sl.DeleteIf(func(k, _ int) bool { return k%31 == key%31 })
+				case op < 996:
+					sl.Compact()
+				case op < 997:
+					sl.Clear()
+				default:
+					if len(views) > 0 && r.Intn(2) == 0 {
+						// close the oldest one
+						views[0].Close()
+						views, wants = views[1:], wants[1:]
+					} else {
+						views = append(views, sl.Snapshot())
+						wants = append(wants, sl.Items())
+					}
+				}
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if len(views) == 0 {
+				t.Fatalf("no ReadView is open")
+			}
+
+			for i, v := range views {
+				if got := readAll(v); !reflect.DeepEqual(got, wants[i]) {
+					t.Errorf("ForEach() of ReadView %d = %v, want %v", i, got, wants[i])
+				}
+				if got := v.Cap(); got != int32(len(wants[i])) {
+					t.Errorf("Cap() of ReadView %d = %v, want %v", i, got, len(wants[i]))
+				}
+				if got, want := v.Range(500, 1000), filterRange(wants[i], 500, 1000); !reflect.DeepEqual(got, want) {
+					t.Errorf("Range() of ReadView %d = %v, want %v", i, got, want)
+				}
+				for _, kv := range wants[i] {
+					if val, ok := v.Get(kv.Key()); !ok || val != kv.Val() {
+						t.Fatalf("Get(%v) of ReadView %d = %v, %v, want %v", kv.Key(), i, val, ok, kv.Val())
+					}
+				}
+			}
+
+			// reclaim
+			var items = sl.Items()
+			for _, v := range views {
+				v.Close()
+			}
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := sl.Items(); !reflect.DeepEqual(got, items) {
+				t.Errorf("Items() = %v, want %v", got, items)
+			}
+			for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+				if n.history != nil {
+					t.Fatalf("history of %v is not reclaimed", n.key)
+				}
+			}
+			if sl.tombstones {
+				return
+			}
+			if got := sl.Tombstones(); got != 0 {
+				t.Errorf("Tombstones() = %v, want 0", got)
+			}
+
+			// closed
+			if got := views[0].Range(math.MinInt, math.MaxInt); got != nil {
+				t.Errorf("Range() = %v of a closed ReadView", got)
+			}
+		})
+	}
+}
+
+// filterRange returns the *KvPair of key in [start, end].
+func filterRange(items []*KvPair[int, int], start, end int) []*KvPair[int, int] {
+	var res = make([]*KvPair[int, int], 0)
+	for _, kv := range items {
+		if kv.Key() >= start && kv.Key() <= end {
+			res = append(res, kv)
+		}
+	}
+	return res
+}
+
+func TestSkipList_Snapshot_Concurrent(t *testing.T) {
+	var sl = NewSkipList[int, int](16, true)
+	for i := 0; i < 1000; i++ {
+		sl.Put(i, i)
+	}
+
+	var (
+		v    = sl.Snapshot()
+		want = sl.Items()
+		wg   sync.WaitGroup
+	)
+	defer v.Close()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			if key := r.Intn(2000); r.Intn(2) == 0 {
+				sl.Put(key, -i)
+			} else {
+				sl.Delete(key)
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if got := readAll(v); !reflect.DeepEqual(got, want) {
+			t.Fatalf("ForEach() = %v, want %v", got, want)
+		}
+	}
+	wg.Wait()
+}
+
+func TestSkipList_Snapshot_SafeIterators(t *testing.T) {
+	type testCase struct {
+		name    string
+		replace func(sl *SkipList[int, int])
+	}
+	tests := []testCase{
+		{name: "TestSkipList_Snapshot_SafeIterators 1", replace: func(sl *SkipList[int, int]) { sl.Clear() }},
+		{
+			name: "TestSkipList_Snapshot_SafeIterators 2",
+			replace: func(sl *SkipList[int, int]) {
+				sl.ReplaceAll([]*KvPair[int, int]{NewKvPair(0, 0), NewKvPair(3, 3), NewKvPair(9, 9), NewKvPair(10, 10)})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](10, false, WithSafeIterators())
+			for i := 0; i < 8; i++ {
+				sl.Put(i, -i)
+			}
+			sl.Delete(5)
+
+			// the nodes marked for the open Iterator stay visible to the ReadView
+			it := sl.Iterator()
+			defer it.Close()
+			v := sl.Snapshot()
+			defer v.Close()
+			want := sl.Items()
+
+			tt.replace(sl)
+			sl.Put(4, 4)
+			if got := readAll(v); !reflect.DeepEqual(got, want) {
+				t.Errorf("ForEach() of ReadView = %v, want %v", got, want)
+			}
+			if val, ok := v.Get(4); !ok || val != -4 {
+				t.Errorf("Get(4) of ReadView = %v, %v, want -4, true", val, ok)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSkipList_Snapshot_Range_Concurrent(t *testing.T) {
+	var sl = NewSkipList[int, int](16, true)
+	for i := 0; i < 1000; i++ {
+		sl.Put(i, i)
+	}
+
+	var (
+		v    = sl.Snapshot()
+		want = sl.Range(990, 1000000)
+		wg   sync.WaitGroup
+	)
+	defer v.Close()
+
+	// Puts near the tail link nodes onto the levels Range searches
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1000; i < 20000; i++ {
+			sl.Put(i, i)
+		}
+	}()
+	for i := 0; i < 2000; i++ {
+		if got := v.Range(990, 1000000); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Range() = %v, want %v", got, want)
+		}
+	}
+	wg.Wait()
+}
+
+func TestSkipList_Snapshot_Finalizer(t *testing.T) {
+	var sl = NewSkipList[int, int](16, false)
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+
+	func() {
+		v := sl.Snapshot()
+		sl.Delete(1)
+		if _, ok := v.Get(1); !ok {
+			t.Errorf("Get(1) = false, want true")
+		}
+	}()
+	if got := sl.Tombstones(); got != 1 {
+		t.Fatalf("Tombstones() = %v, want 1 while the ReadView is reachable", got)
+	}
+
+	// the ReadView is garbage collected
+	for i := 0; i < 100 && sl.Metrics().Puts == 100; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+		if sl.keepsHistory() {
+			continue
+		}
+		sl.Put(2, 2)
+	}
+	if sl.keepsHistory() {
+		t.Fatalf("ReadView is not released by the finalizer")
+	}
+	if got := sl.Tombstones(); got != 0 {
+		t.Errorf("Tombstones() = %v, want 0 after the next write", got)
+	}
+}
+
+func BenchmarkSkipList_Snapshot(b *testing.B) {
+	var sl = NewSkipList[int, int](20, false)
+	for i := 0; i < 1<<20; i++ {
+		sl.Put(i, i)
+	}
+
+	b.Run("Snapshot", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl.Snapshot().Close()
+		}
+	})
+	b.Run("CopyRange", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl.CopyRange(math.MinInt, math.MaxInt)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package skip_list
+
+import (
+	"errors"
+	"math"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+var ErrNaNScore = errors.New("skip_list: score is NaN")
+
+// SortedSet is a set of members each with a score, ordered by score and then by member as a sorted set of Redis.
+// It is a SkipList of the members keyed by the Encode of CompositeKey of score and member, and a map of the score
+// of each member, by which an update finds the entry to move. Both are kept consistent under the lock of the SkipList.
+// Members are ordered so that equal scores are ordered by member.
+type SortedSet[M constraints.Ordered] struct {
+	sl     *SkipList[string, M]
+	scores map[M]float64
+}
+
+// NewSortedSet returns a SortedSet of the SkipList NewSkipList returns for maxLevel and opts, or nil as it does.
+func NewSortedSet[M constraints.Ordered](maxLevel int32, isConcurrent bool, opts ...Option) *SortedSet[M] {
+	sl := NewSkipList[string, M](maxLevel, isConcurrent, opts...)
+	if sl == nil {
+		return nil
+	}
+	return &SortedSet[M]{sl: sl, scores: make(map[M]float64)}
+}
+
+// Add adds member with score, or updates its score moving it in the order, and returns whether it is added.
+// It returns ErrNaNScore if score is NaN, which has no order.
+func (s *SortedSet[M]) Add(member M, score float64) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	if math.IsNaN(score) {
+		return false, ErrNaNScore
+	}
+	if score == 0 {
+		// -0 is 0
+		score = 0
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	old, exist := s.scores[member]
+	if exist {
+		if old == score {
+			return false, nil
+		}
+		sl.delete(scoreKey(old, member), nil)
+	}
+	sl.put(nil, scoreKey(score, member), member)
+	sl.count(&sl.puts)
+	s.scores[member] = score
+	return !exist, nil
+}
+
+// Remove deletes member, and returns whether it exists.
+func (s *SortedSet[M]) Remove(member M) bool {
+	if s == nil {
+		return false
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	score, exist := s.scores[member]
+	if !exist {
+		return false
+	}
+	sl.delete(scoreKey(score, member), nil)
+	sl.count(&sl.deletes)
+	delete(s.scores, member)
+	return true
+}
+
+// Score returns the score of member.
+func (s *SortedSet[M]) Score(member M) (score float64, exist bool) {
+	if s == nil {
+		return
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	score, exist = s.scores[member]
+	return
+}
+
+// Rank returns the index of member in ascending order of score and member.
+// Without spans in the towers, it counts the members before it on level 0.
+func (s *SortedSet[M]) Rank(member M) (int, bool) {
+	if s == nil {
+		return 0, false
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	score, exist := s.scores[member]
+	if !exist {
+		return 0, false
+	}
+	key := scoreKey(score, member)
+	return sl.countUntil(func(k string) bool { return k >= key }), true
+}
+
+// RangeByScore returns the members with scores in [min, max] in order, as the keys of *KvPair with their scores
+// as the values.
+func (s *SortedSet[M]) RangeByScore(min, max float64) []*KvPair[M, float64] {
+	if s == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[M, float64], 0)
+	if !(min <= max) {
+		return res
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if min == 0 {
+		// -0 is 0
+		min = 0
+	}
+	// the encoded min alone precedes the keys of every member with score min
+	var start = string(appendOrdered(nil, reflect.ValueOf(min), true))
+	for n := sl.ceil(start); n != nil; n = n.next() {
+		score := s.scores[n.val]
+		if score > max {
+			break
+		}
+		res = append(res, newKvPair(n.val, score))
+	}
+	return res
+}
+
+// RangeByRank returns the members at indexes [i, j) in order, where j is clamped to Card and a negative i to 0,
+// as the keys of *KvPair with their scores as the values.
+func (s *SortedSet[M]) RangeByRank(i, j int) []*KvPair[M, float64] {
+	if s == nil {
+		return nil
+	}
+
+	sl := s.sl
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if i < 0 {
+		i = 0
+	}
+	if j > int(sl.cap) {
+		j = int(sl.cap)
+	}
+	if i >= j {
+		return make([]*KvPair[M, float64], 0)
+	}
+
+	var (
+		res = make([]*KvPair[M, float64], 0, j-i)
+		n   = sl.head.next()
+	)
+	for k := 0; k < i; k++ {
+		n = n.next()
+	}
+	for ; len(res) < j-i; n = n.next() {
+		res = append(res, newKvPair(n.val, s.scores[n.val]))
+	}
+	return res
+}
+
+// Card returns the number of members.
+func (s *SortedSet[M]) Card() int {
+	if s == nil {
+		return 0
+	}
+
+	return int(s.sl.Cap())
+}
+
+// scoreKey returns the key of member with score in the SkipList of SortedSet.
+func scoreKey[M constraints.Ordered](score float64, member M) string {
+	return NewCompositeKey(score, member).Encode()
+}
@@ -0,0 +1,190 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSortedSet(t *testing.T) {
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSortedSet 1", isConcurrent: false},
+		{name: "TestSortedSet 2", isConcurrent: true},
+		{name: "TestSortedSet 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				s   = NewSortedSet[string](0, tt.isConcurrent, tt.opts...)
+				ref = make(map[string]float64)
+			)
+			for i := 0; i < 10000; i++ {
+				member := "m" + strconv.Itoa(rnd.Intn(300))
+				// few distinct scores, so that many are equal
+				score := float64(rnd.Intn(41)-20) / 4
+				_, exist := ref[member]
+				if rnd.Intn(4) == 0 {
+					if got := s.Remove(member); got != exist {
+						t.Fatalf("Remove(%v) = %v, want %v", member, got, exist)
+					}
+					delete(ref, member)
+					continue
+				}
+				if got, err := s.Add(member, score); err != nil || got != !exist {
+					t.Fatalf("Add(%v, %v) = %v, %v, want %v, nil", member, score, got, err, !exist)
+				}
+				ref[member] = score
+			}
+
+			want := sortedScores(ref)
+			if s.Card() != len(want) {
+				t.Fatalf("Card() = %v, want %v", s.Card(), len(want))
+			}
+			if got := s.RangeByRank(0, len(want)); !reflect.DeepEqual(got, want) {
+				t.Fatalf("RangeByRank() = %v, want %v", got, want)
+			}
+			for i, kv := range want {
+				if rank, ok := s.Rank(kv.Key()); !ok || rank != i {
+					t.Fatalf("Rank(%v) = %v, %v, want %v, true", kv.Key(), rank, ok, i)
+				}
+				if score, ok := s.Score(kv.Key()); !ok || score != kv.Val() {
+					t.Fatalf("Score(%v) = %v, %v, want %v, true", kv.Key(), score, ok, kv.Val())
+				}
+			}
+			if _, ok := s.Rank("absent"); ok {
+				t.Errorf("Rank() of an absent member exists")
+			}
+			if _, ok := s.Score("absent"); ok {
+				t.Errorf("Score() of an absent member exists")
+			}
+
+			for q := 0; q < 200; q++ {
+				min, max := float64(rnd.Intn(49)-24)/4, float64(rnd.Intn(49)-24)/4
+				var wantRange = make([]*KvPair[string, float64], 0)
+				for _, kv := range want {
+					if kv.Val() >= min && kv.Val() <= max {
+						wantRange = append(wantRange, kv)
+					}
+				}
+				if got := s.RangeByScore(min, max); !reflect.DeepEqual(got, wantRange) {
+					t.Fatalf("RangeByScore(%v, %v) = %v, want %v", min, max, got, wantRange)
+				}
+
+				i, j := rnd.Intn(len(want)+10)-5, rnd.Intn(len(want)+10)-5
+				var wantRank = make([]*KvPair[string, float64], 0)
+				for k := i; k < j && k < len(want); k++ {
+					if k >= 0 {
+						wantRank = append(wantRank, want[k])
+					}
+				}
+				if got := s.RangeByRank(i, j); !reflect.DeepEqual(got, wantRank) {
+					t.Fatalf("RangeByRank(%v, %v) = %v, want %v", i, j, got, wantRank)
+				}
+			}
+			if err := s.sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSortedSet_Update(t *testing.T) {
+	s := NewSortedSet[string](10, false)
+	for _, m := range []struct {
+		member string
+		score  float64
+	}{{"carol", 2}, {"alice", 2}, {"bob", 2}, {"dave", -1}, {"eve", 5}} {
+		s.Add(m.member, m.score)
+	}
+
+	// equal scores are ordered by member
+	if got, want := s.RangeByScore(2, 2), []*KvPair[string, float64]{{"alice", 2}, {"bob", 2}, {"carol", 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeByScore() = %v, want %v", got, want)
+	}
+
+	// an update moves the member across the order
+	if added, err := s.Add("dave", 3); added || err != nil {
+		t.Errorf("Add() of an existing member = %v, %v, want false, nil", added, err)
+	}
+	if added, _ := s.Add("eve", -10); added {
+		t.Errorf("Add() of an existing member = true")
+	}
+	want := []*KvPair[string, float64]{{"eve", -10}, {"alice", 2}, {"bob", 2}, {"carol", 2}, {"dave", 3}}
+	if got := s.RangeByRank(0, 10); !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeByRank() = %v, want %v", got, want)
+	}
+	if rank, _ := s.Rank("dave"); rank != 4 {
+		t.Errorf("Rank() = %v, want 4", rank)
+	}
+	if rank, _ := s.Rank("eve"); rank != 0 {
+		t.Errorf("Rank() = %v, want 0", rank)
+	}
+	if s.Card() != 5 {
+		t.Errorf("Card() = %v, want 5", s.Card())
+	}
+
+	// the same score keeps the member
+	if added, _ := s.Add("bob", 2); added || s.Card() != 5 {
+		t.Errorf("Add() with the same score = %v, Card() = %v", added, s.Card())
+	}
+
+	// -0 is 0, and infinities are ordered
+	s.Add("zero", math.Copysign(0, -1))
+	s.Add("inf", math.Inf(1))
+	s.Add("-inf", math.Inf(-1))
+	if got, want := s.RangeByScore(0, 0), []*KvPair[string, float64]{{"zero", 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RangeByScore(0, 0) = %v, want %v", got, want)
+	}
+	if got := s.RangeByScore(math.Copysign(0, -1), 0); len(got) != 1 {
+		t.Errorf("RangeByScore(-0, 0) = %v, want zero", got)
+	}
+	if got := s.RangeByScore(math.Inf(-1), math.Inf(1)); len(got) != 8 || got[0].Key() != "-inf" || got[7].Key() != "inf" {
+		t.Errorf("RangeByScore(-Inf, Inf) = %v", got)
+	}
+	if got := s.RangeByScore(3, 2); len(got) != 0 {
+		t.Errorf("RangeByScore(3, 2) = %v, want none", got)
+	}
+
+	if _, err := s.Add("nan", math.NaN()); err != ErrNaNScore {
+		t.Errorf("Add() of NaN error = %v, want %v", err, ErrNaNScore)
+	}
+	if got := s.RangeByScore(math.NaN(), 1); len(got) != 0 {
+		t.Errorf("RangeByScore(NaN, 1) = %v, want none", got)
+	}
+
+	if !s.Remove("dave") || s.Remove("dave") || s.Card() != 7 {
+		t.Errorf("Remove() did not remove dave once")
+	}
+
+	var nilSet *SortedSet[string]
+	if added, _ := nilSet.Add("a", 1); added || nilSet.Remove("a") || nilSet.Card() != 0 || nilSet.RangeByRank(0, 1) != nil {
+		t.Errorf("nil SortedSet is not empty")
+	}
+	if s := NewSortedSet[string](-1, false); s != nil {
+		t.Errorf("NewSortedSet() = %v, want nil", s)
+	}
+}
+
+// sortedScores returns the members of ref with their scores ordered by score and then by member.
+func sortedScores(ref map[string]float64) []*KvPair[string, float64] {
+	var res = make([]*KvPair[string, float64], 0, len(ref))
+	for member, score := range ref {
+		res = append(res, NewKvPair(member, score))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Val() != res[j].Val() {
+			return res[i].Val() < res[j].Val()
+		}
+		return res[i].Key() < res[j].Key()
+	})
+	return res
+}
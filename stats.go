@@ -0,0 +1,95 @@
+package skip_list
+
+import "unsafe"
+
+type (
+	Stats struct {
+		Level, MaxLevel, Cap int32
+
+		// number of nodes marked as tombstones
+		Tombstones int32
+
+		// probability of promoting a node to the next level
+		Probability float64
+
+		// LevelHistogram[i] is the number of nodes whose tower has i+1 levels, including tombstones
+		LevelHistogram []int32
+	}
+)
+
+// Stats returns the structural statistics of SkipList.
+func (sl *SkipList[O, T]) Stats() Stats {
+	if sl == nil {
+		return Stats{}
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var s = Stats{
+		Level:          sl.level,
+		MaxLevel:       sl.maxLevel,
+		Cap:            sl.cap,
+		Tombstones:     sl.tombstoneCap,
+		Probability:    sl.p,
+		LevelHistogram: make([]int32, sl.level),
+	}
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		s.LevelHistogram[len(n.nextNodes)-1]++
+	}
+	return s
+}
+
+// EstimatedBytes returns the approximate bytes occupied by SkipList, including its nodes, their towers,
+// the fixed-size part of keys and values, and the bytes of string keys.
+// Memory referenced by values, such as pointers, slices and maps, is not counted.
+func (sl *SkipList[O, T]) EstimatedBytes() int64 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	const ptrSize = int64(unsafe.Sizeof(uintptr(0)))
+
+	var (
+		nodeSize = int64(unsafe.Sizeof(node[O, T]{}))
+		bytes    = int64(unsafe.Sizeof(*sl)) + nodeSize + int64(cap(sl.head.nextNodes))*ptrSize
+	)
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		bytes += nodeSize
+		if len(n.nextNodes) > inlineLevels {
+			// tower out of inline
+			bytes += int64(cap(n.nextNodes)) * ptrSize
+		}
+		if s, ok := any(n.key).(string); ok {
+			bytes += int64(len(s))
+		}
+	}
+	return bytes
+}
+
+// ForEachLevel calls fn for every level from the top down with the keys linked on it in order, including tombstones.
+func (sl *SkipList[O, T]) ForEachLevel(fn func(level int, keys []O)) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	for l := sl.level - 1; l >= 0; l-- {
+		var keys = make([]O, 0)
+		for n := sl.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			keys = append(keys, n.key)
+		}
+		fn(int(l), keys)
+	}
+}
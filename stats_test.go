@@ -0,0 +1,143 @@
+package skip_list
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestSkipList_Stats(t *testing.T) {
+	var sl *SkipList[int, int]
+	t.Run("TestSkipList_Stats 1", func(t *testing.T) {
+		if got := sl.Stats(); !reflect.DeepEqual(got, Stats{}) {
+			t.Errorf("Stats() = %v, want %v", got, Stats{})
+		}
+	})
+
+	sl = NewSkipList[int, int](10, false)
+	t.Run("TestSkipList_Stats 2", func(t *testing.T) {
+		want := Stats{Level: 1, MaxLevel: 10, Probability: 0.5, LevelHistogram: []int32{0}}
+		if got := sl.Stats(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Stats() = %v, want %v", got, want)
+		}
+	})
+
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+	t.Run("TestSkipList_Stats 3", func(t *testing.T) {
+		got := sl.Stats()
+		if got.Cap != 100 || got.Level != sl.Level() || int32(len(got.LevelHistogram)) != got.Level {
+			t.Errorf("Stats() = %v", got)
+		}
+		var sum int32
+		for _, c := range got.LevelHistogram {
+			sum += c
+		}
+		if sum != got.Cap {
+			t.Errorf("Stats() histogram sum = %v, want %v", sum, got.Cap)
+		}
+	})
+}
+
+func TestSkipList_Stats_Probability(t *testing.T) {
+	const (
+		n         = 200000
+		tolerance = 0.01
+	)
+	for _, p := range []float64{0.5, 0.25} {
+		sl := NewSkipList[int, int](32, false, WithProbability(p))
+		for i := 0; i < n; i++ {
+			sl.Put(i, i)
+		}
+
+		s := sl.Stats()
+		// tower heights are geometrically distributed: P(height = h+1) = (1-p) * p^h
+		for h := 0; h < 4 && h < len(s.LevelHistogram); h++ {
+			got := float64(s.LevelHistogram[h]) / n
+			want := (1 - p) * math.Pow(p, float64(h))
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("p = %v, height %v frequency = %v, want %v", p, h+1, got, want)
+			}
+		}
+	}
+}
+
+func TestSkipList_EstimatedBytes(t *testing.T) {
+	var sl *SkipList[int, int]
+	t.Run("TestSkipList_EstimatedBytes 1", func(t *testing.T) {
+		if got := sl.EstimatedBytes(); got != 0 {
+			t.Errorf("EstimatedBytes() = %v, want 0", got)
+		}
+	})
+
+	sl = NewSkipList[int, int](16, false)
+	var (
+		empty    = sl.EstimatedBytes()
+		nodeSize = int64(unsafe.Sizeof(node[int, int]{}))
+		sizes    []int64
+	)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 10000; j++ {
+			sl.Put(i*10000+j, j)
+		}
+		sizes = append(sizes, sl.EstimatedBytes())
+	}
+
+	t.Run("TestSkipList_EstimatedBytes 2", func(t *testing.T) {
+		for i, size := range sizes {
+			n := int64(i+1) * 10000
+			perNode := float64(size-empty) / float64(n)
+			if perNode < float64(nodeSize) || perNode > float64(nodeSize)*1.5 {
+				t.Errorf("EstimatedBytes() = %v with %v nodes, %v bytes per node", size, n, perNode)
+			}
+		}
+		for i := 1; i < len(sizes); i++ {
+			grow := float64(sizes[i] - sizes[i-1])
+			if first := float64(sizes[0] - empty); math.Abs(grow-first)/first > 0.1 {
+				t.Errorf("EstimatedBytes() grows %v, want about %v", grow, first)
+			}
+		}
+	})
+
+	var strSl = NewSkipList[string, int](16, false)
+	strSl.Put("abcdefgh", 1)
+	t.Run("TestSkipList_EstimatedBytes 3", func(t *testing.T) {
+		want := strSl.EstimatedBytes() - int64(unsafe.Sizeof(node[string, int]{})) - 8
+		strSl.Delete("abcdefgh")
+		if got := strSl.EstimatedBytes(); got != want {
+			t.Errorf("EstimatedBytes() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSkipList_ForEachLevel(t *testing.T) {
+	type level struct {
+		level int
+		keys  []int
+	}
+	var (
+		heights = []int{1, 0, 2, 0, 1}
+		sl      = NewSkipList[int, int](3, false, WithLevelFunc(func(n int) int { return heights[n] }))
+		got     []level
+		collect = func(l int, keys []int) { got = append(got, level{l, keys}) }
+	)
+
+	sl.ForEachLevel(collect)
+	if want := []level{{0, []int{}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEachLevel() of empty SkipList = %v, want %v", got, want)
+	}
+
+	for i := range heights {
+		sl.Put(i*10, i)
+	}
+	got = nil
+	sl.ForEachLevel(collect)
+	if want := []level{{2, []int{20}}, {1, []int{0, 20, 40}}, {0, []int{0, 10, 20, 30, 40}}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEachLevel() = %v, want %v", got, want)
+	}
+
+	var empty *SkipList[int, int]
+	empty.ForEachLevel(func(int, []int) { t.Errorf("ForEachLevel() of nil SkipList calls fn") })
+}
@@ -0,0 +1,100 @@
+package skip_list
+
+import (
+	"context"
+
+	"golang.org/x/exp/constraints"
+)
+
+// RangeChan streams the KvPair of key in [start, end] in key order over a channel of buffer buf, which is closed at the end
+// of the range or once ctx is done, whichever comes first. The pairs are read in chunks, under the read lock if concurrent,
+// and sent without holding it, so the stream is not a snapshot under concurrent writes. A consumer abandoning the channel
+// must cancel ctx to release the producing goroutine.
+func (sl *SkipList[O, T]) RangeChan(ctx context.Context, start, end O, buf int) <-chan KvPair[O, T] {
+	var ch = make(chan KvPair[O, T], buf)
+	if sl == nil {
+		close(ch)
+		return ch
+	}
+
+	var lock, unlock = func() {}, func() {}
+	if sl.isConcurrent {
+		lock, unlock = sl.RLock, sl.RUnlock
+	}
+	go func() {
+		defer close(ch)
+		sl.scanChunks(&start, &end, defaultRangeChunk, lock, unlock, sendTo(ctx, ch))
+	}()
+	return ch
+}
+
+// RangeChan is SkipList.RangeChan scanning as Range does.
+func (c *ConcurrentSkipList[O, T]) RangeChan(ctx context.Context, start, end O, buf int) <-chan KvPair[O, T] {
+	var ch = make(chan KvPair[O, T], buf)
+	if c == nil {
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		defer close(ch)
+		c.scan(&start, &end, sendTo(ctx, ch))
+	}()
+	return ch
+}
+
+// sendTo returns a callback of scans sending to ch, which stops the scan once ctx is done.
+func sendTo[O constraints.Ordered, T any](ctx context.Context, ch chan<- KvPair[O, T]) func(key O, val T) bool {
+	return func(key O, val T) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		select {
+		case ch <- KvPair[O, T]{key: key, val: val}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// scanChunks calls fn for each key in [start, end] in order until fn returns false, where nil bounds are unbounded.
+// Up to size keys are copied between lock and unlock at a time, and fn is called without holding it,
+// the next chunk resuming after the last key copied.
+func (sl *SkipList[O, T]) scanChunks(start, end *O, size int, lock, unlock func(), fn func(key O, val T) bool) {
+	var (
+		chunk     = make([]KvPair[O, T], 0, size)
+		inclusive = true
+	)
+	for {
+		lock()
+		chunk = sl.chunk(chunk[:0], start, inclusive, end)
+		unlock()
+
+		for _, kv := range chunk {
+			if !fn(kv.key, kv.val) {
+				return
+			}
+		}
+		if len(chunk) < size {
+			return
+		}
+		last := chunk[len(chunk)-1].key
+		start, inclusive = &last, false
+	}
+}
+
+// chunk appends to dst up to cap(dst) pairs from start, or after it if not inclusive, to end.
+func (sl *SkipList[O, T]) chunk(dst []KvPair[O, T], start *O, inclusive bool, end *O) []KvPair[O, T] {
+	var n = sl.head.next()
+	if start != nil {
+		n = sl.ceil(*start)
+		if !inclusive && n != nil && n.key == *start {
+			n = n.next()
+		}
+	}
+	for ; n != nil && len(dst) < cap(dst) && (end == nil || n.key <= *end); n = n.next() {
+		dst = append(dst, KvPair[O, T]{key: n.key, val: n.val})
+	}
+	return dst
+}
@@ -0,0 +1,106 @@
+package skip_list
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRangeChan(t *testing.T) {
+	const keys = 3000
+
+	type testCase struct {
+		name string
+		// RangeChan of a list of keys 0, 2, ..., 2*(keys-1) with values -key
+		rangeChan func(ctx context.Context, start, end, buf int) <-chan KvPair[int, int]
+	}
+	var (
+		sl   = NewSkipList[int, int](0, false)
+		csl  = NewSkipList[int, int](0, true)
+		c    = NewConcurrent[int, int](0, WithRangeChunk(100))
+		copt = NewConcurrent[int, int](0, WithOptimisticLocking())
+	)
+	for i := 0; i < keys; i++ {
+		sl.Put(i*2, -i*2)
+		csl.Put(i*2, -i*2)
+		c.Put(i*2, -i*2)
+		copt.Put(i*2, -i*2)
+	}
+	tests := []testCase{
+		{
+			name:      "TestRangeChan 1",
+			rangeChan: sl.RangeChan,
+		},
+		{
+			name:      "TestRangeChan 2",
+			rangeChan: csl.RangeChan,
+		},
+		{
+			name:      "TestRangeChan 3",
+			rangeChan: c.RangeChan,
+		},
+		{
+			name:      "TestRangeChan 4",
+			rangeChan: copt.RangeChan,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// full consumption
+			var got []KvPair[int, int]
+			for kv := range tt.rangeChan(context.Background(), 1, 4000, 0) {
+				got = append(got, kv)
+			}
+			var want []KvPair[int, int]
+			for _, kv := range sl.Range(1, 4000) {
+				want = append(want, *kv)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("RangeChan() = %v pairs, want %v", len(got), len(want))
+			}
+
+			// early cancellation
+			ctx, cancel := context.WithCancel(context.Background())
+			var (
+				ch    = tt.rangeChan(ctx, 0, 2*keys, 4)
+				count int
+			)
+			for range ch {
+				if count++; count == 10 {
+					cancel()
+				}
+			}
+			// at most the buffer and one pending send are delivered after cancel
+			if count < 10 || count > 10+4+1 {
+				t.Errorf("RangeChan() delivered %v pairs after cancel at 10", count)
+			}
+
+			// an abandoned channel is released by cancel
+			before := runtime.NumGoroutine()
+			ctx, cancel = context.WithCancel(context.Background())
+			<-tt.rangeChan(ctx, 0, 2*keys, 0)
+			cancel()
+			for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > before; {
+				if time.Now().After(deadline) {
+					t.Fatalf("RangeChan() leaks its goroutine, %v > %v", runtime.NumGoroutine(), before)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		})
+	}
+
+	t.Run("TestRangeChan 5", func(t *testing.T) {
+		var (
+			nilSl *SkipList[int, int]
+			nilC  *ConcurrentSkipList[int, int]
+		)
+		if _, ok := <-nilSl.RangeChan(context.Background(), 0, 1, 0); ok {
+			t.Errorf("RangeChan() of nil SkipList is not closed")
+		}
+		if _, ok := <-nilC.RangeChan(context.Background(), 0, 1, 0); ok {
+			t.Errorf("RangeChan() of nil ConcurrentSkipList is not closed")
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package skip_list
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	// SyncMapAdapter exposes a ConcurrentSkipList through the method set of sync.Map, while its ordered methods
+	// remain reachable through the embedded ConcurrentSkipList. Keys of another type than O are absent,
+	// and storing a key or value of another type than O or T panics, where a nil value is the zero T.
+	SyncMapAdapter[O constraints.Ordered, T any] struct {
+		*ConcurrentSkipList[O, T]
+	}
+)
+
+// NewSyncMapAdapter returns a SyncMapAdapter of c.
+func NewSyncMapAdapter[O constraints.Ordered, T any](c *ConcurrentSkipList[O, T]) *SyncMapAdapter[O, T] {
+	if c == nil {
+		return nil
+	}
+	return &SyncMapAdapter[O, T]{ConcurrentSkipList: c}
+}
+
+// Load returns the value stored for key, or nil if not exist.
+func (m *SyncMapAdapter[O, T]) Load(key any) (value any, ok bool) {
+	k, ok := key.(O)
+	if !ok {
+		return nil, false
+	}
+	if val, ok := m.Get(k); ok {
+		return val, true
+	}
+	return nil, false
+}
+
+// Store sets the value for key.
+func (m *SyncMapAdapter[O, T]) Store(key, value any) {
+	m.Put(mustKey[O](key), mustVal[T](value))
+}
+
+// LoadOrStore returns the existing value for key if present, or stores and returns value otherwise.
+// loaded is true if the value was loaded, false if stored.
+func (m *SyncMapAdapter[O, T]) LoadOrStore(key, value any) (actual any, loaded bool) {
+	return m.GetOrPut(mustKey[O](key), mustVal[T](value))
+}
+
+// LoadAndDelete deletes the value for key, returning the previous value if any.
+func (m *SyncMapAdapter[O, T]) LoadAndDelete(key any) (value any, loaded bool) {
+	k, ok := key.(O)
+	if !ok {
+		return nil, false
+	}
+	if val, loaded := m.ConcurrentSkipList.LoadAndDelete(k); loaded {
+		return val, true
+	}
+	return nil, false
+}
+
+// Delete deletes the value for key.
+func (m *SyncMapAdapter[O, T]) Delete(key any) {
+	if k, ok := key.(O); ok {
+		m.ConcurrentSkipList.Delete(k)
+	}
+}
+
+// Swap swaps the value for key and returns the previous value if any.
+func (m *SyncMapAdapter[O, T]) Swap(key, value any) (previous any, loaded bool) {
+	if previous, loaded := m.ConcurrentSkipList.Swap(mustKey[O](key), mustVal[T](value)); loaded {
+		return previous, true
+	}
+	return nil, false
+}
+
+// CompareAndSwap swaps the old and new values for key if the value stored is equal to old, which must be comparable.
+func (m *SyncMapAdapter[O, T]) CompareAndSwap(key, old, new any) (swapped bool) {
+	k, ok := key.(O)
+	if !ok {
+		return false
+	}
+	return m.ConcurrentSkipList.CompareAndSwap(k, zeroOf[T](), mustVal[T](new), func(val, _ T) bool { return any(val) == old })
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old, which must be comparable.
+func (m *SyncMapAdapter[O, T]) CompareAndDelete(key, old any) (deleted bool) {
+	k, ok := key.(O)
+	if !ok {
+		return false
+	}
+	return m.ConcurrentSkipList.CompareAndDelete(k, zeroOf[T](), func(val, _ T) bool { return any(val) == old })
+}
+
+// Range calls f sequentially for each key and value in key order until f returns false.
+// As sync.Map, it is not a snapshot under concurrent writes, but visits no key more than once.
+func (m *SyncMapAdapter[O, T]) Range(f func(key, value any) bool) {
+	m.ForEach(func(key O, val T) bool { return f(key, val) })
+}
+
+func mustKey[O constraints.Ordered](key any) O {
+	k, ok := key.(O)
+	if !ok {
+		panic(fmt.Sprintf("skip_list: key of type %T, want %T", key, k))
+	}
+	return k
+}
+
+func mustVal[T any](value any) T {
+	if value == nil {
+		return zeroOf[T]()
+	}
+	val, ok := value.(T)
+	if !ok {
+		panic(fmt.Sprintf("skip_list: value of type %T, want %T", value, val))
+	}
+	return val
+}
+
+func zeroOf[T any]() (zero T) {
+	return
+}
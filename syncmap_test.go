@@ -0,0 +1,199 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// syncMap is the method set of sync.Map.
+type syncMap interface {
+	Load(key any) (value any, ok bool)
+	Store(key, value any)
+	LoadOrStore(key, value any) (actual any, loaded bool)
+	LoadAndDelete(key any) (value any, loaded bool)
+	Delete(key any)
+	Swap(key, value any) (previous any, loaded bool)
+	CompareAndSwap(key, old, new any) (swapped bool)
+	CompareAndDelete(key, old any) (deleted bool)
+	Range(f func(key, value any) bool)
+}
+
+var (
+	_ syncMap = (*sync.Map)(nil)
+	_ syncMap = (*SyncMapAdapter[int, any])(nil)
+)
+
+func TestSyncMapAdapter(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		var (
+			r    = rand.New(rand.NewSource(1))
+			want sync.Map
+			m    = NewSyncMapAdapter(NewConcurrent[int, any](0, opts...))
+		)
+		// the same operations on sync.Map and SyncMapAdapter give the same results
+		for i := 0; i < 20000; i++ {
+			var (
+				key = r.Intn(50)
+				val any
+			)
+			if v := r.Intn(5); v > 0 {
+				// nil values are stored as well
+				val = v
+			}
+			var got, exp []any
+			switch r.Intn(8) {
+			case 0:
+				v, ok := m.Load(key)
+				got = []any{v, ok}
+				v, ok = want.Load(key)
+				exp = []any{v, ok}
+			case 1:
+				m.Store(key, val)
+				want.Store(key, val)
+			case 2:
+				v, ok := m.LoadOrStore(key, val)
+				got = []any{v, ok}
+				v, ok = want.LoadOrStore(key, val)
+				exp = []any{v, ok}
+			case 3:
+				v, ok := m.LoadAndDelete(key)
+				got = []any{v, ok}
+				v, ok = want.LoadAndDelete(key)
+				exp = []any{v, ok}
+			case 4:
+				m.Delete(key)
+				want.Delete(key)
+			case 5:
+				v, ok := m.Swap(key, val)
+				got = []any{v, ok}
+				v, ok = want.Swap(key, val)
+				exp = []any{v, ok}
+			case 6:
+				got = []any{m.CompareAndSwap(key, val, i)}
+				exp = []any{want.CompareAndSwap(key, val, i)}
+			case 7:
+				got = []any{m.CompareAndDelete(key, val)}
+				exp = []any{want.CompareAndDelete(key, val)}
+			}
+			if !reflect.DeepEqual(got, exp) {
+				t.Fatalf("operation %v on %v = %v, want %v", i, key, got, exp)
+			}
+		}
+
+		var (
+			got  = make(map[any]any)
+			exp  = make(map[any]any)
+			keys []int
+		)
+		m.Range(func(key, value any) bool {
+			got[key] = value
+			keys = append(keys, key.(int))
+			return true
+		})
+		want.Range(func(key, value any) bool {
+			exp[key] = value
+			return true
+		})
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("Range() = %v, want %v", got, exp)
+		}
+		for i := 1; i < len(keys); i++ {
+			if keys[i-1] >= keys[i] {
+				t.Errorf("Range() keys = %v, want ascending", keys)
+			}
+		}
+		var visited int
+		m.Range(func(_, _ any) bool {
+			visited++
+			return false
+		})
+		if visited != 1 {
+			t.Errorf("Range() visited %v keys after false, want 1", visited)
+		}
+	}
+}
+
+func TestSyncMapAdapter_Types(t *testing.T) {
+	m := NewSyncMapAdapter(NewConcurrent[int, string](10))
+	m.Store(1, "a")
+	m.Store(2, nil)
+
+	// a key of another type is absent
+	if v, ok := m.Load("1"); v != nil || ok {
+		t.Errorf("Load(\"1\") = %v, %v, want nil, false", v, ok)
+	}
+	if v, ok := m.LoadAndDelete(1.0); v != nil || ok {
+		t.Errorf("LoadAndDelete(1.0) = %v, %v, want nil, false", v, ok)
+	}
+	m.Delete("1")
+	if m.CompareAndSwap("1", "a", "b") || m.CompareAndDelete("1", "a") {
+		t.Errorf("CompareAndSwap(), CompareAndDelete() of a key of another type = true")
+	}
+	if m.CompareAndSwap(1, 1, "b") {
+		t.Errorf("CompareAndSwap() of an old value of another type = true")
+	}
+	// a nil value is the zero value
+	if v, ok := m.Load(2); v != "" || !ok {
+		t.Errorf("Load(2) = %v, %v, want \"\", true", v, ok)
+	}
+
+	// ordered methods remain reachable
+	if got, want := m.ConcurrentSkipList.Range(1, 2), []*KvPair[int, string]{{1, "a"}, {2, ""}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+	if kv, ok := m.Floor(10); !ok || kv.key != 2 {
+		t.Errorf("Floor(10) = %v, %v, want 2", kv, ok)
+	}
+
+	for _, store := range []func(){
+		func() { m.Store("1", "a") },
+		func() { m.Store(1, 1) },
+		func() { m.LoadOrStore(1, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("storing a key or value of another type does not panic")
+				}
+			}()
+			store()
+		}()
+	}
+
+	if m := NewSyncMapAdapter[int, int](nil); m != nil {
+		t.Errorf("NewSyncMapAdapter(nil) = %v, want nil", m)
+	}
+}
+
+func TestSyncMapAdapter_LoadOrStore(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithOptimisticLocking()}} {
+		var (
+			m      = NewSyncMapAdapter(NewConcurrent[int, int](0, opts...))
+			stored int32
+			wg     sync.WaitGroup
+		)
+		// exactly one store wins per key, and every caller sees its value
+		for g := 0; g < 16; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for key := 0; key < 100; key++ {
+					actual, loaded := m.LoadOrStore(key, g)
+					if !loaded {
+						atomic.AddInt32(&stored, 1)
+					}
+					if v, _ := m.Load(key); v != actual {
+						t.Errorf("Load(%v) = %v, want %v", key, v, actual)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		if stored != 100 {
+			t.Errorf("LoadOrStore() stored %v times, want 100", stored)
+		}
+	}
+}
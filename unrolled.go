@@ -0,0 +1,480 @@
+package skip_list
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// unrolledBlockSize is the maximum number of kv-pairs in a block of UnrolledSkipList.
+const unrolledBlockSize = 16
+
+type (
+	// UnrolledSkipList is a SkipList whose nodes are blocks of up to unrolledBlockSize sorted kv-pairs,
+	// and whose upper levels index the first key of every block.
+	// Scans walk within blocks instead of chasing a pointer per kv-pair, which is cache friendly.
+	UnrolledSkipList[O constraints.Ordered, T any] struct {
+		level, maxLevel, cap int32
+
+		// probability of promoting a block to the next level
+		p float64
+
+		// head block of UnrolledSkipList, which holds no kv-pair
+		head *block[O, T]
+
+		// randomly generate level when linking a block
+		r *rand.Rand
+
+		// concurrent
+		isConcurrent bool
+		sync.RWMutex
+	}
+
+	block[O constraints.Ordered, T any] struct {
+		// sorted kv-pairs, never empty once linked
+		pairs      []KvPair[O, T]
+		nextBlocks []*block[O, T]
+	}
+)
+
+// NewUnrolledSkipList returns an UnrolledSkipList whose blocks have at most maxLevel+1 levels, maxLevel must be positive.
+// WithProbability and WithRandSource are honored, other options are ignored.
+func NewUnrolledSkipList[O constraints.Ordered, T any](maxLevel int32, isConcurrent bool, opts ...Option) *UnrolledSkipList[O, T] {
+	if maxLevel <= 0 {
+		return nil
+	}
+
+	o := newOptions(opts...)
+	if !o.valid() {
+		return nil
+	}
+
+	src := o.randSource
+	if src == nil {
+		src = rand.NewSource(time.Now().Unix())
+	}
+
+	return &UnrolledSkipList[O, T]{
+		level:        1,
+		maxLevel:     maxLevel,
+		cap:          0,
+		p:            o.p,
+		head:         &block[O, T]{nextBlocks: make([]*block[O, T], 1)},
+		r:            rand.New(src),
+		isConcurrent: isConcurrent,
+	}
+}
+
+func (sl *UnrolledSkipList[O, T]) Level() int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.level
+}
+
+func (sl *UnrolledSkipList[O, T]) Cap() int32 {
+	if sl == nil {
+		return 0
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	return sl.cap
+}
+
+func (sl *UnrolledSkipList[O, T]) Get(key O) (val T, exist bool) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if b := sl.find(key); b != sl.head {
+		if i, ok := b.search(key); ok {
+			return b.pairs[i].val, true
+		}
+	}
+	return
+}
+
+func (sl *UnrolledSkipList[O, T]) Put(key O, val T) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	b := sl.find(key)
+	if b == sl.head {
+		// key is less than every key
+		b = sl.head.nextBlocks[0]
+		if b == nil {
+			// first block
+			b = sl.newBlock()
+			b.pairs = append(b.pairs, KvPair[O, T]{key: key, val: val})
+			sl.link(b)
+			sl.cap++
+			return
+		}
+	}
+
+	i, ok := b.search(key)
+	if ok {
+		// update
+		b.pairs[i].val = val
+		return
+	}
+
+	if len(b.pairs) == unrolledBlockSize {
+		// split
+		if nb := sl.split(b); key > nb.first() {
+			b = nb
+		}
+		i, _ = b.search(key)
+	}
+
+	// insert
+	b.pairs = append(b.pairs, KvPair[O, T]{})
+	copy(b.pairs[i+1:], b.pairs[i:])
+	b.pairs[i] = KvPair[O, T]{key: key, val: val}
+	sl.cap++
+}
+
+func (sl *UnrolledSkipList[O, T]) Delete(key O) {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	b := sl.find(key)
+	if b == sl.head {
+		// not exist
+		return
+	}
+	i, ok := b.search(key)
+	if !ok {
+		// not exist
+		return
+	}
+
+	sl.cap--
+	if len(b.pairs) == 1 {
+		// delete the block
+		sl.unlink(b)
+		sl.cut()
+		return
+	}
+
+	// delete
+	copy(b.pairs[i:], b.pairs[i+1:])
+	b.pairs[len(b.pairs)-1] = KvPair[O, T]{}
+	b.pairs = b.pairs[:len(b.pairs)-1]
+
+	// merge the underfull block with the next one
+	if next := b.nextBlocks[0]; next != nil && len(b.pairs) < unrolledBlockSize/2 && len(b.pairs)+len(next.pairs) <= unrolledBlockSize {
+		sl.unlink(next)
+		b.pairs = append(b.pairs, next.pairs...)
+		sl.cut()
+	}
+}
+
+// Range searches the *KvPair of key in [start, end].
+func (sl *UnrolledSkipList[O, T]) Range(start, end O) []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	var res = make([]*KvPair[O, T], 0)
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	// starting point
+	for b, i := sl.ceil(start); b != nil; b, i = b.nextBlocks[0], 0 {
+		// range within the block
+		for ; i < len(b.pairs); i++ {
+			if b.pairs[i].key > end {
+				return res
+			}
+			res = append(res, newKvPair(b.pairs[i].key, b.pairs[i].val))
+		}
+	}
+	return res
+}
+
+// Items returns all *KvPair in key order.
+func (sl *UnrolledSkipList[O, T]) Items() []*KvPair[O, T] {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	var res = make([]*KvPair[O, T], 0, sl.cap)
+	for b := sl.head.nextBlocks[0]; b != nil; b = b.nextBlocks[0] {
+		for i := range b.pairs {
+			res = append(res, newKvPair(b.pairs[i].key, b.pairs[i].val))
+		}
+	}
+	return res
+}
+
+// Ceil returns *KvPair of the least key greater than or equal to target.
+func (sl *UnrolledSkipList[O, T]) Ceil(target O) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if b, i := sl.ceil(target); b != nil {
+		return newKvPair(b.pairs[i].key, b.pairs[i].val), true
+	}
+	return nil, false
+}
+
+// Floor returns *KvPair of the greatest key less than or equal to target.
+func (sl *UnrolledSkipList[O, T]) Floor(target O) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	b := sl.find(target)
+	if b == sl.head {
+		// floor is not exist
+		return nil, false
+	}
+
+	// the first key of b is less than or equal to target
+	i, ok := b.search(target)
+	if !ok {
+		i--
+	}
+	return newKvPair(b.pairs[i].key, b.pairs[i].val), true
+}
+
+// Validate checks the invariants of UnrolledSkipList and returns the first violation found.
+func (sl *UnrolledSkipList[O, T]) Validate() error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if sl.level < 1 || sl.level > sl.maxLevel+1 || len(sl.head.nextBlocks) != int(sl.level) {
+		return fmt.Errorf("skip_list: level %d with %d head levels out of [1, %d]", sl.level, len(sl.head.nextBlocks), sl.maxLevel+1)
+	}
+	if sl.level > 1 && sl.head.nextBlocks[sl.level-1] == nil {
+		return fmt.Errorf("skip_list: top level %d is empty", sl.level-1)
+	}
+
+	// heights[l] is the number of blocks with more than l levels
+	var (
+		heights = make([]int32, sl.level)
+		cap     int32
+	)
+	for b := sl.head.nextBlocks[0]; b != nil; b = b.nextBlocks[0] {
+		if len(b.pairs) == 0 || len(b.pairs) > unrolledBlockSize {
+			return fmt.Errorf("skip_list: block has %d kv-pairs, want [1, %d]", len(b.pairs), unrolledBlockSize)
+		}
+		for i := 1; i < len(b.pairs); i++ {
+			if b.pairs[i-1].key >= b.pairs[i].key {
+				return fmt.Errorf("skip_list: block is not ascending at %v", b.pairs[i].key)
+			}
+		}
+		if next := b.nextBlocks[0]; next != nil && len(next.pairs) > 0 && b.pairs[len(b.pairs)-1].key >= next.first() {
+			return fmt.Errorf("skip_list: blocks are not ascending at %v", next.first())
+		}
+		if h := len(b.nextBlocks); h < 1 || h > int(sl.level) {
+			return fmt.Errorf("skip_list: block %v has %d levels, want [1, %d]", b.first(), h, sl.level)
+		}
+		for l := range b.nextBlocks {
+			heights[l]++
+		}
+		cap += int32(len(b.pairs))
+	}
+	if cap != sl.cap {
+		return fmt.Errorf("skip_list: %d kv-pairs, want cap %d", cap, sl.cap)
+	}
+
+	for l := int32(1); l < sl.level; l++ {
+		var (
+			count int32
+			lower = sl.head
+		)
+		for b := sl.head.nextBlocks[l]; b != nil; b = b.nextBlocks[l] {
+			// every block on level l is on level l-1
+			for lower != nil && lower != b {
+				lower = lower.nextBlocks[l-1]
+			}
+			if lower == nil {
+				return fmt.Errorf("skip_list: block %v on level %d is not on level %d", b.first(), l, l-1)
+			}
+			count++
+		}
+		if count != heights[l] {
+			return fmt.Errorf("skip_list: %d blocks on level %d, want %d", count, l, heights[l])
+		}
+	}
+	return nil
+}
+
+// find returns the last block whose first key is less than or equal to key, or sl.head if not exist.
+func (sl *UnrolledSkipList[O, T]) find(key O) *block[O, T] {
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextBlocks[l] != nil && move.nextBlocks[l].first() <= key {
+			// search to the right
+			move = move.nextBlocks[l]
+		}
+
+		// search down
+	}
+	return move
+}
+
+// ceil returns the block and index of the least key greater than or equal to target, or nil if not exist.
+func (sl *UnrolledSkipList[O, T]) ceil(target O) (*block[O, T], int) {
+	b := sl.find(target)
+	if b == sl.head {
+		// the first key is ceil if exist
+		return sl.head.nextBlocks[0], 0
+	}
+
+	i, _ := b.search(target)
+	if i == len(b.pairs) {
+		// the first key of the next block is ceil if exist
+		return b.nextBlocks[0], 0
+	}
+	return b, i
+}
+
+// split moves the upper half of b into a new block linked after it, and returns the new block.
+func (sl *UnrolledSkipList[O, T]) split(b *block[O, T]) *block[O, T] {
+	var half = len(b.pairs) / 2
+
+	nb := sl.newBlock()
+	nb.pairs = append(nb.pairs, b.pairs[half:]...)
+	for i := half; i < len(b.pairs); i++ {
+		b.pairs[i] = KvPair[O, T]{}
+	}
+	b.pairs = b.pairs[:half]
+
+	sl.link(nb)
+	return nb
+}
+
+// link links b of random level, which must not be empty.
+func (sl *UnrolledSkipList[O, T]) link(b *block[O, T]) {
+	var randL int32
+	for sl.r.Float64() < sl.p && randL < sl.maxLevel {
+		randL++
+	}
+
+	// grow
+	if sl.level < randL+1 {
+		sl.head.nextBlocks = append(sl.head.nextBlocks, make([]*block[O, T], randL+1-sl.level)...)
+		sl.level = randL + 1
+	}
+
+	b.nextBlocks = make([]*block[O, T], randL+1)
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextBlocks[l] != nil && move.nextBlocks[l].first() < b.first() {
+			// search to the right
+			move = move.nextBlocks[l]
+		}
+
+		if l <= randL {
+			// insert
+			b.nextBlocks[l] = move.nextBlocks[l]
+			move.nextBlocks[l] = b
+		}
+
+		// search down
+	}
+}
+
+// unlink unlinks b from every level, which must not be empty.
+func (sl *UnrolledSkipList[O, T]) unlink(b *block[O, T]) {
+	move := sl.head
+	for l := sl.level - 1; l >= 0; l-- {
+		for move.nextBlocks[l] != nil && move.nextBlocks[l].first() < b.first() {
+			// search to the right
+			move = move.nextBlocks[l]
+		}
+
+		if move.nextBlocks[l] == b {
+			// delete
+			move.nextBlocks[l] = b.nextBlocks[l]
+		}
+
+		// search down
+	}
+}
+
+func (sl *UnrolledSkipList[O, T]) cut() {
+	var dif int32
+	for l := sl.level - 1; l > 0; l-- {
+		if sl.head.nextBlocks[l] != nil {
+			break
+		}
+		dif++
+	}
+	sl.head.nextBlocks = sl.head.nextBlocks[:sl.level-dif]
+
+	sl.level -= dif
+}
+
+func (sl *UnrolledSkipList[O, T]) newBlock() *block[O, T] {
+	return &block[O, T]{pairs: make([]KvPair[O, T], 0, unrolledBlockSize)}
+}
+
+func (b *block[O, T]) first() O {
+	return b.pairs[0].key
+}
+
+// search returns the index of the least key greater than or equal to key in b, and whether it equals key.
+func (b *block[O, T]) search(key O) (int, bool) {
+	i := sort.Search(len(b.pairs), func(i int) bool { return b.pairs[i].key >= key })
+	return i, i < len(b.pairs) && b.pairs[i].key == key
+}
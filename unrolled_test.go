@@ -0,0 +1,199 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+func TestUnrolledSkipList_Get(t *testing.T) {
+	type testCase[O constraints.Ordered, T any] struct {
+		name      string
+		sl        *UnrolledSkipList[O, T]
+		key       O
+		wantVal   T
+		wantExist bool
+	}
+
+	var sl = NewUnrolledSkipList[int, int](10, false)
+	for i := 0; i < 100; i += 2 {
+		sl.Put(i, i)
+	}
+
+	tests := []testCase[int, int]{
+		{"TestUnrolledSkipList_Get 1", nil, 1, 0, false},
+		{"TestUnrolledSkipList_Get 2", sl, 0, 0, true},
+		{"TestUnrolledSkipList_Get 3", sl, 98, 98, true},
+		{"TestUnrolledSkipList_Get 4", sl, 51, 0, false},
+		{"TestUnrolledSkipList_Get 5", sl, -1, 0, false},
+		{"TestUnrolledSkipList_Get 6", sl, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotVal, gotExist := tt.sl.Get(tt.key)
+			if gotVal != tt.wantVal || gotExist != tt.wantExist {
+				t.Errorf("Get() = %v, %v, want %v, %v", gotVal, gotExist, tt.wantVal, tt.wantExist)
+			}
+		})
+	}
+}
+
+func TestUnrolledSkipList_Range(t *testing.T) {
+	type args[O constraints.Ordered] struct {
+		start O
+		end   O
+	}
+	type testCase[O constraints.Ordered, T any] struct {
+		name string
+		sl   *UnrolledSkipList[O, T]
+		args args[O]
+		want []*KvPair[O, T]
+	}
+
+	var sl = NewUnrolledSkipList[int, int](10, false)
+	for i := 40; i > 0; i-- {
+		sl.Put(i, i)
+	}
+	rangeOf := func(start, end int) []*KvPair[int, int] {
+		var res = make([]*KvPair[int, int], 0)
+		for i := start; i <= end; i++ {
+			res = append(res, NewKvPair(i, i))
+		}
+		return res
+	}
+
+	tests := []testCase[int, int]{
+		{"TestUnrolledSkipList_Range 1", sl, args[int]{1, 3}, rangeOf(1, 3)},
+		{"TestUnrolledSkipList_Range 2", sl, args[int]{-5, 50}, rangeOf(1, 40)},
+		{"TestUnrolledSkipList_Range 3", sl, args[int]{10, 30}, rangeOf(10, 30)},
+		{"TestUnrolledSkipList_Range 4", sl, args[int]{41, 50}, rangeOf(41, 40)},
+		{"TestUnrolledSkipList_Range 5", NewUnrolledSkipList[int, int](10, false), args[int]{1, 3}, rangeOf(1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sl.Range(tt.args.start, tt.args.end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnrolledSkipList_CeilFloor(t *testing.T) {
+	var sl = NewUnrolledSkipList[int, int](10, false)
+	for i := 0; i < 200; i += 5 {
+		sl.Put(i, i)
+	}
+
+	type testCase struct {
+		name      string
+		target    int
+		wantCeil  *KvPair[int, int]
+		wantFloor *KvPair[int, int]
+	}
+	tests := []testCase{
+		{"TestUnrolledSkipList_CeilFloor 1", -1, &KvPair[int, int]{0, 0}, nil},
+		{"TestUnrolledSkipList_CeilFloor 2", 0, &KvPair[int, int]{0, 0}, &KvPair[int, int]{0, 0}},
+		{"TestUnrolledSkipList_CeilFloor 3", 77, &KvPair[int, int]{80, 80}, &KvPair[int, int]{75, 75}},
+		{"TestUnrolledSkipList_CeilFloor 4", 195, &KvPair[int, int]{195, 195}, &KvPair[int, int]{195, 195}},
+		{"TestUnrolledSkipList_CeilFloor 5", 196, nil, &KvPair[int, int]{195, 195}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := sl.Ceil(tt.target); !reflect.DeepEqual(got, tt.wantCeil) || ok != (tt.wantCeil != nil) {
+				t.Errorf("Ceil() = %v, %v, want %v", got, ok, tt.wantCeil)
+			}
+			if got, ok := sl.Floor(tt.target); !reflect.DeepEqual(got, tt.wantFloor) || ok != (tt.wantFloor != nil) {
+				t.Errorf("Floor() = %v, %v, want %v", got, ok, tt.wantFloor)
+			}
+		})
+	}
+}
+
+func TestUnrolledSkipList_Random(t *testing.T) {
+	var (
+		r   = rand.New(rand.NewSource(1))
+		sl  = NewUnrolledSkipList[int, int](16, false, WithRandSource(rand.NewSource(2)))
+		ref = make(map[int]int)
+	)
+	for i := 0; i < 100000; i++ {
+		key := r.Intn(3000)
+		if r.Intn(3) == 0 {
+			sl.Delete(key)
+			delete(ref, key)
+		} else {
+			sl.Put(key, i)
+			ref[key] = i
+		}
+		if i%10000 == 0 {
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v after %d operations", err, i)
+			}
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var keys = make([]int, 0, len(ref))
+	for key := range ref {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	var want = make([]*KvPair[int, int], 0, len(keys))
+	for _, key := range keys {
+		want = append(want, NewKvPair(key, ref[key]))
+	}
+	if got := sl.Items(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v, want %v", got, want)
+	}
+	if got := sl.Cap(); got != int32(len(want)) {
+		t.Errorf("Cap() = %v, want %v", got, len(want))
+	}
+
+	// drain
+	for _, key := range keys {
+		sl.Delete(key)
+	}
+	if err := sl.Validate(); err != nil || sl.Cap() != 0 || sl.Level() != 1 {
+		t.Errorf("Validate() error = %v, Cap() = %v, Level() = %v", err, sl.Cap(), sl.Level())
+	}
+}
+
+func BenchmarkUnrolledSkipList(b *testing.B) {
+	const n = 1 << 20
+
+	var (
+		sl = NewSkipList[int, int](20, false)
+		ul = NewUnrolledSkipList[int, int](20, false)
+	)
+	for _, i := range rand.Perm(n) {
+		sl.Put(i, i)
+		ul.Put(i, i)
+	}
+
+	b.Run("SkipList/Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl.Get(i * 7919 % n)
+		}
+	})
+	b.Run("UnrolledSkipList/Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ul.Get(i * 7919 % n)
+		}
+	})
+	b.Run("SkipList/Range1k", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			start := i * 7919 % (n - 1000)
+			sl.Range(start, start+999)
+		}
+	})
+	b.Run("UnrolledSkipList/Range1k", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			start := i * 7919 % (n - 1000)
+			ul.Range(start, start+999)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package skip_list
+
+import "fmt"
+
+// Validate checks the invariants of SkipList and returns the first violation found.
+func (sl *SkipList[O, T]) Validate() error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+
+	if sl.level < 1 || sl.level > sl.maxLevel+1 {
+		return fmt.Errorf("skip_list: level %d out of [1, %d]", sl.level, sl.maxLevel+1)
+	}
+	if len(sl.head.nextNodes) != int(sl.level) {
+		return fmt.Errorf("skip_list: head has %d levels, want %d", len(sl.head.nextNodes), sl.level)
+	}
+	if sl.level > 1 && sl.head.nextNodes[sl.level-1] == nil {
+		return fmt.Errorf("skip_list: top level %d is empty", sl.level-1)
+	}
+
+	// heights[l] is the number of nodes with more than l levels
+	var (
+		heights           = make([]int32, sl.level)
+		cap, tombstoneCap int32
+	)
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if h := len(n.nextNodes); h < 1 || h > int(sl.level) {
+			return fmt.Errorf("skip_list: node %v has %d levels, want [1, %d]", n.key, h, sl.level)
+		}
+		for l := range n.nextNodes {
+			heights[l]++
+		}
+		if n.deleted {
+			tombstoneCap++
+		} else {
+			cap++
+		}
+	}
+	if cap != sl.cap {
+		return fmt.Errorf("skip_list: %d nodes, want cap %d", cap, sl.cap)
+	}
+	if tombstoneCap != sl.tombstoneCap {
+		return fmt.Errorf("skip_list: %d tombstones, want %d", tombstoneCap, sl.tombstoneCap)
+	}
+	if tombstoneCap > 0 && !sl.tombstones && !sl.keepsHistory() {
+		return fmt.Errorf("skip_list: %d tombstones without tombstone mode or ReadView", tombstoneCap)
+	}
+
+	for l := int32(0); l < sl.level; l++ {
+		var (
+			count int32
+			lower = sl.head
+		)
+		for n := sl.head.nextNodes[l]; n != nil; n = n.nextNodes[l] {
+			if len(n.nextNodes) <= int(l) {
+				return fmt.Errorf("skip_list: node %v on level %d has %d levels", n.key, l, len(n.nextNodes))
+			}
+			if next := n.nextNodes[l]; next != nil && next.key <= n.key {
+				return fmt.Errorf("skip_list: level %d is not ascending at %v", l, n.key)
+			}
+			if l > 0 {
+				// every node on level l is on level l-1
+				for lower != nil && lower != n {
+					lower = lower.nextNodes[l-1]
+				}
+				if lower == nil {
+					return fmt.Errorf("skip_list: node %v on level %d is not on level %d", n.key, l, l-1)
+				}
+			}
+			count++
+		}
+		if count != heights[l] {
+			return fmt.Errorf("skip_list: %d nodes on level %d, want %d", count, l, heights[l])
+		}
+	}
+	return nil
+}
+
+// Reindex recounts the nodes and tombstones on level 0, and cuts the empty top levels, as a recovery after in-place
+// edits which desynchronize the counters from the nodes. It does not reorder, as keys are not edited in place.
+// SkipList has no spans in the towers to recompute. The index of WithValueIndex is rebuilt from the current values.
+func (sl *SkipList[O, T]) Reindex() {
+	if sl == nil {
+		return
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	var cap, tombstoneCap int32
+	for n := sl.head.nextNodes[0]; n != nil; n = n.nextNodes[0] {
+		if n.deleted {
+			tombstoneCap++
+		} else {
+			cap++
+		}
+	}
+	sl.cap, sl.tombstoneCap = cap, tombstoneCap
+	sl.values.rebuild(sl.head)
+
+	// cut
+	sl.cut()
+
+	// tune
+	sl.tune(sl.cap)
+}
@@ -0,0 +1,172 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSkipList_Validate(t *testing.T) {
+	var sl = NewSkipList[int, int](10, false)
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i)
+	}
+
+	type testCase struct {
+		name    string
+		corrupt func()
+		wantErr bool
+	}
+	tests := []testCase{
+		{
+			name:    "TestSkipList_Validate 1",
+			corrupt: func() {},
+			wantErr: false,
+		},
+		{
+			name:    "TestSkipList_Validate 2",
+			corrupt: func() { sl.cap++ },
+			wantErr: true,
+		},
+		{
+			name:    "TestSkipList_Validate 3",
+			corrupt: func() { sl.cap--; sl.head.nextNodes[0].key = 1000 },
+			wantErr: true,
+		},
+		{
+			name: "TestSkipList_Validate 4",
+			corrupt: func() {
+				sl.head.nextNodes[0].key = 0
+				n := sl.head.nextNodes[0]
+				for len(n.nextNodes) < 2 {
+					n = n.nextNodes[0]
+				}
+				n.nextNodes[1] = nil
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.corrupt()
+			if err := sl.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSkipList_Validate_Random(t *testing.T) {
+	var (
+		r   = rand.New(rand.NewSource(1))
+		sl  = NewSkipList[int, int](16, false, WithRandSource(rand.NewSource(2)))
+		ref = make(map[int]int)
+	)
+	for i := 0; i < 50000; i++ {
+		key := r.Intn(5000)
+		switch r.Intn(10) {
+		case 0, 1, 2, 3, 4:
+			sl.Put(key, i)
+			ref[key] = i
+		case 5, 6, 7:
+			sl.Delete(key)
+			delete(ref, key)
+		case 8:
+			sl.CompareAndDelete(key, ref[key], func(a, b int) bool { return a == b })
+			delete(ref, key)
+		case 9:
+			if i%1000 == 0 {
+				sl.Compact()
+			}
+		}
+		if i%5000 == 0 {
+			if err := sl.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v after %d operations", err, i)
+			}
+		}
+	}
+	if err := sl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var keys = make([]int, 0, len(ref))
+	for key := range ref {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	var want = make([]*KvPair[int, int], 0, len(keys))
+	for _, key := range keys {
+		want = append(want, &KvPair[int, int]{key, ref[key]})
+	}
+	if got := sl.Range(0, 5000); !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+}
+
+func TestSkipList_Reindex(t *testing.T) {
+	type testCase struct {
+		name    string
+		opts    []Option
+		desync  func(sl *SkipList[int, int])
+		wantCap int32
+	}
+	tests := []testCase{
+		{
+			name:    "TestSkipList_Reindex 1",
+			desync:  func(sl *SkipList[int, int]) {},
+			wantCap: 90,
+		},
+		{
+			name:    "TestSkipList_Reindex 2",
+			desync:  func(sl *SkipList[int, int]) { sl.cap += 7 },
+			wantCap: 90,
+		},
+		{
+			name:    "TestSkipList_Reindex 3",
+			opts:    []Option{WithTombstones()},
+			desync:  func(sl *SkipList[int, int]) { sl.cap, sl.tombstoneCap = 0, 0 },
+			wantCap: 90,
+		},
+		{
+			// an emptied top level
+			name: "TestSkipList_Reindex 4",
+			desync: func(sl *SkipList[int, int]) {
+				top := sl.level - 1
+				for n := sl.head.nextNodes[top]; n != nil; {
+					next := n.nextNodes[top]
+					n.nextNodes = n.nextNodes[:top]
+					n = next
+				}
+				sl.head.nextNodes[top] = nil
+			},
+			wantCap: 90,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, int](0, true, tt.opts...)
+			for i := 0; i < 100; i++ {
+				sl.Put(i, i)
+			}
+			for i := 0; i < 100; i += 10 {
+				sl.Delete(i)
+			}
+			tt.desync(sl)
+
+			sl.Reindex()
+			if sl.Cap() != tt.wantCap {
+				t.Errorf("Cap() = %v, want %v", sl.Cap(), tt.wantCap)
+			}
+			if err := sl.Validate(); err != nil {
+				t.Errorf("Validate() error = %v after Reindex()", err)
+			}
+			if got := len(sl.Items()); got != int(tt.wantCap) {
+				t.Errorf("len(Items()) = %v, want %v", got, tt.wantCap)
+			}
+		})
+	}
+
+	var nilSl *SkipList[int, int]
+	nilSl.Reindex()
+}
@@ -0,0 +1,119 @@
+package skip_list
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// valueIndexFuncs holds the functions of WithValueIndex until they are asserted at construction.
+type valueIndexFuncs[T any] struct {
+	hash func(T) uint64
+	eq   func(a, b T) bool
+}
+
+// valueIndex is the set of keys of each hash of values. Keys of colliding values share a set,
+// and are told apart by eq on their current values.
+type valueIndex[O constraints.Ordered, T any] struct {
+	valueIndexFuncs[T]
+	keys map[uint64]map[O]struct{}
+}
+
+func newValueIndex[O constraints.Ordered, T any](funcs valueIndexFuncs[T]) *valueIndex[O, T] {
+	return &valueIndex[O, T]{valueIndexFuncs: funcs, keys: make(map[uint64]map[O]struct{})}
+}
+
+func (ix *valueIndex[O, T]) add(key O, val T) {
+	if ix == nil {
+		return
+	}
+
+	h := ix.hash(val)
+	set := ix.keys[h]
+	if set == nil {
+		set = make(map[O]struct{})
+		ix.keys[h] = set
+	}
+	set[key] = struct{}{}
+}
+
+func (ix *valueIndex[O, T]) remove(key O, val T) {
+	if ix == nil {
+		return
+	}
+
+	h := ix.hash(val)
+	set := ix.keys[h]
+	delete(set, key)
+	if len(set) == 0 {
+		delete(ix.keys, h)
+	}
+}
+
+func (ix *valueIndex[O, T]) update(key O, old, new T) {
+	if ix == nil {
+		return
+	}
+
+	if h := ix.hash(new); h != ix.hash(old) {
+		ix.remove(key, old)
+		ix.add(key, new)
+	}
+}
+
+// rebuild indexes the nodes from head again.
+func (ix *valueIndex[O, T]) rebuild(head *node[O, T]) {
+	if ix == nil {
+		return
+	}
+
+	ix.keys = make(map[uint64]map[O]struct{})
+	for n := head.next(); n != nil; n = n.next() {
+		ix.add(n.key, n.val)
+	}
+}
+
+// FindByValue returns the keys whose values are equal to val by the eq of WithValueIndex in ascending order.
+// It looks up the keys of the hash of val instead of scanning the SkipList, and returns nil without WithValueIndex.
+// Values edited in place through GetRef are not reindexed until Reindex.
+func (sl *SkipList[O, T]) FindByValue(val T) []O {
+	if sl == nil || sl.values == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	var res = make([]O, 0)
+	for key := range sl.values.keys[sl.values.hash(val)] {
+		if n := sl.get(key); n != nil && !n.deleted && sl.values.eq(n.val, val) {
+			res = append(res, key)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// ContainsValue returns whether any key has a value equal to val by the eq of WithValueIndex.
+// It returns false without WithValueIndex.
+func (sl *SkipList[O, T]) ContainsValue(val T) bool {
+	if sl == nil || sl.values == nil {
+		return false
+	}
+
+	if sl.isConcurrent {
+		sl.RLock()
+		defer sl.RUnlock()
+	}
+	sl.count(&sl.gets)
+
+	for key := range sl.values.keys[sl.values.hash(val)] {
+		if n := sl.get(key); n != nil && !n.deleted && sl.values.eq(n.val, val) {
+			return true
+		}
+	}
+	return false
+}
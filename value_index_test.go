@@ -0,0 +1,171 @@
+package skip_list
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSkipList_FindByValue(t *testing.T) {
+	// few hashes, so that values collide
+	var (
+		hash = func(v int) uint64 { return uint64(v % 7) }
+		eq   = func(a, b int) bool { return a == b }
+	)
+
+	type testCase struct {
+		name         string
+		isConcurrent bool
+		opts         []Option
+	}
+	tests := []testCase{
+		{name: "TestSkipList_FindByValue 1", isConcurrent: false},
+		{name: "TestSkipList_FindByValue 2", isConcurrent: true},
+		{name: "TestSkipList_FindByValue 3", isConcurrent: false, opts: []Option{WithTombstones()}},
+		{name: "TestSkipList_FindByValue 4", isConcurrent: false, opts: []Option{WithFinger()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				rnd = rand.New(rand.NewSource(1))
+				sl  = NewSkipList[int, int](0, tt.isConcurrent, append(tt.opts, WithValueIndex(hash, eq))...)
+				ref = make(map[int]int)
+			)
+			for i := 0; i < 20000; i++ {
+				key, val := rnd.Intn(300), rnd.Intn(50)
+				switch op := rnd.Intn(100); {
+				case op < 60:
+					// mostly overwrites of existing keys
+					sl.Put(key, val)
+					ref[key] = val
+				case op < 75:
+					if old, exist := ref[key]; exist && sl.CompareAndSwap(key, old, val, eq) {
+						ref[key] = val
+					}
+				case op < 90:
+					sl.Delete(key)
+					delete(ref, key)
+				case op < 95:
+					sl.DeleteRange(key, key+10)
+					for k := key; k <= key+10; k++ {
+						delete(ref, k)
+					}
+				case op < 99:
+					sl.DeleteIf(func(_ int, v int) bool { return v == val })
+					for k, v := range ref {
+						if v == val {
+							delete(ref, k)
+						}
+					}
+				default:
+					if rnd.Intn(2) == 0 {
+						sl.Clear()
+						ref = make(map[int]int)
+					} else {
+						pairs := sl.Items()
+						for _, kv := range pairs {
+							kv.val++
+							ref[kv.key]++
+						}
+						sl.ReplaceAll(pairs)
+					}
+				}
+
+				if i%100 == 0 {
+					testFindByValue(t, sl, ref)
+				}
+			}
+			testFindByValue(t, sl, ref)
+		})
+	}
+}
+
+func TestSkipList_FindByValue_Copies(t *testing.T) {
+	var (
+		hash = func(s string) uint64 { return uint64(len(s)) }
+		eq   = func(a, b string) bool { return a == b }
+		sl   = NewSkipList[int, string](0, false, WithValueIndex(hash, eq))
+	)
+	for i, v := range []string{"a", "b", "a", "cc", "dd", "a"} {
+		sl.Put(i, v)
+	}
+	if got, want := sl.FindByValue("a"), []int{0, 2, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() = %v, want %v", got, want)
+	}
+	if got, want := sl.FindByValue("dd"), []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() = %v, want %v", got, want)
+	}
+	if got := sl.FindByValue("e"); len(got) != 0 {
+		t.Errorf("FindByValue() of a colliding absent value = %v, want none", got)
+	}
+
+	// an overwrite unindexes the old value
+	sl.Put(2, "b")
+	if got, want := sl.FindByValue("a"), []int{0, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() after an overwrite = %v, want %v", got, want)
+	}
+	if got, want := sl.FindByValue("b"), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() after an overwrite = %v, want %v", got, want)
+	}
+
+	// the copies keep the index of their own nodes
+	odd, _ := sl.Partition(func(key int, _ string) bool { return key%2 == 1 })
+	if got, want := odd.FindByValue("a"), []int{5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() of a partition = %v, want %v", got, want)
+	}
+	loaded := BulkLoad[int, string](0, false, sl.Items(), WithValueIndex(hash, eq))
+	if got, want := loaded.FindByValue("b"), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindByValue() of BulkLoad = %v, want %v", got, want)
+	}
+
+	// in-place edits are reindexed by Reindex
+	ref, _ := sl.GetRef(0)
+	*ref = "z"
+	sl.Reindex()
+	if !sl.ContainsValue("z") || !reflect.DeepEqual(sl.FindByValue("a"), []int{5}) {
+		t.Errorf("FindByValue() after Reindex = %v, want [5]", sl.FindByValue("a"))
+	}
+
+	// without the index
+	plain := NewSkipList[int, string](0, false)
+	plain.Put(1, "a")
+	if plain.FindByValue("a") != nil || plain.ContainsValue("a") {
+		t.Errorf("FindByValue() without WithValueIndex found a value")
+	}
+	if mismatched := NewSkipList[int, int](0, false, WithValueIndex(hash, eq)); mismatched != nil {
+		t.Errorf("NewSkipList() with a mismatched WithValueIndex = %v, want nil", mismatched)
+	}
+}
+
+// testFindByValue checks FindByValue and ContainsValue of sl for every value against ref.
+func testFindByValue(t *testing.T, sl *SkipList[int, int], ref map[int]int) {
+	t.Helper()
+
+	var want = make(map[int][]int)
+	for key, val := range ref {
+		want[val] = append(want[val], key)
+	}
+	for val := -1; val <= 60; val++ {
+		keys := want[val]
+		sort.Ints(keys)
+		if keys == nil {
+			keys = make([]int, 0)
+		}
+		if got := sl.FindByValue(val); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("FindByValue(%v) = %v, want %v", val, got, keys)
+		}
+		if got := sl.ContainsValue(val); got != (len(keys) > 0) {
+			t.Fatalf("ContainsValue(%v) = %v, want %v", val, got, len(keys) > 0)
+		}
+	}
+
+	// no stale keys are left in the index
+	var indexed int
+	for _, set := range sl.values.keys {
+		indexed += len(set)
+	}
+	if indexed != len(ref) {
+		t.Fatalf("%d keys indexed, want %d", indexed, len(ref))
+	}
+}
@@ -0,0 +1,246 @@
+package skip_list
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+// A log is a sequence of records, one per mutation in the order they are applied.
+//
+//	record:  payload length uvarint | payload | crc u32
+//	payload: op | fields
+//
+// Each field is its length uvarint followed by its bytes in the binary form of keys and values. The fields of
+// logPut are the key and the value, of logDelete the key, of logDeleteRange start and end, and logClear has none.
+// crc is the little-endian CRC-32 (IEEE) of the payload.
+const (
+	logPut byte = iota + 1
+	logDelete
+	logDeleteRange
+	logClear
+)
+
+var ErrLogAttached = errors.New("skip_list: a log is already attached")
+
+type (
+	// opLog appends the records of mutations to w until a write fails.
+	opLog[O constraints.Ordered, T any] struct {
+		w     io.Writer
+		codec Codec[T]
+
+		// records are not appended while muted, and err is the first failing write
+		muted bool
+		err   error
+
+		payload, buf []byte
+	}
+)
+
+// AttachLog appends a record to w for every mutation from now on, such as Put, Delete, DeleteRange and Clear,
+// so that Replay of the records into a SkipList loaded from a snapshot reproduces SkipList. Each record is written by
+// one call of w.Write while holding the lock, and a failing write drops the records after it, which keeps w
+// a prefix of the mutations; DetachLog returns the error. It returns ErrLogAttached if a log is attached.
+func (sl *SkipList[O, T]) AttachLog(w io.Writer) error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	if sl.log != nil {
+		return ErrLogAttached
+	}
+	sl.log = &opLog[O, T]{w: w, codec: sl.codec}
+	return nil
+}
+
+// DetachLog stops appending records to the log attached by AttachLog, and returns the first error of writing them.
+func (sl *SkipList[O, T]) DetachLog() error {
+	if sl == nil {
+		return nil
+	}
+
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	l := sl.log
+	sl.log = nil
+	if l == nil {
+		return nil
+	}
+	return l.err
+}
+
+// Replay applies the records of a log AttachLog writes read from r to into in order, and returns the number of
+// records applied. A torn final record, as a crash while appending it leaves, ends the log without an error.
+// A checksum mismatch of any other record returns ErrChecksum, and a malformed record ErrCorruptBinary,
+// after applying the records before it.
+func Replay[O constraints.Ordered, T any](r io.Reader, into *SkipList[O, T]) (int, error) {
+	if into == nil {
+		return 0, nil
+	}
+	if into.head == nil {
+		return 0, ErrNotInitialized
+	}
+
+	var (
+		br      = bufio.NewReader(r)
+		cr      = &countingReader{r: br}
+		payload bytes.Buffer
+		crc     = make([]byte, 4)
+		applied int
+	)
+	// fail returns the error of a failing read of record i, or nil if it is torn
+	fail := func(i int) error {
+		switch {
+		case cr.err != nil:
+			return fmt.Errorf("record %d: %w", i, cr.err)
+		case cr.eof:
+			return nil
+		}
+		return fmt.Errorf("%w: length of record %d", ErrCorruptBinary, i)
+	}
+	for ; ; applied++ {
+		if err := readBinaryField(cr, &payload); err != nil {
+			return applied, fail(applied)
+		}
+		if _, err := io.ReadFull(cr, crc); err != nil {
+			return applied, fail(applied)
+		}
+		if binary.LittleEndian.Uint32(crc) != crc32.ChecksumIEEE(payload.Bytes()) {
+			if _, err := br.Peek(1); err == io.EOF {
+				// torn
+				return applied, nil
+			}
+			return applied, fmt.Errorf("%w: record %d", ErrChecksum, applied)
+		}
+
+		if err := applyRecord(into, payload.Bytes(), into.codec.Decode); err != nil {
+			return applied, fmt.Errorf("%w: record %d", err, applied)
+		}
+	}
+}
+
+// applyRecord applies the mutation of a verified payload to sl.
+func applyRecord[O constraints.Ordered, T any](sl *SkipList[O, T], payload []byte, decodeVal func(b []byte) (T, error)) error {
+	if len(payload) == 0 {
+		return ErrCorruptBinary
+	}
+	var (
+		op     = payload[0]
+		fields [][]byte
+	)
+	for b := payload[1:]; len(b) > 0; {
+		n, k := binary.Uvarint(b)
+		if k <= 0 || n > uint64(len(b)-k) {
+			return ErrCorruptBinary
+		}
+		fields = append(fields, b[k:k+int(n)])
+		b = b[k+int(n):]
+	}
+
+	var keys []O
+	for i, f := range fields {
+		if op == logPut && i == 1 {
+			// value
+			break
+		}
+		var key O
+		if err := decodeBuiltin(f, reflect.ValueOf(&key).Elem()); err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	switch {
+	case op == logPut && len(fields) == 2:
+		val, err := decodeVal(fields[1])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrCorruptBinary, err)
+		}
+		sl.Put(keys[0], val)
+	case op == logDelete && len(fields) == 1:
+		sl.Delete(keys[0])
+	case op == logDeleteRange && len(fields) == 2:
+		sl.DeleteRange(keys[0], keys[1])
+	case op == logClear && len(fields) == 0:
+		sl.Clear()
+	default:
+		return fmt.Errorf("%w: op %d with %d fields", ErrCorruptBinary, op, len(fields))
+	}
+	return nil
+}
+
+func (l *opLog[O, T]) put(key O, val T) {
+	if !l.recording() {
+		return
+	}
+
+	b, err := l.codec.Encode(val)
+	if err != nil {
+		l.err = fmt.Errorf("key %v: %w", key, err)
+		return
+	}
+	l.append(logPut, appendBuiltin(nil, reflect.ValueOf(key)), b)
+}
+
+func (l *opLog[O, T]) delete(key O) {
+	if l.recording() {
+		l.append(logDelete, appendBuiltin(nil, reflect.ValueOf(key)))
+	}
+}
+
+func (l *opLog[O, T]) deleteRange(start, end O) {
+	if l.recording() {
+		l.append(logDeleteRange, appendBuiltin(nil, reflect.ValueOf(start)), appendBuiltin(nil, reflect.ValueOf(end)))
+	}
+}
+
+func (l *opLog[O, T]) clear() {
+	if l.recording() {
+		l.append(logClear)
+	}
+}
+
+// mute stops appending records until the returned function is called, for a mutation recorded as a whole.
+func (l *opLog[O, T]) mute() func() {
+	if l == nil || l.muted {
+		return func() {}
+	}
+	l.muted = true
+	return func() { l.muted = false }
+}
+
+// recording returns whether l is attached, not muted and has not failed.
+func (l *opLog[O, T]) recording() bool {
+	return l != nil && !l.muted && l.err == nil
+}
+
+// append writes the record of op and fields to w by one call of w.Write.
+func (l *opLog[O, T]) append(op byte, fields ...[]byte) {
+	l.payload = append(l.payload[:0], op)
+	for _, f := range fields {
+		l.payload = binary.AppendUvarint(l.payload, uint64(len(f)))
+		l.payload = append(l.payload, f...)
+	}
+
+	l.buf = binary.AppendUvarint(l.buf[:0], uint64(len(l.payload)))
+	l.buf = append(l.buf, l.payload...)
+	l.buf = binary.LittleEndian.AppendUint32(l.buf, crc32.ChecksumIEEE(l.payload))
+	if _, err := l.w.Write(l.buf); err != nil {
+		l.err = err
+	}
+}
@@ -0,0 +1,183 @@
+package skip_list
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSkipList_AttachLog(t *testing.T) {
+	t.Run("TestSkipList_AttachLog 1", func(t *testing.T) {
+		// every prefix of the log replays the mutations of the records in it
+		var (
+			log  bytes.Buffer
+			sl   = NewSkipList[int, string](10, false)
+			ends []int
+			want [][]*KvPair[int, string]
+		)
+		if err := sl.AttachLog(&log); err != nil {
+			t.Fatalf("AttachLog() error = %v", err)
+		}
+		record := func() {
+			if len(ends) > 0 && ends[len(ends)-1] == log.Len() {
+				// a no-op is not recorded
+				return
+			}
+			ends = append(ends, log.Len())
+			want = append(want, sl.Items())
+		}
+		for i := 0; i < 40; i++ {
+			sl.Put(i%17, string(rune('a'+i%26)))
+			record()
+			if i%5 == 4 {
+				sl.Delete(i % 7)
+				record()
+			}
+			if i%13 == 12 {
+				sl.DeleteRange(i%10, i%10+3)
+				record()
+			}
+			if i == 30 {
+				sl.Clear()
+				record()
+			}
+		}
+		if err := sl.DetachLog(); err != nil {
+			t.Fatalf("DetachLog() error = %v", err)
+		}
+		sl.Put(100, "detached")
+		if log.Len() != ends[len(ends)-1] {
+			t.Fatalf("log has %d bytes after DetachLog(), want %d", log.Len(), ends[len(ends)-1])
+		}
+
+		data := log.Bytes()
+		for k := 0; k <= len(data); k++ {
+			var (
+				applied int
+				items   = []*KvPair[int, string]{}
+			)
+			for applied < len(ends) && ends[applied] <= k {
+				items = want[applied]
+				applied++
+			}
+
+			dst := NewSkipList[int, string](10, false)
+			n, err := Replay(bytes.NewReader(data[:k]), dst)
+			if err != nil || n != applied {
+				t.Fatalf("Replay() of %d bytes = %v, %v, want %v, nil", k, n, err, applied)
+			}
+			if got := dst.Items(); !reflect.DeepEqual(got, items) {
+				t.Fatalf("Items() after Replay() of %d bytes = %v, want %v", k, got, items)
+			}
+		}
+	})
+
+	t.Run("TestSkipList_AttachLog 2", func(t *testing.T) {
+		// a snapshot and the log after it recover the list
+		var (
+			path = filepath.Join(t.TempDir(), "snapshot")
+			log  bytes.Buffer
+			sl   = NewSkipList[string, []byte](0, true, WithTombstones())
+		)
+		for i := 0; i < 100; i++ {
+			sl.Put(string(rune('a'+i%26))+string(rune('0'+i%10)), []byte{byte(i)})
+		}
+		if err := sl.SaveFile(path); err != nil {
+			t.Fatalf("SaveFile() error = %v", err)
+		}
+		if err := sl.AttachLog(&log); err != nil {
+			t.Fatalf("AttachLog() error = %v", err)
+		}
+		if err := sl.AttachLog(&log); !errors.Is(err, ErrLogAttached) {
+			t.Errorf("AttachLog() error = %v, want %v", err, ErrLogAttached)
+		}
+
+		// mutations other than Put and Delete are recorded per node
+		sl.PopMin()
+		sl.DeleteIf(func(key string, _ []byte) bool { return key[1] == '3' })
+		sl.CompareAndSwap("b1", []byte{27}, []byte("swapped"), bytes.Equal)
+		sl.ReplaceAll(append(sl.Items()[5:], NewKvPair("zz", []byte("new"))))
+		sl.Put("zz", nil)
+
+		dst, err := LoadFile[string, []byte](path, 0, false)
+		if err != nil {
+			t.Fatalf("LoadFile() error = %v", err)
+		}
+		if _, err := Replay(bytes.NewReader(log.Bytes()), dst); err != nil {
+			t.Fatalf("Replay() error = %v", err)
+		}
+		if got, want := dst.Items(), sl.Items(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Items() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestSkipList_AttachLog 3", func(t *testing.T) {
+		// a failing write keeps the log a prefix
+		var (
+			sl = NewSkipList[int, int](10, false)
+			w  = &failingWriter{n: 30}
+		)
+		if err := sl.AttachLog(w); err != nil {
+			t.Fatalf("AttachLog() error = %v", err)
+		}
+		for i := 0; i < 20; i++ {
+			sl.Put(i, i)
+		}
+		if err := sl.DetachLog(); !errors.Is(err, errFailingWriter) {
+			t.Errorf("DetachLog() error = %v, want %v", err, errFailingWriter)
+		}
+		if err := sl.DetachLog(); err != nil {
+			t.Errorf("DetachLog() error = %v, want nil", err)
+		}
+
+		if err := NewSkipList[int, binaryValue](10, false).AttachLog(w); err != nil {
+			t.Errorf("AttachLog() of values by JSONCodec error = %v", err)
+		}
+	})
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		log bytes.Buffer
+		sl  = NewSkipList[int, string](10, false)
+	)
+	_ = sl.AttachLog(&log)
+	for i := 0; i < 5; i++ {
+		sl.Put(i, "v")
+	}
+	data := log.Bytes()
+	size := len(data) / 5
+
+	flip := func(i int) []byte {
+		b := append([]byte(nil), data...)
+		b[i] ^= 0x40
+		return b
+	}
+
+	type testCase struct {
+		name        string
+		data        []byte
+		into        *SkipList[int, string]
+		wantApplied int
+		wantErr     error
+	}
+	testCases := []testCase{
+		{name: "TestReplay 1", data: data, into: NewSkipList[int, string](10, false), wantApplied: 5},
+		{name: "TestReplay 2", data: flip(2*size + 2), into: NewSkipList[int, string](10, false), wantApplied: 2, wantErr: ErrChecksum},
+		{name: "TestReplay 3", data: flip(len(data) - 1), into: NewSkipList[int, string](10, false), wantApplied: 4},
+		{name: "TestReplay 4", data: append(append([]byte(nil), data...), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01), into: NewSkipList[int, string](10, false), wantApplied: 5, wantErr: ErrCorruptBinary},
+		{name: "TestReplay 5", data: append(append(append([]byte(nil), data...), 1, 9, 0, 0, 0, 0), data[:size]...), into: NewSkipList[int, string](10, false), wantApplied: 5, wantErr: ErrChecksum},
+		{name: "TestReplay 6", data: data, into: &SkipList[int, string]{}, wantErr: ErrNotInitialized},
+		{name: "TestReplay 7", data: nil, into: NewSkipList[int, string](10, false)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := Replay(bytes.NewReader(tc.data), tc.into)
+			if n != tc.wantApplied || !errors.Is(err, tc.wantErr) {
+				t.Errorf("Replay() = %v, %v, want %v, %v", n, err, tc.wantApplied, tc.wantErr)
+			}
+		})
+	}
+}
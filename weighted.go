@@ -0,0 +1,48 @@
+package skip_list
+
+import "golang.org/x/exp/constraints"
+
+// WeightedRandom returns *KvPair of a random node picked with probability proportional to its value, drawn from the rand source of sl.
+// Nodes of zero value are never picked. It returns nil and false if sl is empty, the values sum to zero,
+// or any value is negative or NaN, since such weights define no distribution.
+func WeightedRandom[O constraints.Ordered, T constraints.Integer | constraints.Float](sl *SkipList[O, T]) (*KvPair[O, T], bool) {
+	if sl == nil {
+		return nil, false
+	}
+
+	// the rand source is not safe for concurrent use
+	if sl.isConcurrent {
+		sl.Lock()
+		defer sl.Unlock()
+	}
+
+	var total float64
+	for n := sl.head.next(); n != nil; n = n.next() {
+		w := float64(n.val)
+		if w < 0 || w != w {
+			return nil, false
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, false
+	}
+
+	var (
+		x    = sl.r.Float64() * total
+		sum  float64
+		last *node[O, T]
+	)
+	for n := sl.head.next(); n != nil; n = n.next() {
+		if n.val == 0 {
+			continue
+		}
+		sum += float64(n.val)
+		if x < sum {
+			return &KvPair[O, T]{key: n.key, val: n.val}, true
+		}
+		last = n
+	}
+	// x rounded up to total
+	return &KvPair[O, T]{key: last.key, val: last.val}, true
+}
@@ -0,0 +1,78 @@
+package skip_list
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedRandom(t *testing.T) {
+	type testCase struct {
+		name    string
+		vals    map[int]float64
+		wantNil bool
+	}
+	tests := []testCase{
+		{
+			name:    "TestWeightedRandom 1",
+			wantNil: true,
+		},
+		{
+			name:    "TestWeightedRandom 2",
+			vals:    map[int]float64{1: 0, 2: 0},
+			wantNil: true,
+		},
+		{
+			name:    "TestWeightedRandom 3",
+			vals:    map[int]float64{1: 1, 2: -1},
+			wantNil: true,
+		},
+		{
+			name:    "TestWeightedRandom 4",
+			vals:    map[int]float64{1: 1, 2: math.NaN()},
+			wantNil: true,
+		},
+		{
+			name: "TestWeightedRandom 5",
+			vals: map[int]float64{1: 0, 2: 0.5, 3: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSkipList[int, float64](10, true)
+			for k, v := range tt.vals {
+				sl.Put(k, v)
+			}
+			got, ok := WeightedRandom(sl)
+			if gotNil := got == nil; gotNil != tt.wantNil || ok == tt.wantNil {
+				t.Fatalf("WeightedRandom() = %v, %v, want nil %v", got, ok, tt.wantNil)
+			}
+			if got != nil && got.val == 0 {
+				t.Errorf("WeightedRandom() = %v of zero weight", got)
+			}
+		})
+	}
+
+	t.Run("TestWeightedRandom 6", func(t *testing.T) {
+		const draws = 100000
+
+		var (
+			sl      = NewSkipList[string, int](10, false, WithRandSource(rand.NewSource(1)))
+			weights = map[string]int{"a": 1, "b": 2, "c": 3, "d": 0, "e": 4}
+			counts  = make(map[string]int)
+		)
+		for k, w := range weights {
+			sl.Put(k, w)
+		}
+		for i := 0; i < draws; i++ {
+			kv, _ := WeightedRandom(sl)
+			counts[kv.key]++
+		}
+		for k, w := range weights {
+			want := float64(draws) * float64(w) / 10
+			if got := float64(counts[k]); math.Abs(got-want) > 0.05*float64(draws)/10+1 {
+				t.Errorf("WeightedRandom() picked %v %v times, want about %v", k, got, want)
+			}
+		}
+	})
+}